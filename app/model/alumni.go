@@ -1,34 +1,96 @@
 package model
 
 import (
+	"time"
+
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type Alumni struct {
     ID         primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
-    NIM string `bson:"nim" json:"nim"`
-    Nama       string             `bson:"nama" json:"nama"`
-    Jurusan    string             `bson:"jurusan" json:"jurusan"`
-    Angkatan   int                `bson:"angkatan" json:"angkatan"`
-    TahunLulus int                `bson:"tahun_lulus" json:"tahun_lulus"`
-    Email      string             `bson:"email" json:"email"`
-    NoTelepon  int             `bson:"no_telepon,omitempty" json:"no_telepon,omitempty"`
-    Alamat     string             `bson:"alamat,omitempty" json:"alamat,omitempty"`
-    CreatedAt  string          `bson:"created_at" json:"created_at"`
-    UpdatedAt  string         `bson:"updated_at" json:"updated_at"`
+    LegacyID   int                `bson:"id,omitempty" json:"-"` // id numerik lama, dirujuk pekerjaan.alumni_id
+    NIM        string             `bson:"nim" json:"nim" validate:"required,nim"`
+    Nama       string             `bson:"nama" json:"nama" validate:"required,max=150"`
+    Jurusan    string             `bson:"jurusan" json:"jurusan" validate:"required,jurusan"`
+    Angkatan   int                `bson:"angkatan" json:"angkatan" validate:"required,year"`
+    TahunLulus int                `bson:"tahun_lulus" json:"tahun_lulus" validate:"required,year,gtefield=Angkatan"`
+    Email      string             `bson:"email" json:"email" validate:"required,email"`
+    NoTelepon  string             `bson:"no_telepon,omitempty" json:"no_telepon,omitempty" validate:"phone"` // E.164, mis. +6281234567890
+    Alamat     string             `bson:"alamat,omitempty" json:"alamat,omitempty" validate:"max=255"`
+    Custom     map[string]any     `bson:"custom,omitempty" json:"custom,omitempty"` // nilai custom field, key = CustomField.Name
+    Tags       []string           `bson:"tags,omitempty" json:"tags,omitempty" validate:"max=20,tags"` // huruf kecil, tanpa duplikat
+    CreatedAt  string             `bson:"created_at" json:"created_at"`
+    UpdatedAt  string             `bson:"updated_at" json:"updated_at"`
+    Version    int64              `bson:"version" json:"version"` // naik setiap perubahan, dipakai sebagai ETag
+    DeletedAt  *time.Time         `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"` // terisi jika ada di trash
+    DeletedBy  string             `bson:"deleted_by,omitempty" json:"deleted_by,omitempty"` // user_id yang menghapus
+    MergedInto *primitive.ObjectID `bson:"merged_into,omitempty" json:"merged_into,omitempty"` // alumni tujuan jika dihapus karena merge
+    PurgesAt   *time.Time         `bson:"-" json:"purges_at,omitempty"`                     // hanya di listing trash
+    Score      float64            `bson:"score,omitempty" json:"score,omitempty"`           // relevansi, hanya di hasil search_mode=text
+}
+
+// AlumniDetail - alumni beserta relasi yang diminta lewat ?include=. Relasi
+// yang tidak diminta tidak muncul di JSON; yang diminta tapi kosong menjadi [].
+type AlumniDetail struct {
+    Alumni    `bson:",inline"`
+    Pekerjaan *[]Pekerjaan `bson:"pekerjaan,omitempty" json:"pekerjaan,omitempty"`
+    Files     *[]File      `bson:"files,omitempty" json:"files,omitempty"`
 }
 
 type MetaInfo struct {
-    Page   int    `json:"page"`
-    Limit  int    `json:"limit"`
-    Total  int    `json:"total"`
-    Pages  int    `json:"pages"`
-    SortBy string `json:"sortBy"`
-    Order  string `json:"order"`
-    Search string `json:"search"`
+    Page       int           `json:"page"`
+    Limit      int           `json:"limit"`
+    Total      int           `json:"total"`
+    Pages      int           `json:"pages"`
+    SortBy     string        `json:"sortBy"`
+    Order      string        `json:"order"`
+    Search     string        `json:"search"`
+    SearchMode string        `json:"search_mode,omitempty"` // contains / text (daftar alumni)
+    Filter     *AlumniFilter `json:"filter,omitempty"`      // filter yang diterapkan (daftar alumni)
+}
+
+const (
+    // AlumniSearchContains - potongan teks di nama/jurusan/email (tanpa peduli
+    // huruf besar/kecil dan diakritik) atau awalan NIM
+    AlumniSearchContains = "contains"
+    // AlumniSearchText - text index MongoDB per kata, bisa diurutkan berdasarkan relevansi
+    AlumniSearchText = "text"
+)
+
+// AlumniSearch - kata kunci pencarian daftar alumni dan cara mencocokkannya
+type AlumniSearch struct {
+    Query string
+    Mode  string
+}
+
+// AlumniSuggestion - satu hasil autocomplete /alumni/suggest
+type AlumniSuggestion struct {
+    ID      primitive.ObjectID `bson:"_id" json:"id"`
+    NIM     string             `bson:"nim" json:"nim"`
+    Nama    string             `bson:"nama" json:"nama"`
+    Jurusan string             `bson:"jurusan" json:"jurusan"`
+}
+
+// AlumniFilter - filter terstruktur daftar alumni. Nama field JSON sama
+// dengan nama query parameter; field kosong berarti tidak difilter.
+type AlumniFilter struct {
+    Jurusan           []string `json:"jurusan,omitempty"`
+    AngkatanMin       int      `json:"angkatan_min,omitempty"`
+    AngkatanMax       int      `json:"angkatan_max,omitempty"`
+    TahunLulusMin     int      `json:"tahun_lulus_min,omitempty"`
+    TahunLulusMax     int      `json:"tahun_lulus_max,omitempty"`
+    HasEmail          *bool    `json:"has_email,omitempty"`
+    CreatedFrom       string   `json:"created_from,omitempty"` // YYYY-MM-DD, inklusif
+    CreatedTo         string   `json:"created_to,omitempty"`
+    UpdatedFrom       string   `json:"updated_from,omitempty"`
+    UpdatedTo         string   `json:"updated_to,omitempty"`
+    HasPekerjaan      *bool    `json:"has_pekerjaan,omitempty"`
+    CurrentlyEmployed *bool    `json:"currently_employed,omitempty"` // punya pekerjaan tanpa tanggal selesai / belum selesai
+    Custom            []CustomFilter `json:"custom,omitempty"`             // ?custom.<name>=, urut nama field
+    Tags              []string       `json:"tag,omitempty"`                // ?tag=, alumni harus punya semua tag
 }
 
 type AlumniResponse struct {
-    Data []Alumni `json:"data"`
+    Data []AlumniDetail `json:"data"`
     Meta MetaInfo `json:"meta"`
 }
@@ -0,0 +1,74 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Aksi di audit log
+const (
+    AuditLogin        = "login"
+    AuditLoginFailed  = "login_failed"
+    AuditExport       = "export"
+    AuditImport       = "import"
+    AuditFileDownload = "file_download"
+    AuditRoleChange   = "role_change"
+    AuditHardDelete   = "hard_delete"
+    AuditTrashPurge   = "trash_purge" // purge trash otomatis oleh scheduler
+    AuditMerge        = "merge"       // alumni duplikat digabung
+    AuditBulkTag      = "bulk_tag"    // tag ditambah/dihapus pada alumni hasil filter
+)
+
+// Resource di audit log selain yang ada di riwayat record
+const (
+    ResourceFiles        = "files"
+    ResourceAuditLog     = "audit_log"
+    ResourceCustomFields = "custom_fields"
+    ResourceSegments     = "segments"
+)
+
+// AuditSystemActor - actor_id untuk aksi yang dijalankan server sendiri
+const AuditSystemActor = "system"
+
+// AuditEntry - satu kejadian di audit log. Entri tidak pernah diubah atau
+// dihapus; Hash dihitung dari isi entri dan PrevHash (hash entri sebelumnya)
+// sehingga perubahan pada entri lama memutus rantai.
+type AuditEntry struct {
+    ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+    Seq        int64              `bson:"seq" json:"seq"` // urutan di rantai, mulai dari 1
+    At         time.Time          `bson:"at" json:"at"`
+    ActorID    string             `bson:"actor_id" json:"actor_id"`
+    ActorName  string             `bson:"actor_name,omitempty" json:"actor_name,omitempty"`
+    ActorRole  string             `bson:"actor_role,omitempty" json:"actor_role,omitempty"`
+    Action     string             `bson:"action" json:"action"`
+    Resource   string             `bson:"resource" json:"resource"`
+    ResourceID string             `bson:"resource_id,omitempty" json:"resource_id,omitempty"`
+    Status     int                `bson:"status,omitempty" json:"status,omitempty"` // status HTTP; kosong untuk aksi di luar request
+    Method     string             `bson:"method,omitempty" json:"method,omitempty"`
+    Path       string             `bson:"path,omitempty" json:"path,omitempty"`
+    IP         string             `bson:"ip,omitempty" json:"ip,omitempty"`
+    RequestID  string             `bson:"request_id,omitempty" json:"request_id,omitempty"`
+    Detail     map[string]string  `bson:"detail,omitempty" json:"detail,omitempty"`
+    PrevHash   string             `bson:"prev_hash" json:"prev_hash"`
+    Hash       string             `bson:"hash" json:"hash"`
+}
+
+// AuditFilter - filter query audit log. Actor cocok dengan actor_id atau
+// actor_name; From/To berupa tanggal YYYY-MM-DD (To inklusif).
+type AuditFilter struct {
+    Actor    string `json:"actor,omitempty"`
+    Action   string `json:"action,omitempty"`
+    Resource string `json:"resource,omitempty"`
+    From     string `json:"from,omitempty"`
+    To       string `json:"to,omitempty"`
+}
+
+// AuditVerifyResult - hasil pemeriksaan rantai hash audit log
+type AuditVerifyResult struct {
+    Valid     bool   `json:"valid"`
+    Checked   int64  `json:"checked"`              // jumlah entri yang diperiksa
+    BrokenSeq int64  `json:"broken_seq,omitempty"` // entri pertama yang hash atau urutannya tidak cocok
+    LastSeq   int64  `json:"last_seq,omitempty"`
+    LastHash  string `json:"last_hash,omitempty"` // simpan di luar sistem untuk mendeteksi entri terakhir yang dihapus
+}
@@ -0,0 +1,45 @@
+package model
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// Tipe nilai custom field
+const (
+    CustomFieldString = "string"
+    CustomFieldNumber = "number"
+    CustomFieldDate   = "date" // YYYY-MM-DD
+    CustomFieldEnum   = "enum" // salah satu Options
+    CustomFieldURL    = "url"  // http/https
+)
+
+// Siapa yang boleh melihat nilai custom field
+const (
+    CustomFieldPublic = "public" // semua user yang login
+    CustomFieldAdmin  = "admin"  // hanya admin
+)
+
+// CustomField - definisi atribut tambahan alumni yang dibuat admin. Nilainya
+// disimpan di alumni.custom.<name>. Name dan Type tidak bisa diubah setelah
+// dibuat karena dipakai oleh data yang sudah tersimpan.
+type CustomField struct {
+    ID         primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+    Name       string             `bson:"name" json:"name" validate:"required,max=40,slug"`
+    Label      string             `bson:"label,omitempty" json:"label,omitempty" validate:"max=100"`
+    Type       string             `bson:"type" json:"type" validate:"required,oneof=string number date enum url"`
+    Options    []string           `bson:"options,omitempty" json:"options,omitempty"` // pilihan untuk type enum
+    Required   bool               `bson:"required" json:"required"`
+    Visibility string             `bson:"visibility" json:"visibility" validate:"required,oneof=public admin"`
+    CreatedAt  string             `bson:"created_at" json:"created_at"`
+    UpdatedAt  string             `bson:"updated_at" json:"updated_at"`
+    Version    int64              `bson:"version" json:"version"`
+}
+
+// CustomFilter - filter daftar alumni pada satu custom field
+// (?custom.<name>=). Number dan date memakai rentang inklusif Min/Max; tipe
+// lain cocok jika nilainya sama dengan salah satu Values.
+type CustomFilter struct {
+    Name   string   `json:"name"`
+    Type   string   `json:"type"`
+    Values []string `json:"values,omitempty"`
+    Min    string   `json:"min,omitempty"`
+    Max    string   `json:"max,omitempty"`
+}
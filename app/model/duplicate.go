@@ -0,0 +1,69 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Alasan pasangan dianggap duplikat
+const (
+    DuplicateByNIM   = "nim"
+    DuplicateByEmail = "email"
+    DuplicateByPhone = "no_telepon"
+    DuplicateByName  = "nama"
+)
+
+// DuplicatePair - dua alumni aktif yang kemungkinan orang yang sama
+type DuplicatePair struct {
+    Score          int      `json:"score"`           // 0-100, makin besar makin mirip
+    Reasons        []string `json:"reasons"`         // field yang cocok
+    NameSimilarity float64  `json:"name_similarity"` // 0-1 (Jaro-Winkler nama yang dinormalisasi)
+    A              Alumni   `json:"a"`
+    B              Alumni   `json:"b"`
+}
+
+type DuplicateResponse struct {
+    Data []DuplicatePair `json:"data"`
+    Meta DuplicateMeta   `json:"meta"`
+}
+
+type DuplicateMeta struct {
+    Total    int `json:"total"` // semua pasangan di atas min_score
+    Limit    int `json:"limit"`
+    MinScore int `json:"min_score"`
+}
+
+// DuplicateDismissal - pasangan yang sudah diperiksa admin dan bukan
+// duplikat; A selalu ID yang lebih kecil
+type DuplicateDismissal struct {
+    ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+    A           primitive.ObjectID `bson:"a" json:"a"`
+    B           primitive.ObjectID `bson:"b" json:"b"`
+    DismissedBy string             `bson:"dismissed_by" json:"dismissed_by"`
+    DismissedAt time.Time          `bson:"dismissed_at" json:"dismissed_at"`
+}
+
+// DismissDuplicateRequest - body POST /admin/alumni/duplicates/dismiss
+type DismissDuplicateRequest struct {
+    A string `json:"a" validate:"required"`
+    B string `json:"b" validate:"required"`
+}
+
+// MergeRequest - body POST /alumni/{id}/merge. Fields memilih sumber nilai
+// per field ("survivor" atau "duplicate"); field yang tidak disebut memakai
+// nilai survivor, atau nilai duplikat jika survivor kosong.
+type MergeRequest struct {
+    DuplicateID string            `json:"duplicate_id" validate:"required"`
+    Fields      map[string]string `json:"fields,omitempty"`
+}
+
+// MergeResult - hasil merge duplikat ke survivor
+type MergeResult struct {
+    Survivor           Alumni             `json:"survivor"`
+    DuplicateID        primitive.ObjectID `json:"duplicate_id"`
+    TakenFromDuplicate []string           `json:"taken_from_duplicate"`
+    PekerjaanMoved     int64              `json:"pekerjaan_moved"`
+    UsersMoved         int64              `json:"users_moved"`
+    FilesMoved         int64              `json:"files_moved"` // file milik akun user yang dipindah
+}
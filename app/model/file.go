@@ -16,4 +16,6 @@ type File struct {
     FileType     string             `bson:"file_type" json:"file_type"`
     Category     string             `bson:"category" json:"category"` // "foto" atau "sertifikat"
     UploadedAt   time.Time          `bson:"uploaded_at" json:"uploaded_at"`
+    VerifiedAt   *time.Time         `bson:"verified_at,omitempty" json:"verified_at,omitempty"` // sertifikat yang sudah diperiksa admin
+    VerifiedBy   string             `bson:"verified_by,omitempty" json:"verified_by,omitempty"` // user_id admin yang memverifikasi
 }
@@ -0,0 +1,13 @@
+package model
+
+type HealthCheck struct {
+    Name      string  `json:"name"`
+    Status    string  `json:"status"` // "ok" atau "fail"
+    LatencyMs float64 `json:"latency_ms"`
+    Error     string  `json:"error,omitempty"`
+}
+
+type HealthResponse struct {
+    Status string        `json:"status"`
+    Checks []HealthCheck `json:"checks,omitempty"`
+}
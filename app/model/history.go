@@ -0,0 +1,50 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Resource yang perubahannya dicatat di riwayat
+const (
+    ResourceAlumni    = "alumni"
+    ResourcePekerjaan = "pekerjaan"
+    ResourceUsers     = "users"
+)
+
+// Aksi di riwayat record
+const (
+    HistoryCreate  = "create"
+    HistoryUpdate  = "update"
+    HistoryDelete  = "delete"  // soft delete (pindah ke trash)
+    HistoryRestore = "restore" // keluar dari trash
+    HistoryPurge   = "purge"   // hapus permanen
+    HistoryRevert  = "revert"  // dikembalikan ke isi versi sebelumnya
+)
+
+// HistoryEntry - satu perubahan pada satu record: siapa, kapan, dari request
+// mana, dan field apa saja yang berubah
+type HistoryEntry struct {
+    ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+    Resource   string             `bson:"resource" json:"resource"`
+    RecordID   primitive.ObjectID `bson:"record_id" json:"record_id"`
+    Version    int64              `bson:"version" json:"version"` // versi record setelah perubahan
+    Action     string             `bson:"action" json:"action"`
+    ActorID    string             `bson:"actor_id" json:"actor_id"`
+    ActorName  string             `bson:"actor_name,omitempty" json:"actor_name,omitempty"`
+    RequestID  string             `bson:"request_id,omitempty" json:"request_id,omitempty"`
+    At         time.Time          `bson:"at" json:"at"`
+    Changes    []FieldChange      `bson:"changes" json:"changes"`
+    RevertedTo int64              `bson:"reverted_to,omitempty" json:"reverted_to,omitempty"` // hanya untuk aksi revert
+    Snapshot   bson.Raw           `bson:"snapshot,omitempty" json:"-"`                        // isi record setelah perubahan, dipakai revert
+}
+
+// FieldChange - nilai satu field sebelum dan sesudah perubahan (null jika
+// field belum/tidak lagi ada)
+type FieldChange struct {
+    Field  string `bson:"field" json:"field"`
+    Before any    `bson:"before" json:"before"`
+    After  any    `bson:"after" json:"after"`
+}
@@ -0,0 +1,57 @@
+package model
+
+import (
+    "time"
+
+    "crud_alumni/apperror"
+
+    "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+    ImportModeDryRun = "dry_run"
+    ImportModeCommit = "commit"
+
+    ImportStatusQueued  = "queued"
+    ImportStatusRunning = "running"
+    ImportStatusDone    = "done"
+    ImportStatusFailed  = "failed"
+)
+
+// ImportRowError - kesalahan validasi satu baris file import. Row dihitung
+// seperti di spreadsheet (header = baris 1).
+type ImportRowError struct {
+    Row    int                   `bson:"row" json:"row"`
+    NIM    string                `bson:"nim,omitempty" json:"nim,omitempty"`
+    Fields []apperror.FieldError `bson:"fields" json:"fields"`
+}
+
+// ImportReport - hasil import. Pada dry_run, Created/Updated berisi jumlah
+// yang akan dibuat/diperbarui jika di-commit.
+type ImportReport struct {
+    Mode            string           `bson:"mode" json:"mode"`
+    TotalRows       int              `bson:"total_rows" json:"total_rows"`
+    Valid           int              `bson:"valid" json:"valid"`
+    Invalid         int              `bson:"invalid" json:"invalid"`
+    Created         int              `bson:"created" json:"created"`
+    Updated         int              `bson:"updated" json:"updated"`
+    Errors          []ImportRowError `bson:"errors" json:"errors"`
+    ErrorsTruncated bool             `bson:"errors_truncated,omitempty" json:"errors_truncated,omitempty"`
+}
+
+// ImportJob - import yang diproses di background, bisa dipantau lewat
+// GET /alumni/import/{job_id}
+type ImportJob struct {
+    ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+    Status     string             `bson:"status" json:"status"`
+    Mode       string             `bson:"mode" json:"mode"`
+    FileName   string             `bson:"file_name" json:"file_name"`
+    Total      int                `bson:"total" json:"total"`
+    Processed  int                `bson:"processed" json:"processed"`
+    CreatedBy  string             `bson:"created_by" json:"created_by"`
+    CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+    UpdatedAt  time.Time          `bson:"updated_at" json:"updated_at"`
+    FinishedAt *time.Time         `bson:"finished_at,omitempty" json:"finished_at,omitempty"`
+    Report     *ImportReport      `bson:"report,omitempty" json:"report,omitempty"`
+    Error      string             `bson:"error,omitempty" json:"error,omitempty"`
+}
@@ -0,0 +1,9 @@
+package model
+
+type LogLevelRequest struct {
+    Level string `json:"level"`
+}
+
+type LogLevelResponse struct {
+    Level string `json:"level"`
+}
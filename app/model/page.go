@@ -0,0 +1,41 @@
+package model
+
+// CursorMeta - meta pagination cursor (keyset). Next/Prev dikirim kembali
+// sebagai ?cursor= untuk halaman berikutnya/sebelumnya dengan sortBy dan
+// order yang sama.
+type CursorMeta struct {
+    Limit      int           `json:"limit"`
+    SortBy     string        `json:"sortBy"`
+    Order      string        `json:"order"`
+    Next       string        `json:"next,omitempty"`
+    Prev       string        `json:"prev,omitempty"`
+    Total      *int          `json:"total,omitempty"`       // hanya jika ?count=true
+    Search     string        `json:"search,omitempty"`      // daftar alumni
+    SearchMode string        `json:"search_mode,omitempty"` // daftar alumni
+    Filter     *AlumniFilter `json:"filter,omitempty"`      // daftar alumni
+}
+
+type AlumniCursorResponse struct {
+    Data []AlumniDetail `json:"data"`
+    Meta CursorMeta     `json:"meta"`
+}
+
+type PekerjaanCursorResponse struct {
+    Data []Pekerjaan `json:"data"`
+    Meta CursorMeta  `json:"meta"`
+}
+
+type FileCursorResponse struct {
+    Data []File     `json:"data"`
+    Meta CursorMeta `json:"meta"`
+}
+
+type UserCursorResponse struct {
+    Data []User     `json:"data"`
+    Meta CursorMeta `json:"meta"`
+}
+
+type AuditCursorResponse struct {
+    Data []AuditEntry `json:"data"`
+    Meta CursorMeta   `json:"meta"`
+}
@@ -30,25 +30,30 @@
 package model
 
 import (
+	"time"
+
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type Pekerjaan struct {
     ID                  primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
     LegacyID         int                `bson:"id" json:"id"`
-    AlumniID            int `bson:"alumni_id" json:"alumni_id"` 
-    NamaPerusahaan      string             `bson:"nama_perusahaan" json:"nama_perusahaan"`
-    PosisiJabatan       string             `bson:"posisi_jabatan" json:"posisi_jabatan"`
-    BidangIndustri      string             `bson:"bidang_industri" json:"bidang_industri"`
-    LokasiKerja         string             `bson:"lokasi_kerja" json:"lokasi_kerja"`
-    GajiRange           string             `bson:"gaji_range,omitempty" json:"gaji_range,omitempty"`
-    TanggalMulaiKerja   string             `bson:"tanggal_mulai_kerja" json:"tanggal_mulai_kerja"`
-    TanggalSelesaiKerja *string            `bson:"tanggal_selesai_kerja,omitempty" json:"tanggal_selesai_kerja,omitempty"`
-    StatusPekerjaan     string             `bson:"status_pekerjaan" json:"status_pekerjaan"`
+    AlumniID            int                `bson:"alumni_id" json:"alumni_id" validate:"required,min=1"`
+    NamaPerusahaan      string             `bson:"nama_perusahaan" json:"nama_perusahaan" validate:"required,max=150"`
+    PosisiJabatan       string             `bson:"posisi_jabatan" json:"posisi_jabatan" validate:"required,max=100"`
+    BidangIndustri      string             `bson:"bidang_industri" json:"bidang_industri" validate:"required,max=100"`
+    LokasiKerja         string             `bson:"lokasi_kerja" json:"lokasi_kerja" validate:"required,max=100"`
+    GajiRange           string             `bson:"gaji_range,omitempty" json:"gaji_range,omitempty" validate:"max=50"`
+    TanggalMulaiKerja   string             `bson:"tanggal_mulai_kerja" json:"tanggal_mulai_kerja" validate:"required,date"`
+    TanggalSelesaiKerja *string            `bson:"tanggal_selesai_kerja,omitempty" json:"tanggal_selesai_kerja,omitempty" validate:"date,gtefield=TanggalMulaiKerja"`
+    StatusPekerjaan     string             `bson:"status_pekerjaan" json:"status_pekerjaan" validate:"required,max=50"`
     IsDellete            string               `bson:"isdellete" json:"isdellete"`
-    Deskripsi           string             `bson:"deskripsi_pekerjaan,omitempty" json:"deskripsi_pekerjaan,omitempty"`
+    Deskripsi           string             `bson:"deskripsi_pekerjaan,omitempty" json:"deskripsi_pekerjaan,omitempty" validate:"max=1000"`
     CreatedAt           any          `bson:"created_at" json:"created_at"`
 	UpdatedAt           any         `bson:"updated_at" json:"updated_at"`
+    Version             int64              `bson:"version" json:"version"` // naik setiap perubahan, dipakai sebagai ETag
+    DeletedAt           *time.Time         `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"` // waktu soft delete
+    PurgesAt            *time.Time         `bson:"-" json:"purges_at,omitempty"`                     // hanya di listing trash
 }
 
 type JumlahPekerjaanPerTahun struct {
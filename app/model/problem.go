@@ -0,0 +1,14 @@
+package model
+
+import "crud_alumni/apperror"
+
+// Problem - body error standar (RFC 7807, application/problem+json)
+type Problem struct {
+    Type     string                `json:"type"`
+    Title    string                `json:"title"`
+    Status   int                   `json:"status"`
+    Detail   string                `json:"detail,omitempty"`
+    Instance string                `json:"instance,omitempty"`
+    Code     string                `json:"code"`
+    Errors   []apperror.FieldError `json:"errors,omitempty"`
+}
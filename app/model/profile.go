@@ -0,0 +1,32 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AlumniProfile - data halaman profil alumni dalam satu response
+type AlumniProfile struct {
+    Alumni           Alumni             `json:"alumni"`
+    RiwayatPekerjaan []Pekerjaan        `json:"riwayat_pekerjaan"`            // urut dari yang paling lama
+    PekerjaanSaatIni *Pekerjaan         `json:"pekerjaan_saat_ini,omitempty"` // belum selesai, mulai paling akhir
+    FotoURL          string             `json:"foto_url,omitempty"`           // foto terbaru
+    Sertifikat       []ProfileFile      `json:"sertifikat"`                   // hanya yang sudah diverifikasi
+    Kelengkapan      ProfileCompleteness `json:"kelengkapan"`
+    Redacted         bool               `json:"redacted"` // data kontak/gaji disembunyikan (bukan admin)
+}
+
+// ProfileFile - file yang ditampilkan di profil
+type ProfileFile struct {
+    ID           primitive.ObjectID `json:"id"`
+    OriginalName string             `json:"original_name"`
+    URL          string             `json:"url"`
+    VerifiedAt   *time.Time         `json:"verified_at,omitempty"`
+}
+
+// ProfileCompleteness - skor 0-100 dari data profil yang sudah terisi
+type ProfileCompleteness struct {
+    Skor       int      `json:"skor"`
+    BelumDiisi []string `json:"belum_diisi"`
+}
@@ -0,0 +1,49 @@
+package model
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// Segment - filter daftar alumni yang disimpan dengan nama, mis. "penerima
+// beasiswa 2019". Query berisi parameter pencarian dan filter dalam bentuk
+// query string yang sama dengan /alumni/pag (search, jurusan, tag,
+// custom.<name>, ...), jadi definisi custom field dibaca ulang setiap kali
+// segment dipakai.
+type Segment struct {
+    ID          primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+    Name        string             `bson:"name" json:"name" validate:"required,max=100"`
+    Description string             `bson:"description,omitempty" json:"description,omitempty" validate:"max=500"`
+    Query       string             `bson:"query" json:"query" validate:"required"` // mis. "angkatan_min=2019&tag=penerima+beasiswa"
+    CreatedBy   string             `bson:"created_by" json:"created_by"`           // user_id pembuat
+    CreatedAt   string             `bson:"created_at" json:"created_at"`
+    UpdatedAt   string             `bson:"updated_at" json:"updated_at"`
+    Version     int64              `bson:"version" json:"version"`
+}
+
+// SegmentCount - jumlah alumni yang cocok dengan segment saat ini
+type SegmentCount struct {
+    Total      int           `json:"total"`
+    Search     string        `json:"search,omitempty"`
+    SearchMode string        `json:"search_mode,omitempty"`
+    Filter     *AlumniFilter `json:"filter,omitempty"`
+}
+
+// TagUpdateRequest - body POST /alumni/tags. Tag di Add ditambahkan dan tag
+// di Remove dihapus dari semua alumni yang cocok dengan filter di query string.
+type TagUpdateRequest struct {
+    Add    []string `json:"add,omitempty" validate:"max=20,tags"`
+    Remove []string `json:"remove,omitempty" validate:"max=20,tags"`
+}
+
+// TagUpdateResult - jumlah alumni yang berubah karena bulk tag
+type TagUpdateResult struct {
+    Matched   int   `json:"matched"`   // alumni yang cocok dengan filter
+    Tagged    int64 `json:"tagged"`    // alumni yang mendapat minimal satu tag baru
+    Untagged  int64 `json:"untagged"`  // alumni yang kehilangan minimal satu tag
+    TagLimit  int64 `json:"tag_limit"` // alumni yang dilewati karena akan punya lebih dari 20 tag
+    Conflicts int64 `json:"conflicts"` // alumni yang dilewati karena diubah request lain selama proses
+}
+
+// TagCount - satu tag beserta jumlah alumni aktif yang memakainya
+type TagCount struct {
+    Tag   string `bson:"_id" json:"tag"`
+    Count int    `bson:"count" json:"count"`
+}
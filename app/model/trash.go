@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+// TrashItem - satu data di trash yang akan di-purge
+type TrashItem struct {
+    ID        string           `json:"id"`
+    Label     string           `json:"label"`
+    DeletedAt time.Time        `json:"deleted_at"`
+    PurgesAt  time.Time        `json:"purges_at"`
+    Related   map[string]int64 `json:"related,omitempty"`  // relasi yang ikut terhapus, mis. {"pekerjaan": 2}
+    Unlinked  map[string]int64 `json:"unlinked,omitempty"` // data yang tetap ada tetapi tidak lagi terhubung ke alumni, mis. {"files": 1}
+}
+
+// PurgeTargetReport - ringkasan purge untuk satu jenis data
+type PurgeTargetReport struct {
+    Target string      `json:"target"`
+    Count  int64       `json:"count"` // jumlah seluruhnya
+    Items  []TrashItem `json:"items"` // paling banyak 100 item, terlama lebih dulu
+}
+
+// PurgeReport - laporan dry-run: apa yang akan dihapus permanen jika purge
+// dijalankan sekarang
+type PurgeReport struct {
+    Enabled     bool                `json:"enabled"`
+    Retention   string              `json:"retention"`
+    Cutoff      time.Time           `json:"cutoff"`
+    GeneratedAt time.Time           `json:"generated_at"`
+    Targets     []PurgeTargetReport `json:"targets"`
+}
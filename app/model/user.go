@@ -45,6 +45,12 @@ type User struct {
     Role      string             `bson:"role" json:"role"`
     CreatedAt string          `bson:"created_at" json:"created_at"`
     PasswordHash string             `bson:"password_hash" json:"-"`
+    Version   int64              `bson:"version" json:"version"` // naik setiap perubahan, dipakai sebagai ETag
+}
+
+// RoleUpdateRequest - body PUT /admin/users/{id}/role
+type RoleUpdateRequest struct {
+    Role string `json:"role" validate:"required,oneof=admin user"`
 }
 
 type LoginRequest struct {
@@ -5,27 +5,30 @@ import (
 	"crud_alumni/app/model"
 	"crud_alumni/database"
 	"fmt"
+	"regexp"
+	"strconv"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-// Ambil semua alumni
-func GetAllAlumni() ([]model.Alumni, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// Ambil semua alumni (bentuk hasil sesuai ro)
+func GetAllAlumni(ctx context.Context, ro ReadOptions) ([]model.AlumniDetail, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
 	defer cancel()
 
 	fmt.Println("📡 Coba ambil semua alumni...")
-	cursor, err := database.AlumniCollection.Find(ctx, bson.M{})
+	cursor, err := alumniShape(ro).find(ctx, database.AlumniCollection, activeAlumni(bson.M{}), nil)
 	if err != nil {
 		fmt.Println("❌ Error MongoDB Find:", err)
 		return nil, err
 	}
 	defer cursor.Close(ctx)
 
-	var list []model.Alumni
+	var list []model.AlumniDetail
 	if err = cursor.All(ctx, &list); err != nil {
 		fmt.Println("❌ Error Decode:", err)
 		return nil, err
@@ -34,108 +37,371 @@ func GetAllAlumni() ([]model.Alumni, error) {
 	return list, nil
 }
 
-
-// Tambah alumni
-func CreateAlumni(a model.Alumni) (primitive.ObjectID, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// Tambah alumni; NIM yang sudah dipakai alumni lain menghasilkan ErrDuplicate
+func CreateAlumni(ctx context.Context, a model.Alumni) (primitive.ObjectID, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
 	defer cancel()
 
 	a.ID = primitive.NewObjectID()
+	a.Version = InitialVersion
 	a.CreatedAt = time.Now().Format("2006-01-02 15:04:05")
-    a.UpdatedAt = time.Now().Format("2006-01-02 15:04:05")
-
+	a.UpdatedAt = time.Now().Format("2006-01-02 15:04:05")
 
 	_, err := database.AlumniCollection.InsertOne(ctx, a)
-	return a.ID, err
+	return a.ID, alumniSaveError(err)
 }
 
-// Update alumni
-func UpdateAlumni(id string, a model.Alumni) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// ReplaceAlumni menulis ulang semua field alumni yang ada di model (termasuk
+// nim). Field opsional yang kosong (alamat, no_telepon, custom, tags) ikut
+// terhapus; field dokumen yang tidak ada di model, termasuk id lama yang
+// dirujuk pekerjaan.alumni_id, tidak disentuh. NIM yang sudah dipakai
+// alumni lain menghasilkan ErrDuplicate.
+// a.Version harus berisi versi yang dibaca sebelumnya; jika dokumen sudah
+// diubah request lain hasilnya ErrVersionConflict. Versi dan updated_at di a
+// diperbarui.
+func ReplaceAlumni(ctx context.Context, a *model.Alumni) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(id)
+	expected := a.Version
+	a.Version++
+	a.UpdatedAt = time.Now().Format("2006-01-02 15:04:05")
+	update, err := alumniReplaceUpdate(*a)
 	if err != nil {
 		return err
 	}
+	return alumniSaveError(checkVersionMatched(database.AlumniCollection.UpdateOne(ctx, activeAlumni(versionFilter(a.ID, expected)), update)))
+}
+
+// alumniClearable - field opsional yang di-$unset ReplaceAlumni jika kosong
+var alumniClearable = []string{"no_telepon", "alamat", "custom", "tags"}
+
+// alumniReplaceUpdate - update $set/$unset untuk ReplaceAlumni. id lama
+// hanya di-$set jika terisi (mis. diwarisi dari duplikat saat merge) dan
+// tidak pernah dihapus.
+func alumniReplaceUpdate(a model.Alumni) (bson.M, error) {
+	return replaceUpdate(a, alumniClearable, "score")
+}
+
+// replaceUpdate - update yang menulis ulang semua field model v dengan $set
+// dan meng-$unset field clearable yang kosong. Field dokumen yang tidak ada
+// di model tidak disentuh, berbeda dengan ReplaceOne. _id dan readOnly tidak
+// ikut di-$set.
+func replaceUpdate(v any, clearable []string, readOnly ...string) (bson.M, error) {
+	raw, err := bson.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	set := bson.M{}
+	if err := bson.Unmarshal(raw, &set); err != nil {
+		return nil, err
+	}
+	delete(set, "_id")
+	for _, field := range readOnly {
+		delete(set, field)
+	}
 
-	update := bson.M{
+	update := bson.M{"$set": set}
+	unset := bson.M{}
+	for _, field := range clearable {
+		if _, ok := set[field]; !ok {
+			unset[field] = ""
+		}
+	}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	return update, nil
+}
+
+// SoftDeleteAlumni memindahkan alumni ke trash (deleted_at/deleted_by)
+func SoftDeleteAlumni(ctx context.Context, id string, version int64, deletedBy string) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	objID, err := parseObjectID(id)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	return checkVersionMatched(database.AlumniCollection.UpdateOne(ctx, activeAlumni(versionFilter(objID, version)), bson.M{
 		"$set": bson.M{
-			"nama":        a.Nama,
-			"jurusan":     a.Jurusan,
-			"angkatan":    a.Angkatan,
-			"tahun_lulus": a.TahunLulus,
-			"email":       a.Email,
-			"no_telepon":  a.NoTelepon,
-			"alamat":      a.Alamat,
-			"updated_at":  time.Now(),
+			"deleted_at": now,
+			"deleted_by": deletedBy,
+			"updated_at": now.Format("2006-01-02 15:04:05"),
 		},
+		"$inc": bson.M{"version": 1},
+	}))
+}
+
+// RestoreAlumni mengeluarkan alumni dari trash
+func RestoreAlumni(ctx context.Context, id string, version int64) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	objID, err := parseObjectID(id)
+	if err != nil {
+		return err
+	}
+	return alumniSaveError(checkVersionMatched(database.AlumniCollection.UpdateOne(ctx, trashedAlumni(versionFilter(objID, version)), bson.M{
+		"$set":   bson.M{"updated_at": time.Now().Format("2006-01-02 15:04:05")},
+		"$unset": bson.M{"deleted_at": "", "deleted_by": "", "merged_into": "", "nim_scope": ""},
+		"$inc":   bson.M{"version": 1},
+	})))
+}
+
+// alumniSaveError - NIM yang sudah dipakai alumni lain (index unik
+// alumni_nim_scope_unique, termasuk yang di trash) menjadi ErrDuplicate
+func alumniSaveError(err error) error {
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrDuplicate
 	}
-	_, err = database.AlumniCollection.UpdateByID(ctx, objID, update)
 	return err
 }
 
-// Hapus alumni
-func DeleteAlumni(id string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// PurgeAlumni menghapus permanen alumni yang sudah ada di trash
+func PurgeAlumni(ctx context.Context, id string, version int64) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(id)
+	objID, err := parseObjectID(id)
 	if err != nil {
 		return err
 	}
-	_, err = database.AlumniCollection.DeleteOne(ctx, bson.M{"_id": objID})
-	return err
+	return checkVersionDeleted(database.AlumniCollection.DeleteOne(ctx, trashedAlumni(versionFilter(objID, version))))
+}
+
+// TrashAlumni – semua alumni di trash, yang terakhir dihapus lebih dulu
+func TrashAlumni(ctx context.Context) ([]model.Alumni, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "deleted_at", Value: -1}})
+	cursor, err := database.AlumniCollection.Find(ctx, trashedAlumni(bson.M{}), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	list := []model.Alumni{}
+	if err := cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// ExpiredTrashAlumni – paling banyak limit alumni yang masuk trash sebelum
+// cutoff, yang terlama lebih dulu
+func ExpiredTrashAlumni(ctx context.Context, cutoff time.Time, limit int64) ([]model.Alumni, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "deleted_at", Value: 1}, {Key: "_id", Value: 1}}).SetLimit(limit)
+	cursor, err := database.AlumniCollection.Find(ctx, expiredTrashAlumni(cutoff), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	list := []model.Alumni{}
+	if err := cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// CountExpiredTrashAlumni – jumlah alumni yang masuk trash sebelum cutoff
+func CountExpiredTrashAlumni(ctx context.Context, cutoff time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	return database.AlumniCollection.CountDocuments(ctx, expiredTrashAlumni(cutoff))
+}
+
+// alumniRelations - filter pekerjaan dan file yang terhubung ke alumni a:
+// pekerjaan lewat id lama, file lewat akun user dengan email yang sama. Relasi yang juga
+// dipakai alumni lain (mis. survivor merge yang mewarisi id lama atau email
+// duplikat) tidak termasuk; filter nil berarti tidak ada relasi.
+func alumniRelations(ctx context.Context, a model.Alumni) (pekerjaan, files bson.M, err error) {
+	shared := func(field string, value any) (bool, error) {
+		n, err := database.AlumniCollection.CountDocuments(ctx, bson.M{"_id": bson.M{"$ne": a.ID}, field: value}, options.Count().SetLimit(1))
+		return n > 0, err
+	}
+
+	if a.LegacyID != 0 {
+		taken, err := shared("id", a.LegacyID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !taken {
+			pekerjaan = bson.M{"alumni_id": a.LegacyID}
+		}
+	}
+	if a.Email != "" {
+		taken, err := shared("email", a.Email)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !taken {
+			owners, err := database.UserCollection.Distinct(ctx, "_id", bson.M{"email": a.Email})
+			if err != nil {
+				return nil, nil, err
+			}
+			if len(owners) > 0 {
+				files = bson.M{"user_id": bson.M{"$in": owners}}
+			}
+		}
+	}
+	return pekerjaan, files, nil
+}
+
+// AlumniRelationCounts – jumlah pekerjaan yang ikut terhapus jika alumni a
+// di-purge dan jumlah file akun user dengan email a yang tidak lagi
+// terhubung ke alumni mana pun (lihat alumniRelations)
+func AlumniRelationCounts(ctx context.Context, a model.Alumni) (pekerjaan, files int64, err error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	pf, ff, err := alumniRelations(ctx, a)
+	if err != nil {
+		return 0, 0, err
+	}
+	if pf != nil {
+		if pekerjaan, err = database.PekerjaanCollection.CountDocuments(ctx, pf); err != nil {
+			return 0, 0, err
+		}
+	}
+	if ff != nil {
+		if files, err = database.DB.Collection(FilesCollection).CountDocuments(ctx, ff); err != nil {
+			return 0, 0, err
+		}
+	}
+	return pekerjaan, files, nil
+}
+
+// DeleteAlumniRelations menghapus permanen pekerjaan (termasuk yang di trash)
+// milik alumni a yang sudah di-purge, lalu mengembalikan data yang terhapus.
+// File tidak ikut dihapus karena dimiliki akun user, yang tetap ada.
+func DeleteAlumniRelations(ctx context.Context, a model.Alumni) ([]model.Pekerjaan, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	pf, _, err := alumniRelations(ctx, a)
+	if err != nil || pf == nil {
+		return nil, err
+	}
+	var pekerjaan []model.Pekerjaan
+	if err := findAll(ctx, database.PekerjaanCollection, pf, &pekerjaan); err != nil {
+		return nil, err
+	}
+	if _, err := database.PekerjaanCollection.DeleteMany(ctx, pf); err != nil {
+		return nil, err
+	}
+	return pekerjaan, nil
+}
+
+func findAll(ctx context.Context, coll *mongo.Collection, filter bson.M, out any) error {
+	cursor, err := coll.Find(ctx, filter)
+	if err != nil {
+		return err
+	}
+	return cursor.All(ctx, out)
+}
+
+// GetTrashedAlumniByID – ambil alumni yang ada di trash
+func GetTrashedAlumniByID(ctx context.Context, id string) (model.Alumni, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	var a model.Alumni
+	objID, err := parseObjectID(id)
+	if err != nil {
+		return a, err
+	}
+	err = database.AlumniCollection.FindOne(ctx, trashedAlumni(bson.M{"_id": objID})).Decode(&a)
+	return a, mapError(err)
 }
 
 // Get by ID
-func GetAlumniByID(id string) (model.Alumni, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func GetAlumniByID(ctx context.Context, id string) (model.Alumni, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
 	defer cancel()
 
 	var a model.Alumni
-	objID, err := primitive.ObjectIDFromHex(id)
+	objID, err := parseObjectID(id)
 	if err != nil {
 		return a, err
 	}
-	err = database.AlumniCollection.FindOne(ctx, bson.M{"_id": objID}).Decode(&a)
-	return a, err
+	err = database.AlumniCollection.FindOne(ctx, activeAlumni(bson.M{"_id": objID})).Decode(&a)
+	return a, mapError(err)
 }
 
-// Pagination + Sorting + Searching
-func GetAlumniWithPagination(search, sortBy, order string, limit, offset int) ([]model.Alumni, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	filter := bson.M{}
-	if search != "" {
-		filter = bson.M{
-			"$or": []bson.M{
-				{"nama": bson.M{"$regex": search, "$options": "i"}},
-				{"nim": bson.M{"$regex": search, "$options": "i"}},
-				{"jurusan": bson.M{"$regex": search, "$options": "i"}},
-				{"email": bson.M{"$regex": search, "$options": "i"}},
-			},
+// GetAlumniDetail - alumni aktif berdasarkan ID dengan bentuk hasil sesuai ro
+func GetAlumniDetail(ctx context.Context, id string, ro ReadOptions) (model.AlumniDetail, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	var a model.AlumniDetail
+	objID, err := parseObjectID(id)
+	if err != nil {
+		return a, err
+	}
+	cursor, err := alumniShape(ro).find(ctx, database.AlumniCollection, activeAlumni(bson.M{"_id": objID}), options.Find().SetLimit(1))
+	if err != nil {
+		return a, err
+	}
+	defer cursor.Close(ctx)
+
+	if !cursor.Next(ctx) {
+		if err := cursor.Err(); err != nil {
+			return a, err
 		}
+		return a, ErrNotFound
 	}
+	return a, cursor.Decode(&a)
+}
 
-	sortOrder := 1
-	if order == "desc" {
-		sortOrder = -1
+// FindAlumniByNIMs – alumni dengan NIM di daftar, dikelompokkan per NIM.
+// Alumni di trash ikut (NIM-nya tetap terpakai); duplikat yang sudah di-merge
+// tidak, karena NIM-nya sudah diwakili survivor.
+func FindAlumniByNIMs(ctx context.Context, nims []string) (map[string]model.Alumni, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	cursor, err := database.AlumniCollection.Find(ctx, bson.M{"nim": bson.M{"$in": nims}, "merged_into": bson.M{"$exists": false}})
+	if err != nil {
+		return nil, err
 	}
+	defer cursor.Close(ctx)
 
-	opts := options.Find().
-		SetSort(bson.D{{Key: sortBy, Value: sortOrder}}).
+	var list []model.Alumni
+	if err := cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	result := make(map[string]model.Alumni, len(list))
+	for _, a := range list {
+		result[a.NIM] = a
+	}
+	return result, nil
+}
+
+// Pagination + Sorting + Searching
+func GetAlumniWithPagination(ctx context.Context, search model.AlumniSearch, f model.AlumniFilter, sortBy, order string, limit, offset int, ro ReadOptions) ([]model.AlumniDetail, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	q := alumniListQuery(search, f)
+	opts := alumniFindOptions(q.filter, sortBy, order).
 		SetLimit(int64(limit)).
 		SetSkip(int64(offset))
 
-	cursor, err := database.AlumniCollection.Find(ctx, filter, opts)
+	cursor, err := alumniShape(ro).joined(q.join).find(ctx, database.AlumniCollection, q.filter, opts)
 	if err != nil {
 		return nil, err
 	}
 	defer cursor.Close(ctx)
 
-	var list []model.Alumni
+	var list []model.AlumniDetail
 	if err := cursor.All(ctx, &list); err != nil {
 		return nil, err
 	}
@@ -143,24 +409,225 @@ func GetAlumniWithPagination(search, sortBy, order string, limit, offset int) ([
 	return list, nil
 }
 
-// Count total data
-func CountAlumni(search string) (int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// AlumniPage - satu halaman keyset daftar alumni dengan filter yang sama
+// seperti GetAlumniWithPagination (urutan relevance tidak didukung)
+func AlumniPage(ctx context.Context, search model.AlumniSearch, f model.AlumniFilter, req PageRequest, ro ReadOptions) (Page[model.AlumniDetail], error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
 	defer cancel()
 
-	filter := bson.M{}
-	if search != "" {
-		filter = bson.M{
-			"$or": []bson.M{
-				{"nama": bson.M{"$regex": search, "$options": "i"}},
-				{"nim": bson.M{"$regex": search, "$options": "i"}},
-				{"jurusan": bson.M{"$regex": search, "$options": "i"}},
-				{"email": bson.M{"$regex": search, "$options": "i"}},
-			},
-		}
+	q := alumniListQuery(search, f)
+	return findPage[model.AlumniDetail](ctx, database.AlumniCollection, q.filter, req, alumniShape(ro).joined(q.join))
+}
+
+// AlumniIterator membaca hasil query alumni satu per satu tanpa memuat
+// semuanya ke memori. Wajib di-Close.
+type AlumniIterator struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	cursor *mongo.Cursor
+}
+
+// IterateAlumni - semua alumni sesuai pencarian dan urutan yang sama dengan
+// GetAlumniWithPagination (untuk export). timeout berlaku untuk seluruh
+// iterasi, bukan DB_TIMEOUT, karena export bisa berjalan jauh lebih lama.
+func IterateAlumni(ctx context.Context, search model.AlumniSearch, f model.AlumniFilter, sortBy, order string, timeout time.Duration) (*AlumniIterator, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+
+	q := alumniListQuery(search, f)
+	opts := alumniFindOptions(q.filter, sortBy, order).SetBatchSize(500)
+	cursor, err := readShape{}.joined(q.join).find(ctx, database.AlumniCollection, q.filter, opts)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &AlumniIterator{ctx: ctx, cancel: cancel, cursor: cursor}, nil
+}
+
+// Next mengisi a dengan dokumen berikutnya; false jika sudah habis atau error
+func (it *AlumniIterator) Next(a *model.Alumni) (bool, error) {
+	if !it.cursor.Next(it.ctx) {
+		return false, it.cursor.Err()
 	}
+	return true, it.cursor.Decode(a)
+}
+
+func (it *AlumniIterator) Close() error {
+	defer it.cancel()
+	return it.cursor.Close(it.ctx)
+}
+
+// Count total data dengan filter yang sama seperti GetAlumniWithPagination
+func CountAlumni(ctx context.Context, search model.AlumniSearch, f model.AlumniFilter) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
 
-	count, err := database.AlumniCollection.CountDocuments(ctx, filter)
+	count, err := alumniListQuery(search, f).count(ctx)
 	return int(count), err
 }
 
+// alumniQuery - filter daftar alumni. Filter has_pekerjaan/currently_employed
+// butuh koleksi pekerjaan, jadi berupa tahap semi-join (join) setelah filter;
+// query dengan join dijalankan sebagai aggregate.
+type alumniQuery struct {
+	filter bson.M
+	join   []bson.M
+}
+
+// alumniListQuery - pencarian teks + filter terstruktur. Pekerjaan terhubung
+// ke alumni lewat id numerik lama (alumni.id), jadi alumni tanpa id lama
+// dianggap tidak punya pekerjaan.
+func alumniListQuery(search model.AlumniSearch, f model.AlumniFilter) alumniQuery {
+	q := alumniQuery{filter: alumniSearchFilter(search)}
+	applyAlumniFilter(q.filter, f)
+	if f.HasPekerjaan != nil {
+		q.join = append(q.join, pekerjaanSemiJoin("_pekerjaan", false, *f.HasPekerjaan)...)
+	}
+	if f.CurrentlyEmployed != nil {
+		q.join = append(q.join, pekerjaanSemiJoin("_bekerja", true, *f.CurrentlyEmployed)...)
+	}
+	return q
+}
+
+// count - jumlah alumni yang cocok dengan q
+func (q alumniQuery) count(ctx context.Context) (int64, error) {
+	return readShape{}.joined(q.join).count(ctx, database.AlumniCollection, q.filter)
+}
+
+// applyAlumniFilter menambahkan filter yang hanya memakai field alumni
+func applyAlumniFilter(filter bson.M, f model.AlumniFilter) {
+	if len(f.Jurusan) > 0 {
+		// jurusan divalidasi tanpa peduli huruf besar/kecil, jadi filternya juga
+		in := make(bson.A, len(f.Jurusan))
+		for i, j := range f.Jurusan {
+			in[i] = primitive.Regex{Pattern: "^" + regexp.QuoteMeta(j) + "$", Options: "i"}
+		}
+		filter["jurusan"] = bson.M{"$in": in}
+	}
+	if r := intRange(f.AngkatanMin, f.AngkatanMax); r != nil {
+		filter["angkatan"] = r
+	}
+	if r := intRange(f.TahunLulusMin, f.TahunLulusMax); r != nil {
+		filter["tahun_lulus"] = r
+	}
+	if f.HasEmail != nil {
+		op := "$nin"
+		if !*f.HasEmail {
+			op = "$in"
+		}
+		filter["email"] = bson.M{op: bson.A{nil, ""}}
+	}
+	if r := dateRange(f.CreatedFrom, f.CreatedTo); r != nil {
+		filter["created_at"] = r
+	}
+	if r := dateRange(f.UpdatedFrom, f.UpdatedTo); r != nil {
+		filter["updated_at"] = r
+	}
+	if len(f.Tags) > 0 {
+		filter["tags"] = bson.M{"$all": f.Tags}
+	}
+	for _, cf := range f.Custom {
+		filter["custom."+cf.Name] = customCondition(cf)
+	}
+}
+
+// customCondition - syarat untuk nilai custom field. Number dan date memakai
+// rentang inklusif (date disimpan sebagai teks YYYY-MM-DD); enum dicocokkan
+// persis, string dan url tanpa peduli huruf besar/kecil.
+func customCondition(cf model.CustomFilter) bson.M {
+	switch cf.Type {
+	case model.CustomFieldNumber, model.CustomFieldDate:
+		r := bson.M{}
+		bound := func(op, v string) {
+			if v == "" {
+				return
+			}
+			if cf.Type == model.CustomFieldDate {
+				r[op] = v
+			} else if n, err := strconv.ParseFloat(v, 64); err == nil {
+				r[op] = n
+			}
+		}
+		bound("$gte", cf.Min)
+		bound("$lte", cf.Max)
+		return r
+	case model.CustomFieldEnum:
+		return bson.M{"$in": cf.Values}
+	}
+	in := make(bson.A, len(cf.Values))
+	for i, v := range cf.Values {
+		in[i] = primitive.Regex{Pattern: "^" + regexp.QuoteMeta(v) + "$", Options: "i"}
+	}
+	return bson.M{"$in": in}
+}
+
+func intRange(lo, hi int) bson.M {
+	r := bson.M{}
+	if lo != 0 {
+		r["$gte"] = lo
+	}
+	if hi != 0 {
+		r["$lte"] = hi
+	}
+	if len(r) == 0 {
+		return nil
+	}
+	return r
+}
+
+// dateRange - rentang tanggal YYYY-MM-DD (inklusif) untuk field teks
+// "2006-01-02 15:04:05"; format itu bisa dibandingkan sebagai string
+func dateRange(from, to string) bson.M {
+	r := bson.M{}
+	if from != "" {
+		r["$gte"] = from
+	}
+	if to != "" {
+		if t, err := time.Parse("2006-01-02", to); err == nil {
+			r["$lt"] = t.AddDate(0, 0, 1).Format("2006-01-02")
+		}
+	}
+	if len(r) == 0 {
+		return nil
+	}
+	return r
+}
+
+// alumniFindOptions - urutan daftar alumni; _id sebagai penentu urutan yang
+// stabil untuk nilai yang sama. Pada pencarian text index skor relevansi ikut
+// dikembalikan, dan sortBy "relevance" mengurutkan dari yang paling relevan.
+func alumniFindOptions(filter bson.M, sortBy, order string) *options.FindOptions {
+	opts := options.Find()
+	_, textSearch := filter["$text"]
+	if textSearch {
+		opts.SetProjection(bson.M{"score": bson.M{"$meta": "textScore"}})
+	}
+	if sortBy == SortRelevance {
+		if textSearch {
+			return opts.SetSort(bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}, {Key: "_id", Value: 1}})
+		}
+		sortBy = "nama"
+	}
+
+	sortOrder := 1
+	if order == "desc" {
+		sortOrder = -1
+	}
+	return opts.SetSort(bson.D{{Key: sortBy, Value: sortOrder}, {Key: "_id", Value: sortOrder}})
+}
+
+// activeAlumni menambahkan syarat "belum dihapus" ke filter
+func activeAlumni(filter bson.M) bson.M {
+	filter["deleted_at"] = nil
+	return filter
+}
+
+// trashedAlumni menambahkan syarat "ada di trash" ke filter
+func trashedAlumni(filter bson.M) bson.M {
+	filter["deleted_at"] = bson.M{"$ne": nil}
+	return filter
+}
+
+// expiredTrashAlumni - filter alumni di trash yang dihapus sebelum cutoff
+func expiredTrashAlumni(cutoff time.Time) bson.M {
+	return bson.M{"deleted_at": bson.M{"$lte": cutoff}}
+}
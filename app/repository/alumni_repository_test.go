@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"testing"
+
+	"crud_alumni/app/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestAlumniSearchFilter_ExcludesTrash(t *testing.T) {
+	for _, search := range []string{"", "budi"} {
+		filter := alumniSearchFilter(model.AlumniSearch{Query: search})
+		if v, ok := filter["deleted_at"]; !ok || v != nil {
+			t.Errorf("search %q: expected deleted_at: null, got %v", search, filter)
+		}
+		if _, ok := filter["$or"]; ok != (search != "") {
+			t.Errorf("search %q: unexpected $or in %v", search, filter)
+		}
+	}
+}
+
+func TestTrashedAlumni_KeepsVersionFilter(t *testing.T) {
+	id := primitive.NewObjectID()
+	filter := trashedAlumni(versionFilter(id, 3))
+
+	if filter["_id"] != id || filter["version"] != int64(3) {
+		t.Errorf("expected _id dan version tetap ada, got %v", filter)
+	}
+	if ne, ok := filter["deleted_at"].(bson.M); !ok || ne["$ne"] != nil {
+		t.Errorf("expected deleted_at: {$ne: null}, got %v", filter["deleted_at"])
+	}
+}
+
+func TestAlumniReplaceUpdate(t *testing.T) {
+	update, err := alumniReplaceUpdate(model.Alumni{
+		ID: primitive.NewObjectID(), NIM: "2019001", Nama: "Budi", Alamat: "Bandung", Version: 4, Score: 1.5,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	set := update["$set"].(bson.M)
+	for _, field := range []string{"_id", "id", "score", "no_telepon"} {
+		if _, ok := set[field]; ok {
+			t.Errorf("expected %s tidak di-$set, got %v", field, set)
+		}
+	}
+	if set["alamat"] != "Bandung" || set["version"] != int64(4) {
+		t.Errorf("unexpected $set %v", set)
+	}
+	// id lama tidak pernah dihapus; field opsional yang kosong dihapus
+	unset := update["$unset"].(bson.M)
+	if _, ok := unset["id"]; ok {
+		t.Errorf("expected id tidak di-$unset, got %v", unset)
+	}
+	for _, field := range []string{"no_telepon", "custom", "tags"} {
+		if _, ok := unset[field]; !ok {
+			t.Errorf("expected %s di-$unset, got %v", field, unset)
+		}
+	}
+
+	update, _ = alumniReplaceUpdate(model.Alumni{LegacyID: 7})
+	if update["$set"].(bson.M)["id"] != int32(7) {
+		t.Errorf("expected id lama di-$set jika terisi, got %v", update["$set"])
+	}
+}
+
+func TestPekerjaanReplaceUpdate(t *testing.T) {
+	update, err := replaceUpdate(model.Pekerjaan{ID: primitive.NewObjectID(), NamaPerusahaan: "Acme", Version: 2}, pekerjaanClearable)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if set := update["$set"].(bson.M); set["nama_perusahaan"] != "Acme" || set["_id"] != nil {
+		t.Errorf("unexpected $set %v", set)
+	}
+	unset := update["$unset"].(bson.M)
+	for _, field := range []string{"gaji_range", "tanggal_selesai_kerja", "deskripsi_pekerjaan"} {
+		if _, ok := unset[field]; !ok {
+			t.Errorf("expected %s di-$unset, got %v", field, unset)
+		}
+	}
+}
+
+func TestApplyAlumniFilter(t *testing.T) {
+	hasEmail := false
+	filter := bson.M{}
+	applyAlumniFilter(filter, model.AlumniFilter{
+		Jurusan:     []string{"Teknik Informatika", "S.I. (Reguler)"},
+		AngkatanMin: 2015,
+		HasEmail:    &hasEmail,
+		CreatedTo:   "2024-12-31",
+	})
+
+	in := filter["jurusan"].(bson.M)["$in"].(bson.A)
+	if re := in[1].(primitive.Regex); re.Pattern != `^S\.I\. \(Reguler\)$` || re.Options != "i" {
+		t.Errorf("expected jurusan regex di-escape dan case-insensitive, got %+v", re)
+	}
+	if r := filter["angkatan"].(bson.M); r["$gte"] != 2015 || r["$lte"] != nil {
+		t.Errorf("expected angkatan >= 2015 saja, got %v", r)
+	}
+	if _, ok := filter["email"].(bson.M)["$in"]; !ok {
+		t.Errorf("expected email kosong ($in [null, \"\"]), got %v", filter["email"])
+	}
+	// batas atas inklusif: created_at "2024-12-31 23:59:59" masih masuk
+	if r := filter["created_at"].(bson.M); r["$lt"] != "2025-01-01" {
+		t.Errorf("expected created_at < 2025-01-01, got %v", r)
+	}
+	if _, ok := filter["tahun_lulus"]; ok {
+		t.Errorf("expected tahun_lulus tidak difilter, got %v", filter)
+	}
+}
+
+func TestTagTargetFilter(t *testing.T) {
+	filter := bson.M{}
+	applyAlumniFilter(filter, model.AlumniFilter{Tags: []string{"narasumber"}})
+	if all := filter["tags"].(bson.M)["$all"].([]string); len(all) != 1 || all[0] != "narasumber" {
+		t.Fatalf("expected tags $all, got %v", filter["tags"])
+	}
+
+	// bulk tag hanya mengenai alumni yang akan berubah, tanpa menghilangkan
+	// syarat ?tag=
+	got := tagTargetFilter(filter, []string{"beasiswa"}, []string{"x"}, primitive.NilObjectID)["$and"].(bson.A)
+	if len(got) != 3 || got[0].(bson.M)["tags"] == nil {
+		t.Fatalf("expected filter, changes and cursor conditions, got %v", got)
+	}
+	if changes := got[1].(bson.M)["$or"].(bson.A); len(changes) != 2 {
+		t.Errorf("expected add and remove conditions, got %v", changes)
+	}
+	if changes := tagTargetFilter(bson.M{}, nil, []string{"x"}, primitive.NilObjectID)["$and"].(bson.A)[1].(bson.M)["$or"].(bson.A); len(changes) != 1 {
+		t.Errorf("expected only the remove condition, got %v", changes)
+	}
+}
+
+func TestApplyAlumniFilter_Custom(t *testing.T) {
+	filter := bson.M{}
+	applyAlumniFilter(filter, model.AlumniFilter{Custom: []model.CustomFilter{
+		{Name: "ipk", Type: model.CustomFieldNumber, Min: "3.5"},
+		{Name: "wisuda", Type: model.CustomFieldDate, Min: "2024-01-01", Max: "2024-12-31"},
+		{Name: "beasiswa", Type: model.CustomFieldEnum, Values: []string{"KIP", "LPDP"}},
+		{Name: "kota", Type: model.CustomFieldString, Values: []string{"Kota (Baru)"}},
+	}})
+
+	if r := filter["custom.ipk"].(bson.M); r["$gte"] != 3.5 || r["$lte"] != nil {
+		t.Errorf("expected custom.ipk >= 3.5 (angka), got %v", r)
+	}
+	if r := filter["custom.wisuda"].(bson.M); r["$gte"] != "2024-01-01" || r["$lte"] != "2024-12-31" {
+		t.Errorf("expected rentang tanggal inklusif, got %v", r)
+	}
+	if in := filter["custom.beasiswa"].(bson.M)["$in"].([]string); len(in) != 2 {
+		t.Errorf("expected enum dicocokkan persis, got %v", in)
+	}
+	in := filter["custom.kota"].(bson.M)["$in"].(bson.A)
+	if re := in[0].(primitive.Regex); re.Pattern != `^Kota \(Baru\)$` || re.Options != "i" {
+		t.Errorf("expected string di-escape dan case-insensitive, got %+v", re)
+	}
+}
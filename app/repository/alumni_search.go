@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"crud_alumni/app/model"
+	"crud_alumni/database"
+	"crud_alumni/phone"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SortRelevance - urutan berdasarkan skor text index (hanya untuk search_mode=text)
+const SortRelevance = "relevance"
+
+// alumniSearchFilter - filter pencarian daftar alumni (tanpa yang ada di trash).
+// Input user selalu di-escape sebelum masuk $regex. Kata kunci yang mirip NIM
+// dicocokkan sebagai awalan NIM (bisa memakai index nim), dan yang mirip
+// nomor telepon dicocokkan dengan no_telepon apa pun awalan yang diketik
+// (08..., 62..., atau +62...).
+func alumniSearchFilter(s model.AlumniSearch) bson.M {
+	filter := bson.M{}
+	q := strings.TrimSpace(s.Query)
+	tel, isPhone := phone.SearchPattern(q)
+	switch {
+	case q == "":
+	case s.Mode == model.AlumniSearchText && isPhone:
+		filter["$or"] = []bson.M{{"nim": nimPrefix(q)}, {"no_telepon": bson.M{"$regex": tel}}}
+	case s.Mode == model.AlumniSearchText && looksLikeNIM(q):
+		filter["nim"] = nimPrefix(q)
+	case s.Mode == model.AlumniSearchText:
+		filter["$text"] = bson.M{"$search": q}
+	default:
+		pattern := foldPattern(q)
+		or := []bson.M{
+			{"nama": bson.M{"$regex": pattern, "$options": "i"}},
+			{"jurusan": bson.M{"$regex": pattern, "$options": "i"}},
+			{"email": bson.M{"$regex": pattern, "$options": "i"}},
+			{"nim": nimPrefix(q)},
+		}
+		if isPhone {
+			or = append(or, bson.M{"no_telepon": bson.M{"$regex": tel}})
+		}
+		filter["$or"] = or
+	}
+	return activeAlumni(filter)
+}
+
+// SuggestAlumni - autocomplete: alumni yang NIM-nya diawali q, atau yang salah
+// satu kata di namanya diawali q (tanpa peduli diakritik)
+func SuggestAlumni(ctx context.Context, q string, limit int) ([]model.AlumniSuggestion, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	q = strings.TrimSpace(q)
+	filter := bson.M{"nama": bson.M{"$regex": `(^|\s)` + foldPattern(q), "$options": "i"}}
+	sortBy := "nama"
+	if looksLikeNIM(q) {
+		filter = bson.M{"nim": nimPrefix(q)}
+		sortBy = "nim"
+	}
+
+	opts := options.Find().
+		SetProjection(bson.M{"nim": 1, "nama": 1, "jurusan": 1}).
+		SetSort(bson.D{{Key: sortBy, Value: 1}}).
+		SetLimit(int64(limit))
+	cursor, err := database.AlumniCollection.Find(ctx, activeAlumni(filter), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	list := []model.AlumniSuggestion{}
+	if err := cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// looksLikeNIM - satu kata berisi huruf/angka/titik/strip dengan minimal satu angka
+func looksLikeNIM(q string) bool {
+	return nimLike.MatchString(q) && strings.ContainsAny(q, "0123456789")
+}
+
+var nimLike = regexp.MustCompile(`^[A-Za-z0-9.\-]+$`)
+
+// nimPrefix - awalan NIM; regex berjangkar tanpa opsi "i" supaya memakai index
+func nimPrefix(q string) bson.M {
+	return bson.M{"$regex": "^" + regexp.QuoteMeta(q)}
+}
+
+// diacriticClasses - huruf dasar dan variasi beraksen yang dianggap sama
+var diacriticClasses = map[rune]string{
+	'a': "aàáâãäåā", 'c': "cç", 'e': "eèéêëē", 'i': "iìíîïī",
+	'n': "nñ", 'o': "oòóôõöøō", 'u': "uùúûüū", 'y': "yýÿ",
+}
+
+// baseLetter mengembalikan huruf dasar dari huruf beraksen (é -> e)
+func baseLetter(r rune) rune {
+	r = unicode.ToLower(r)
+	for base, class := range diacriticClasses {
+		if strings.ContainsRune(class, r) {
+			return base
+		}
+	}
+	return r
+}
+
+// foldPattern mengubah kata kunci menjadi regex yang aman (metakarakter di-escape)
+// dan tidak peduli diakritik: "jose" dan "José" sama-sama cocok dengan "José".
+func foldPattern(q string) string {
+	var b strings.Builder
+	for _, r := range q {
+		base := baseLetter(r)
+		class, ok := diacriticClasses[base]
+		if !ok {
+			b.WriteString(regexp.QuoteMeta(string(r)))
+			continue
+		}
+		// huruf besar ikut dimasukkan; opsi "i" belum tentu berlaku untuk non-ASCII
+		b.WriteString("[" + class + strings.ToUpper(class) + "]")
+	}
+	return b.String()
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+
+	"crud_alumni/app/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestFoldPattern_EscapesAndIgnoresDiacritics(t *testing.T) {
+	re := regexp.MustCompile("(?i)" + foldPattern("jose (s.kom"))
+	for _, name := range []string{"José (S.Kom)", "JOSÉ (s.kom", "jose (s.kom"} {
+		if !re.MatchString(name) {
+			t.Errorf("expected %q cocok", name)
+		}
+	}
+	if re.MatchString("jose xs.kom") {
+		t.Errorf("expected metakarakter '(' dan '.' di-escape")
+	}
+	if re := regexp.MustCompile(foldPattern("Ñoño")); !re.MatchString("nono") {
+		t.Errorf("expected huruf beraksen di kata kunci ikut dilonggarkan")
+	}
+}
+
+func TestAlumniSearchFilter_Modes(t *testing.T) {
+	text := alumniSearchFilter(model.AlumniSearch{Query: "siti aisyah", Mode: model.AlumniSearchText})
+	if text["$text"] == nil || text["$or"] != nil {
+		t.Errorf("expected $text untuk search_mode=text, got %v", text)
+	}
+
+	nim := alumniSearchFilter(model.AlumniSearch{Query: "2019.01", Mode: model.AlumniSearchText})
+	if re := nim["nim"].(bson.M)["$regex"]; re != `^2019\.01` {
+		t.Errorf("expected awalan NIM ter-escape, got %v", nim)
+	}
+
+	tel := alumniSearchFilter(model.AlumniSearch{Query: "0812-3456", Mode: model.AlumniSearchText})
+	if or, _ := tel["$or"].([]bson.M); len(or) != 2 || or[1]["no_telepon"].(bson.M)["$regex"] != `^\+628123456` {
+		t.Errorf("expected awalan NIM atau no_telepon +62, got %v", tel)
+	}
+
+	contains := alumniSearchFilter(model.AlumniSearch{Query: "a.*"})
+	or := contains["$or"].([]bson.M)
+	if re := or[0]["nama"].(bson.M)["$regex"]; re != `[aàáâãäåāAÀÁÂÃÄÅĀ]\.\*` {
+		t.Errorf("expected regex ter-escape, got %v", re)
+	}
+}
+
+func TestAlumniFindOptions_Relevance(t *testing.T) {
+	text := alumniFindOptions(bson.M{"$text": bson.M{"$search": "budi"}}, SortRelevance, "asc")
+	if text.Projection == nil || text.Sort.(bson.D)[0].Key != "score" {
+		t.Errorf("expected sort berdasarkan skor, got %+v", text.Sort)
+	}
+
+	// tanpa $text (mis. kata kunci berupa NIM) relevance diganti nama
+	plain := alumniFindOptions(bson.M{}, SortRelevance, "asc")
+	if plain.Projection != nil || plain.Sort.(bson.D)[0].Key != "nama" {
+		t.Errorf("expected fallback ke nama, got %+v", plain.Sort)
+	}
+}
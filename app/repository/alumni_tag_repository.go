@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"crud_alumni/app/model"
+	"crud_alumni/database"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AlumniTagTargets - paling banyak limit alumni aktif dengan _id setelah
+// after (urut _id) yang cocok dengan pencarian dan filter (sama seperti
+// CountAlumni) dan akan berubah oleh bulk tag: belum punya semua tag add
+// atau punya salah satu tag remove. Dibaca per batch supaya setiap alumni
+// bisa diubah dengan cek versi dan dicatat di riwayat.
+func AlumniTagTargets(ctx context.Context, search model.AlumniSearch, f model.AlumniFilter, add, remove []string, after primitive.ObjectID, limit int64) ([]model.Alumni, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	q := alumniListQuery(search, f)
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(limit)
+	cursor, err := readShape{}.joined(q.join).find(ctx, database.AlumniCollection, tagTargetFilter(q.filter, add, remove, after), opts)
+	if err != nil {
+		return nil, err
+	}
+	var list []model.Alumni
+	if err := cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// tagTargetFilter - filter ditambah syarat bulk tag, tanpa menghilangkan
+// syarat tags dari ?tag=
+func tagTargetFilter(filter bson.M, add, remove []string, after primitive.ObjectID) bson.M {
+	var changes bson.A
+	if len(add) > 0 {
+		changes = append(changes, bson.M{"tags": bson.M{"$not": bson.M{"$all": add}}})
+	}
+	if len(remove) > 0 {
+		changes = append(changes, bson.M{"tags": bson.M{"$in": remove}})
+	}
+	return bson.M{"$and": bson.A{filter, bson.M{"$or": changes}, bson.M{"_id": bson.M{"$gt": after}}}}
+}
+
+// SetAlumniTags menyimpan a.Tags (dihapus jika kosong) pada alumni aktif.
+// Seperti ReplaceAlumni, a.Version harus berisi versi yang dibaca
+// sebelumnya; versi dan updated_at di a diperbarui.
+func SetAlumniTags(ctx context.Context, a *model.Alumni) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	expected := a.Version
+	a.Version++
+	a.UpdatedAt = time.Now().Format("2006-01-02 15:04:05")
+	update := bson.M{
+		"$set": bson.M{"tags": a.Tags, "updated_at": a.UpdatedAt},
+		"$inc": bson.M{"version": 1},
+	}
+	if len(a.Tags) == 0 {
+		update["$set"] = bson.M{"updated_at": a.UpdatedAt}
+		update["$unset"] = bson.M{"tags": ""}
+	}
+	return checkVersionMatched(database.AlumniCollection.UpdateOne(ctx, activeAlumni(versionFilter(a.ID, expected)), update))
+}
+
+// AlumniTagCounts - semua tag yang dipakai alumni aktif beserta jumlahnya,
+// urut dari yang paling banyak
+func AlumniTagCounts(ctx context.Context) ([]model.TagCount, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	cursor, err := database.AlumniCollection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: activeAlumni(bson.M{"tags.0": bson.M{"$exists": true}})}},
+		{{Key: "$unwind", Value: "$tags"}},
+		{{Key: "$group", Value: bson.M{"_id": "$tags", "count": bson.M{"$sum": 1}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	list := []model.TagCount{}
+	if err := cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
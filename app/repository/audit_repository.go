@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"context"
+	"crud_alumni/app/model"
+	"crud_alumni/database"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AuditCollection - nama koleksi audit log (append-only)
+const AuditCollection = "audit_log"
+
+// auditAppendRetries - berapa kali AppendAudit mengulang jika instance lain
+// menulis seq yang sama lebih dulu
+const auditAppendRetries = 5
+
+// AppendAuditFunc menggantikan AppendAudit di test supaya tidak mengakses MongoDB
+var AppendAuditFunc func(ctx context.Context, e *model.AuditEntry) error
+
+// auditMu - satu penulis per proses supaya request paralel tidak berebut seq
+var auditMu sync.Mutex
+
+func auditCollection() *mongo.Collection {
+	return database.DB.Collection(AuditCollection)
+}
+
+// AppendAudit menambahkan e di ujung rantai: Seq dan PrevHash diambil dari
+// entri terakhir, lalu Hash dihitung. Unique index seq menjaga rantai tetap
+// satu walau ada beberapa instance server; yang kalah mengulang dari ujung
+// rantai terbaru.
+func AppendAudit(ctx context.Context, e *model.AuditEntry) error {
+	if AppendAuditFunc != nil {
+		return AppendAuditFunc(ctx, e)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	auditMu.Lock()
+	defer auditMu.Unlock()
+
+	if e.At.IsZero() {
+		e.At = time.Now()
+	}
+	// MongoDB menyimpan waktu dalam milidetik; hash harus sama setelah dibaca ulang
+	e.At = e.At.UTC().Truncate(time.Millisecond)
+
+	last := options.FindOne().SetSort(bson.D{{Key: "seq", Value: -1}}).SetProjection(bson.M{"seq": 1, "hash": 1})
+	for attempt := 0; ; attempt++ {
+		var prev model.AuditEntry
+		err := auditCollection().FindOne(ctx, bson.M{}, last).Decode(&prev)
+		if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+			return err
+		}
+		e.ID = primitive.NewObjectID()
+		e.Seq, e.PrevHash = prev.Seq+1, prev.Hash
+		e.Hash = AuditHash(*e)
+
+		_, err = auditCollection().InsertOne(ctx, e)
+		if mongo.IsDuplicateKeyError(err) && attempt < auditAppendRetries {
+			continue
+		}
+		return err
+	}
+}
+
+// AuditHash - SHA-256 (hex) dari isi entri termasuk PrevHash, tanpa ID dan
+// Hash itu sendiri. JSON dipakai sebagai bentuk kanonik: urutan field tetap
+// dan key Detail diurutkan.
+func AuditHash(e model.AuditEntry) string {
+	e.ID, e.Hash = primitive.NilObjectID, ""
+	e.At = e.At.UTC()
+	raw, _ := json.Marshal(e)
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// auditLinked - e sah sebagai penerus prev (prev nil untuk entri pertama)
+func auditLinked(prev *model.AuditEntry, e model.AuditEntry) bool {
+	var seq int64
+	var hash string
+	if prev != nil {
+		seq, hash = prev.Seq, prev.Hash
+	}
+	return e.Seq == seq+1 && e.PrevHash == hash && e.Hash == AuditHash(e)
+}
+
+// auditQuery - filter MongoDB dari AuditFilter
+func auditQuery(f model.AuditFilter) bson.M {
+	q := bson.M{}
+	if f.Actor != "" {
+		q["$or"] = []bson.M{{"actor_id": f.Actor}, {"actor_name": f.Actor}}
+	}
+	if f.Action != "" {
+		q["action"] = f.Action
+	}
+	if f.Resource != "" {
+		q["resource"] = f.Resource
+	}
+	at := bson.M{}
+	if t, err := time.Parse("2006-01-02", f.From); err == nil {
+		at["$gte"] = t
+	}
+	if t, err := time.Parse("2006-01-02", f.To); err == nil {
+		at["$lt"] = t.AddDate(0, 0, 1)
+	}
+	if len(at) > 0 {
+		q["at"] = at
+	}
+	return q
+}
+
+// AuditPage - satu halaman keyset audit log sesuai filter
+func AuditPage(ctx context.Context, f model.AuditFilter, req PageRequest) (Page[model.AuditEntry], error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	return findPage[model.AuditEntry](ctx, auditCollection(), auditQuery(f), req, readShape{})
+}
+
+type AuditIterator struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	cursor *mongo.Cursor
+}
+
+// IterateAudit - semua entri sesuai filter urut seq naik (untuk export dan
+// pemeriksaan rantai). timeout berlaku untuk seluruh iterasi.
+func IterateAudit(ctx context.Context, f model.AuditFilter, timeout time.Duration) (*AuditIterator, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+
+	opts := options.Find().SetSort(bson.D{{Key: "seq", Value: 1}}).SetBatchSize(500)
+	cursor, err := auditCollection().Find(ctx, auditQuery(f), opts)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &AuditIterator{ctx: ctx, cancel: cancel, cursor: cursor}, nil
+}
+
+// Next mengisi e dengan entri berikutnya; false jika sudah habis atau error
+func (it *AuditIterator) Next(e *model.AuditEntry) (bool, error) {
+	if !it.cursor.Next(it.ctx) {
+		return false, it.cursor.Err()
+	}
+	return true, it.cursor.Decode(e)
+}
+
+func (it *AuditIterator) Close() error {
+	defer it.cancel()
+	return it.cursor.Close(it.ctx)
+}
+
+// VerifyAudit memeriksa seluruh rantai dari entri pertama. Berhenti di
+// entri pertama yang rusak: isinya diubah, ada entri yang hilang di tengah,
+// atau urutannya tidak bersambung.
+func VerifyAudit(ctx context.Context, timeout time.Duration) (model.AuditVerifyResult, error) {
+	result := model.AuditVerifyResult{Valid: true}
+	it, err := IterateAudit(ctx, model.AuditFilter{}, timeout)
+	if err != nil {
+		return result, err
+	}
+	defer it.Close()
+
+	var prev *model.AuditEntry
+	for {
+		var e model.AuditEntry
+		ok, err := it.Next(&e)
+		if err != nil {
+			return result, err
+		}
+		if !ok {
+			break
+		}
+		result.Checked++
+		if !auditLinked(prev, e) {
+			result.Valid, result.BrokenSeq = false, e.Seq
+			return result, nil
+		}
+		prev = &e
+		result.LastSeq, result.LastHash = e.Seq, e.Hash
+	}
+	return result, nil
+}
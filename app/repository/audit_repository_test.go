@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"crud_alumni/app/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func auditChain(n int) []model.AuditEntry {
+	chain := make([]model.AuditEntry, n)
+	prevHash := ""
+	for i := range chain {
+		e := model.AuditEntry{
+			Seq:      int64(i + 1),
+			At:       time.Date(2026, 10, 1, 8, 0, i, 123e6, time.UTC),
+			ActorID:  "u1",
+			Action:   model.AuditExport,
+			Resource: model.ResourceAlumni,
+			Detail:   map[string]string{"format": "csv", "columns": "nim,nama"},
+			PrevHash: prevHash,
+		}
+		e.Hash = AuditHash(e)
+		prevHash = e.Hash
+		chain[i] = e
+	}
+	return chain
+}
+
+func verifyChain(chain []model.AuditEntry) int64 {
+	var prev *model.AuditEntry
+	for i := range chain {
+		if !auditLinked(prev, chain[i]) {
+			return chain[i].Seq
+		}
+		prev = &chain[i]
+	}
+	return 0
+}
+
+func TestAuditHash_SurvivesBSONRoundTrip(t *testing.T) {
+	e := auditChain(1)[0]
+	raw, err := bson.Marshal(e)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	var back model.AuditEntry
+	if err := bson.Unmarshal(raw, &back); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if got := AuditHash(back); got != e.Hash {
+		t.Errorf("hash changed after round trip: %s != %s", got, e.Hash)
+	}
+}
+
+func TestAuditLinked_DetectsTampering(t *testing.T) {
+	if broken := verifyChain(auditChain(4)); broken != 0 {
+		t.Fatalf("expected valid chain, broken at %d", broken)
+	}
+
+	edited := auditChain(4)
+	edited[1].ActorID = "u2"
+	if broken := verifyChain(edited); broken != 2 {
+		t.Errorf("edited entry: expected broken at 2, got %d", broken)
+	}
+
+	// hash entri yang diubah ikut dihitung ulang: entri berikutnya tidak lagi cocok
+	rehashed := auditChain(4)
+	rehashed[1].Detail["format"] = "xlsx"
+	rehashed[1].Hash = AuditHash(rehashed[1])
+	if broken := verifyChain(rehashed); broken != 3 {
+		t.Errorf("rehashed entry: expected broken at 3, got %d", broken)
+	}
+
+	removed := auditChain(4)
+	removed = append(removed[:2], removed[3:]...)
+	if broken := verifyChain(removed); broken != 4 {
+		t.Errorf("removed entry: expected broken at 4, got %d", broken)
+	}
+}
+
+func TestAuditQuery(t *testing.T) {
+	q := auditQuery(model.AuditFilter{Actor: "admin", Action: model.AuditLogin, From: "2026-10-01", To: "2026-10-02"})
+	if q["action"] != model.AuditLogin {
+		t.Errorf("unexpected action filter: %v", q)
+	}
+	if or, ok := q["$or"].([]bson.M); !ok || len(or) != 2 {
+		t.Errorf("actor must match id or name, got %v", q["$or"])
+	}
+	at := q["at"].(bson.M)
+	if !at["$gte"].(time.Time).Equal(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)) ||
+		!at["$lt"].(time.Time).Equal(time.Date(2026, 10, 3, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("to must be inclusive, got %v", at)
+	}
+	if len(auditQuery(model.AuditFilter{})) != 0 {
+		t.Errorf("empty filter must match everything")
+	}
+}
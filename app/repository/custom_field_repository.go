@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"crud_alumni/app/model"
+	"crud_alumni/database"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CustomFieldCollection - definisi custom field alumni
+const CustomFieldCollection = "custom_fields"
+
+// ListCustomFieldsFunc menggantikan ListCustomFields di test supaya tidak
+// mengakses MongoDB
+var ListCustomFieldsFunc func(ctx context.Context) ([]model.CustomField, error)
+
+func customFieldCollection() *mongo.Collection {
+	return database.DB.Collection(CustomFieldCollection)
+}
+
+// ListCustomFields - semua definisi custom field, urut nama
+func ListCustomFields(ctx context.Context) ([]model.CustomField, error) {
+	if ListCustomFieldsFunc != nil {
+		return ListCustomFieldsFunc(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	cursor, err := customFieldCollection().Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "name", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	list := []model.CustomField{}
+	if err := cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// GetCustomField - satu definisi berdasarkan _id
+func GetCustomField(ctx context.Context, id string) (model.CustomField, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	var f model.CustomField
+	objID, err := parseObjectID(id)
+	if err != nil {
+		return f, err
+	}
+	err = customFieldCollection().FindOne(ctx, bson.M{"_id": objID}).Decode(&f)
+	return f, mapError(err)
+}
+
+// CreateCustomField menyimpan definisi baru; nama yang sudah dipakai
+// menghasilkan ErrDuplicate. ID, versi, dan waktu di f diisi.
+func CreateCustomField(ctx context.Context, f *model.CustomField) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	now := time.Now().Format("2006-01-02 15:04:05")
+	f.ID, f.Version, f.CreatedAt, f.UpdatedAt = primitive.NewObjectID(), InitialVersion, now, now
+	_, err := customFieldCollection().InsertOne(ctx, f)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrDuplicate
+	}
+	return err
+}
+
+// UpdateCustomField menyimpan label, options, required, dan visibility f
+// jika versinya masih sama. Versi dan updated_at di f diperbarui.
+func UpdateCustomField(ctx context.Context, f *model.CustomField) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	expected := f.Version
+	f.Version++
+	f.UpdatedAt = time.Now().Format("2006-01-02 15:04:05")
+	return checkVersionMatched(customFieldCollection().UpdateOne(ctx, versionFilter(f.ID, expected), bson.M{
+		"$set": bson.M{
+			"label":      f.Label,
+			"options":    f.Options,
+			"required":   f.Required,
+			"visibility": f.Visibility,
+			"updated_at": f.UpdatedAt,
+			"version":    f.Version,
+		},
+	}))
+}
+
+// DeleteCustomField menghapus definisi jika versinya masih sama
+func DeleteCustomField(ctx context.Context, id primitive.ObjectID, version int64) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	return checkVersionDeleted(customFieldCollection().DeleteOne(ctx, versionFilter(id, version)))
+}
+
+// AlumniWithCustom - paling banyak limit alumni (termasuk yang di trash)
+// dengan _id setelah after (urut _id) yang punya nilai custom field name
+func AlumniWithCustom(ctx context.Context, name string, after primitive.ObjectID, limit int64) ([]model.Alumni, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	filter := bson.M{"custom." + name: bson.M{"$exists": true}, "_id": bson.M{"$gt": after}}
+	cursor, err := database.AlumniCollection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	var list []model.Alumni
+	if err := cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// UnsetAlumniCustom menghapus nilai custom field name dari alumni a
+// (termasuk yang di trash). a.Version harus berisi versi yang dibaca
+// sebelumnya; nilai custom, versi, dan updated_at di a diperbarui.
+func UnsetAlumniCustom(ctx context.Context, a *model.Alumni, name string) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	expected := a.Version
+	a.Version++
+	a.UpdatedAt = time.Now().Format("2006-01-02 15:04:05")
+	custom := make(map[string]any, len(a.Custom))
+	for k, v := range a.Custom {
+		if k != name {
+			custom[k] = v
+		}
+	}
+	a.Custom = nil
+	if len(custom) > 0 {
+		a.Custom = custom
+	}
+	return checkVersionMatched(database.AlumniCollection.UpdateOne(ctx, versionFilter(a.ID, expected), bson.M{
+		"$set":   bson.M{"updated_at": a.UpdatedAt},
+		"$unset": bson.M{"custom." + name: ""},
+		"$inc":   bson.M{"version": 1},
+	}))
+}
@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"crud_alumni/app/model"
+	"crud_alumni/database"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DuplicateDismissalCollection - pasangan alumni yang ditandai bukan duplikat
+const DuplicateDismissalCollection = "duplicate_dismissals"
+
+func dismissalCollection() *mongo.Collection {
+	return database.DB.Collection(DuplicateDismissalCollection)
+}
+
+// ActiveAlumni - semua alumni di luar trash (untuk pencarian duplikat)
+func ActiveAlumni(ctx context.Context) ([]model.Alumni, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	cursor, err := database.AlumniCollection.Find(ctx, activeAlumni(bson.M{}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	list := []model.Alumni{}
+	if err := cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// dismissalPair - pasangan dengan urutan tetap (ID lebih kecil lebih dulu)
+func dismissalPair(a, b primitive.ObjectID) (primitive.ObjectID, primitive.ObjectID) {
+	if a.Hex() > b.Hex() {
+		return b, a
+	}
+	return a, b
+}
+
+// DismissDuplicate menandai pasangan a-b bukan duplikat. Menandai ulang
+// pasangan yang sama hanya memperbarui pelaku dan waktunya.
+func DismissDuplicate(ctx context.Context, a, b primitive.ObjectID, by string) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	a, b = dismissalPair(a, b)
+	_, err := dismissalCollection().UpdateOne(ctx,
+		bson.M{"a": a, "b": b},
+		bson.M{"$set": bson.M{"dismissed_by": by, "dismissed_at": time.Now()}},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+// DismissedDuplicates - key "a:b" (lihat DuplicateKey) semua pasangan yang
+// sudah ditandai bukan duplikat
+func DismissedDuplicates(ctx context.Context) (map[string]bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	cursor, err := dismissalCollection().Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var list []model.DuplicateDismissal
+	if err := cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	keys := make(map[string]bool, len(list))
+	for _, d := range list {
+		keys[DuplicateKey(d.A, d.B)] = true
+	}
+	return keys, nil
+}
+
+// DuplicateKey - key pasangan yang sama untuk a-b dan b-a
+func DuplicateKey(a, b primitive.ObjectID) string {
+	a, b = dismissalPair(a, b)
+	return a.Hex() + ":" + b.Hex()
+}
+
+// MergeAlumniInto memindahkan alumni duplikat ke trash dengan merged_into
+// berisi survivor, sehingga masih bisa dipulihkan sampai di-purge
+func MergeAlumniInto(ctx context.Context, id primitive.ObjectID, version int64, deletedBy string, into primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	now := time.Now()
+	return alumniSaveError(checkVersionMatched(database.AlumniCollection.UpdateOne(ctx, activeAlumni(versionFilter(id, version)), bson.M{
+		"$set": bson.M{
+			"deleted_at":  now,
+			"deleted_by":  deletedBy,
+			"merged_into": into,
+			"nim_scope":   id, // lepas dari index unik NIM, lihat migrasi 20261029
+			"updated_at":  now.Format("2006-01-02 15:04:05"),
+		},
+		"$inc": bson.M{"version": 1},
+	})))
+}
+
+// RepointPekerjaan memindahkan semua pekerjaan (termasuk yang di trash) dari
+// id lama alumni from ke to
+func RepointPekerjaan(ctx context.Context, from, to int) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	res, err := database.PekerjaanCollection.UpdateMany(ctx, bson.M{"alumni_id": from}, bson.M{
+		"$set": bson.M{"alumni_id": to, "updated_at": time.Now()},
+		"$inc": bson.M{"version": 1},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+// FindUsersByEmails - akun user yang email-nya sama dengan salah satu emails
+// (tanpa membedakan huruf besar/kecil), tanpa hash password
+func FindUsersByEmails(ctx context.Context, emails []string) ([]model.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	var match bson.A
+	for _, e := range emails {
+		if e = strings.TrimSpace(e); e != "" {
+			match = append(match, primitive.Regex{Pattern: "^" + regexp.QuoteMeta(e) + "$", Options: "i"})
+		}
+	}
+	if len(match) == 0 {
+		return nil, nil
+	}
+
+	cursor, err := database.UserCollection.Find(ctx, bson.M{"email": bson.M{"$in": match}}, options.Find().SetProjection(bson.M{"password_hash": 0}))
+	if err != nil {
+		return nil, err
+	}
+	var found []model.User
+	if err := cursor.All(ctx, &found); err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// RepointUsers mengganti email akun users menjadi to, sehingga akun dan file
+// miliknya terhubung ke alumni dengan email to. Mengembalikan jumlah user
+// yang diubah dan jumlah file milik user tersebut.
+func RepointUsers(ctx context.Context, users []model.User, to string) (moved, files int64, err error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	if len(users) == 0 {
+		return 0, 0, nil
+	}
+	ids := make([]primitive.ObjectID, len(users))
+	for i, u := range users {
+		ids[i] = u.ID
+	}
+
+	res, err := database.UserCollection.UpdateMany(ctx, bson.M{"_id": bson.M{"$in": ids}}, bson.M{
+		"$set": bson.M{"email": to},
+		"$inc": bson.M{"version": 1},
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	files, err = database.DB.Collection(FilesCollection).CountDocuments(ctx, bson.M{"user_id": bson.M{"$in": ids}})
+	return res.ModifiedCount, files, err
+}
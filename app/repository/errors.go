@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Sentinel error repository. Service memetakan error ini ke apperror
+// (ErrNotFound -> 404, ErrInvalidID/ErrInvalidCursor -> 400,
+// ErrVersionConflict -> 412, ErrDuplicate -> 409); error lain dianggap 500.
+var (
+	ErrNotFound        = errors.New("data tidak ditemukan")
+	ErrInvalidID       = errors.New("id tidak valid")
+	ErrVersionConflict = errors.New("versi data sudah berubah")
+	ErrInvalidCursor   = errors.New("cursor tidak valid") // rusak atau dibuat untuk urutan lain
+	ErrDuplicate       = errors.New("data sudah ada")     // melanggar unique index
+)
+
+// parseObjectID mengubah hex string ke ObjectID atau ErrInvalidID
+func parseObjectID(id string) (primitive.ObjectID, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return primitive.NilObjectID, ErrInvalidID
+	}
+	return objID, nil
+}
+
+// mapError menerjemahkan error driver ke sentinel repository
+func mapError(err error) error {
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// checkMatched mengembalikan ErrNotFound jika update tidak mengenai dokumen apa pun
+func checkMatched(res *mongo.UpdateResult, err error) error {
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// InitialVersion - versi dokumen yang baru dibuat
+const InitialVersion int64 = 1
+
+// versionFilter - filter dokumen dengan _id dan versi tertentu. Dokumen lama
+// yang belum punya field version dianggap versi 0.
+func versionFilter(id primitive.ObjectID, version int64) bson.M {
+	if version == 0 {
+		return bson.M{"_id": id, "version": bson.M{"$in": bson.A{0, nil}}}
+	}
+	return bson.M{"_id": id, "version": version}
+}
+
+// checkVersionMatched mengembalikan ErrVersionConflict jika update bersyarat
+// versi tidak mengenai dokumen (sudah diubah atau dihapus request lain)
+func checkVersionMatched(res *mongo.UpdateResult, err error) error {
+	if err := checkMatched(res, err); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return ErrVersionConflict
+		}
+		return err
+	}
+	return nil
+}
+
+// checkVersionDeleted - seperti checkVersionMatched untuk delete
+func checkVersionDeleted(res *mongo.DeleteResult, err error) error {
+	if err := checkDeleted(res, err); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return ErrVersionConflict
+		}
+		return err
+	}
+	return nil
+}
+
+// checkDeleted mengembalikan ErrNotFound jika delete tidak menghapus dokumen apa pun
+func checkDeleted(res *mongo.DeleteResult, err error) error {
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
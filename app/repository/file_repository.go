@@ -10,25 +10,30 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
+// FilesCollection - nama koleksi metadata file
+const FilesCollection = "files"
+
 type FileRepository struct {
     Collection *mongo.Collection
 }
 type FileRepo interface {
-	Create(file *model.File) error
-	GetAll() ([]model.File, error)
-	GetByUserID(userID string) ([]model.File, error)
-	GetByID(id string) (*model.File, error)
-	DeleteByID(id string) error
+	Create(ctx context.Context, file *model.File) error
+	GetAll(ctx context.Context) ([]model.File, error)
+	GetByUserID(ctx context.Context, userID string) ([]model.File, error)
+	Page(ctx context.Context, userID string, req PageRequest) (Page[model.File], error)
+	GetByID(ctx context.Context, id string) (*model.File, error)
+	Verify(ctx context.Context, id string, verifiedBy string) error
+	DeleteByID(ctx context.Context, id string) error
 }
 
 func NewFileRepository(db *mongo.Database) *FileRepository {
     return &FileRepository{
-        Collection: db.Collection("files"),
+        Collection: db.Collection(FilesCollection),
     }
 }
 
-func (r *FileRepository) Create(file *model.File) error {
-    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func (r *FileRepository) Create(ctx context.Context, file *model.File) error {
+    ctx, cancel := context.WithTimeout(ctx, dbTimeout())
     defer cancel()
 
     file.UploadedAt = time.Now()
@@ -36,8 +41,8 @@ func (r *FileRepository) Create(file *model.File) error {
     return err
 }
 
-func (r *FileRepository) FindByUser(userID primitive.ObjectID) ([]model.File, error) {
-    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func (r *FileRepository) FindByUser(ctx context.Context, userID primitive.ObjectID) ([]model.File, error) {
+    ctx, cancel := context.WithTimeout(ctx, dbTimeout())
     defer cancel()
 
     cursor, err := r.Collection.Find(ctx, bson.M{"user_id": userID})
@@ -51,46 +56,78 @@ func (r *FileRepository) FindByUser(userID primitive.ObjectID) ([]model.File, er
     }
     return files, nil
 }
-func (r *FileRepository) GetAll() ([]model.File, error) {
+func (r *FileRepository) GetAll(ctx context.Context) ([]model.File, error) {
 	var files []model.File
-	cursor, err := r.Collection.Find(context.TODO(), bson.M{})
+	cursor, err := r.Collection.Find(ctx, bson.M{})
 	if err != nil {
 		return nil, err
 	}
-	defer cursor.Close(context.TODO())
-	cursor.All(context.TODO(), &files)
+	defer cursor.Close(ctx)
+	cursor.All(ctx, &files)
 	return files, nil
 }
 
-func (r *FileRepository) GetByUserID(userID string) ([]model.File, error) {
+func (r *FileRepository) GetByUserID(ctx context.Context, userID string) ([]model.File, error) {
 	oid, _ := primitive.ObjectIDFromHex(userID)
 	filter := bson.M{"user_id": oid}
 	var files []model.File
-	cursor, err := r.Collection.Find(context.TODO(), filter)
+	cursor, err := r.Collection.Find(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
-	defer cursor.Close(context.TODO())
-	cursor.All(context.TODO(), &files)
+	defer cursor.Close(ctx)
+	cursor.All(ctx, &files)
 	return files, nil
 }
 
-func (r *FileRepository) GetByID(id string) (*model.File, error) {
-	oid, err := primitive.ObjectIDFromHex(id)
+// Page - satu halaman keyset file; userID kosong berarti semua file
+func (r *FileRepository) Page(ctx context.Context, userID string, req PageRequest) (Page[model.File], error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	filter := bson.M{}
+	if userID != "" {
+		oid, err := parseObjectID(userID)
+		if err != nil {
+			return Page[model.File]{}, err
+		}
+		filter["user_id"] = oid
+	}
+	return findPage[model.File](ctx, r.Collection, filter, req, readShape{})
+}
+
+func (r *FileRepository) GetByID(ctx context.Context, id string) (*model.File, error) {
+	oid, err := parseObjectID(id)
 	if err != nil {
 		return nil, err
 	}
 
 	var file model.File
-	err = r.Collection.FindOne(context.TODO(), bson.M{"_id": oid}).Decode(&file)
+	err = r.Collection.FindOne(ctx, bson.M{"_id": oid}).Decode(&file)
 	if err != nil {
-		return nil, err
+		return nil, mapError(err)
 	}
 	return &file, nil
 }
 
-func (r *FileRepository) DeleteByID(id string) error {
-	oid, _ := primitive.ObjectIDFromHex(id)
-	_, err := r.Collection.DeleteOne(context.TODO(), bson.M{"_id": oid})
-	return err
+// Verify menandai file sudah diverifikasi admin verifiedBy
+func (r *FileRepository) Verify(ctx context.Context, id string, verifiedBy string) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	oid, err := parseObjectID(id)
+	if err != nil {
+		return err
+	}
+	return checkMatched(r.Collection.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{
+		"$set": bson.M{"verified_at": time.Now(), "verified_by": verifiedBy},
+	}))
+}
+
+func (r *FileRepository) DeleteByID(ctx context.Context, id string) error {
+	oid, err := parseObjectID(id)
+	if err != nil {
+		return err
+	}
+	return checkDeleted(r.Collection.DeleteOne(ctx, bson.M{"_id": oid}))
 }
\ No newline at end of file
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"crud_alumni/app/model"
+	"crud_alumni/database"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// HistoryCollection - nama koleksi riwayat perubahan per record
+const HistoryCollection = "record_history"
+
+func historyCollection() *mongo.Collection {
+	return database.DB.Collection(HistoryCollection)
+}
+
+// InsertHistory menyimpan satu entri riwayat; ID dan waktu diisi jika kosong
+func InsertHistory(ctx context.Context, e *model.HistoryEntry) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	if e.ID.IsZero() {
+		e.ID = primitive.NewObjectID()
+	}
+	if e.At.IsZero() {
+		e.At = time.Now()
+	}
+	_, err := historyCollection().InsertOne(ctx, e)
+	return err
+}
+
+// ListHistory - riwayat satu record, yang terbaru lebih dulu
+func ListHistory(ctx context.Context, resource, id string) ([]model.HistoryEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	objID, err := parseObjectID(id)
+	if err != nil {
+		return nil, err
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetProjection(bson.M{"snapshot": 0})
+	cursor, err := historyCollection().Find(ctx, bson.M{"resource": resource, "record_id": objID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	list := []model.HistoryEntry{}
+	if err := cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// GetHistoryVersion - entri riwayat yang menghasilkan versi tertentu dan
+// masih menyimpan isi record (bukan purge)
+func GetHistoryVersion(ctx context.Context, resource, id string, version int64) (model.HistoryEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	var e model.HistoryEntry
+	objID, err := parseObjectID(id)
+	if err != nil {
+		return e, err
+	}
+	filter := bson.M{"resource": resource, "record_id": objID, "version": version, "snapshot": bson.M{"$exists": true}}
+	opts := options.FindOne().SetSort(bson.D{{Key: "at", Value: -1}})
+	err = historyCollection().FindOne(ctx, filter, opts).Decode(&e)
+	return e, mapError(err)
+}
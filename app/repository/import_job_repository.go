@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"crud_alumni/app/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ImportJobRepo - penyimpanan status job import alumni. Disimpan di MongoDB
+// (bukan memori) supaya progress bisa dipantau dari instance mana pun.
+type ImportJobRepo interface {
+	Create(ctx context.Context, job *model.ImportJob) error
+	GetByID(ctx context.Context, id string) (*model.ImportJob, error)
+	UpdateProgress(ctx context.Context, id primitive.ObjectID, status string, processed int) error
+	Finish(ctx context.Context, id primitive.ObjectID, report *model.ImportReport, errMsg string) error
+	FailUnfinished(ctx context.Context, errMsg string) (int64, error)
+}
+
+type ImportJobRepository struct {
+	Collection *mongo.Collection
+}
+
+func NewImportJobRepository(db *mongo.Database) *ImportJobRepository {
+	return &ImportJobRepository{Collection: db.Collection("import_jobs")}
+}
+
+func (r *ImportJobRepository) Create(ctx context.Context, job *model.ImportJob) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	job.ID = primitive.NewObjectID()
+	job.CreatedAt = time.Now()
+	job.UpdatedAt = job.CreatedAt
+	_, err := r.Collection.InsertOne(ctx, job)
+	return err
+}
+
+func (r *ImportJobRepository) GetByID(ctx context.Context, id string) (*model.ImportJob, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	oid, err := parseObjectID(id)
+	if err != nil {
+		return nil, err
+	}
+	var job model.ImportJob
+	if err := r.Collection.FindOne(ctx, bson.M{"_id": oid}).Decode(&job); err != nil {
+		return nil, mapError(err)
+	}
+	return &job, nil
+}
+
+func (r *ImportJobRepository) UpdateProgress(ctx context.Context, id primitive.ObjectID, status string, processed int) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	return checkMatched(r.Collection.UpdateByID(ctx, id, bson.M{
+		"$set": bson.M{"status": status, "processed": processed, "updated_at": time.Now()},
+	}))
+}
+
+// Finish menandai job selesai (errMsg kosong) atau gagal, beserta laporannya
+func (r *ImportJobRepository) Finish(ctx context.Context, id primitive.ObjectID, report *model.ImportReport, errMsg string) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	now := time.Now()
+	status := model.ImportStatusDone
+	if errMsg != "" {
+		status = model.ImportStatusFailed
+	}
+	set := bson.M{"status": status, "report": report, "updated_at": now, "finished_at": now}
+	if errMsg != "" {
+		set["error"] = errMsg
+	}
+	if report != nil {
+		set["processed"] = report.TotalRows
+	}
+	return checkMatched(r.Collection.UpdateByID(ctx, id, bson.M{"$set": set}))
+}
+
+// FailUnfinished menandai failed semua job yang masih queued/running. Dipanggil
+// saat server start: job dari proses sebelumnya tidak akan pernah selesai.
+func (r *ImportJobRepository) FailUnfinished(ctx context.Context, errMsg string) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	now := time.Now()
+	res, err := r.Collection.UpdateMany(ctx, bson.M{
+		"status": bson.M{"$in": bson.A{model.ImportStatusQueued, model.ImportStatusRunning}},
+	}, bson.M{
+		"$set": bson.M{"status": model.ImportStatusFailed, "error": errMsg, "updated_at": now, "finished_at": now},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
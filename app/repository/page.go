@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"encoding/base64"
+	"slices"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// PageRequest - permintaan satu halaman keyset. Cursor kosong berarti halaman
+// pertama; selain itu berisi Next/Prev dari halaman sebelumnya dengan SortBy
+// dan Order yang sama.
+type PageRequest struct {
+	SortBy string
+	Order  string
+	Limit  int
+	Cursor string
+	Count  bool // hitung total dokumen yang cocok dengan filter (query tambahan)
+}
+
+// Page - hasil satu halaman keyset
+type Page[T any] struct {
+	Items []T
+	Next  string // kosong jika tidak ada halaman berikutnya
+	Prev  string // kosong jika ini halaman pertama
+	Total *int   // hanya jika PageRequest.Count
+}
+
+// pageCursor - isi cursor: nilai field urutan dan _id dokumen batas. Disimpan
+// sebagai BSON supaya tipe nilainya (string, angka, tanggal) tetap sama saat
+// dibandingkan di query berikutnya.
+type pageCursor struct {
+	SortBy string             `bson:"s"`
+	Order  string             `bson:"o"`
+	Value  bson.RawValue      `bson:"v"`
+	ID     primitive.ObjectID `bson:"i"`
+	Before bool               `bson:"b,omitempty"` // cursor ke halaman sebelumnya
+}
+
+func encodeCursor(c pageCursor) string {
+	raw, err := bson.Marshal(c)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(s string) (pageCursor, error) {
+	var c pageCursor
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || bson.Unmarshal(raw, &c) != nil || c.ID.IsZero() {
+		return c, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// findPage menjalankan query keyset: dokumen diurutkan berdasarkan SortBy
+// lalu _id sebagai penentu urutan, dan halaman berikutnya dimulai setelah
+// dokumen terakhir (bukan skip), jadi tetap stabil walau data berubah.
+// Field SortBy selalu diambil walau tidak ada di projection shape.
+func findPage[T any](ctx context.Context, coll *mongo.Collection, filter bson.M, req PageRequest, shape readShape) (Page[T], error) {
+	var page Page[T]
+	asc := req.Order != "desc"
+
+	var cur *pageCursor
+	if req.Cursor != "" {
+		c, err := decodeCursor(req.Cursor)
+		if err != nil || c.SortBy != req.SortBy || c.Order != req.Order {
+			return page, ErrInvalidCursor
+		}
+		cur = &c
+	}
+	backward := cur != nil && cur.Before
+	// halaman sebelumnya dibaca dengan urutan terbalik lalu dibalik lagi
+	travelAsc := asc != backward
+
+	query := filter
+	if cur != nil {
+		query = bson.M{"$and": []bson.M{filter, keysetFilter(req.SortBy, travelAsc, cur.Value, cur.ID)}}
+	}
+	dir := 1
+	if !travelAsc {
+		dir = -1
+	}
+	sort := bson.D{{Key: "_id", Value: dir}}
+	if req.SortBy != "_id" {
+		sort = append(bson.D{{Key: req.SortBy, Value: dir}}, sort...)
+	}
+
+	opts := options.Find().SetSort(sort).SetLimit(int64(req.Limit) + 1)
+	cursor, err := shape.keep(req.SortBy).find(ctx, coll, query, opts)
+	if err != nil {
+		return page, err
+	}
+	var docs []bson.Raw
+	if err := cursor.All(ctx, &docs); err != nil {
+		return page, err
+	}
+
+	hasMore := len(docs) > req.Limit
+	if hasMore {
+		docs = docs[:req.Limit]
+	}
+	if backward {
+		slices.Reverse(docs)
+	}
+
+	page.Items = make([]T, len(docs))
+	for i, doc := range docs {
+		if err := bson.Unmarshal(doc, &page.Items[i]); err != nil {
+			return page, err
+		}
+	}
+	// saat mundur, halaman asal selalu ada di depan
+	hasNext, hasPrev := hasMore, cur != nil
+	if backward {
+		hasNext, hasPrev = true, hasMore
+	}
+	if len(docs) > 0 && hasNext {
+		page.Next = boundaryCursor(docs[len(docs)-1], req, false)
+	}
+	if len(docs) > 0 && hasPrev {
+		page.Prev = boundaryCursor(docs[0], req, true)
+	}
+
+	if req.Count {
+		n, err := shape.count(ctx, coll, filter)
+		if err != nil {
+			return page, err
+		}
+		total := int(n)
+		page.Total = &total
+	}
+	return page, nil
+}
+
+func boundaryCursor(doc bson.Raw, req PageRequest, before bool) string {
+	c := pageCursor{SortBy: req.SortBy, Order: req.Order, Before: before}
+	c.ID, _ = doc.Lookup("_id").ObjectIDOK()
+	c.Value = doc.Lookup(req.SortBy)
+	if c.Value.Type == 0 {
+		// field tidak ada diperlakukan sama dengan null, seperti urutan MongoDB
+		c.Value = bson.RawValue{Type: bsontype.Null}
+	}
+	return encodeCursor(c)
+}
+
+// keysetFilter - dokumen yang posisinya setelah (v, id) pada arah asc/desc.
+// MongoDB menaruh null/field kosong paling awal pada urutan naik, jadi null
+// ditangani terpisah ($gt/$lt tidak pernah cocok dengan null).
+func keysetFilter(field string, asc bool, v bson.RawValue, id primitive.ObjectID) bson.M {
+	cmp := "$gt"
+	if !asc {
+		cmp = "$lt"
+	}
+	if field == "_id" {
+		return bson.M{"_id": bson.M{cmp: id}}
+	}
+
+	sameValueLaterID := bson.M{field: v, "_id": bson.M{cmp: id}}
+	if v.Type == bsontype.Null {
+		sameValueLaterID = bson.M{field: nil, "_id": bson.M{cmp: id}}
+		if asc {
+			return bson.M{"$or": []bson.M{sameValueLaterID, {field: bson.M{"$ne": nil}}}}
+		}
+		return sameValueLaterID
+	}
+
+	or := []bson.M{{field: bson.M{cmp: v}}, sameValueLaterID}
+	if !asc {
+		or = append(or, bson.M{field: nil})
+	}
+	return bson.M{"$or": or}
+}
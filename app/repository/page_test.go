@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"encoding/base64"
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	id := primitive.NewObjectID()
+	doc, _ := bson.Marshal(bson.M{"_id": id, "angkatan": int32(2019)})
+	req := PageRequest{SortBy: "angkatan", Order: "desc"}
+
+	c, err := decodeCursor(boundaryCursor(doc, req, true))
+	if err != nil {
+		t.Fatalf("decodeCursor: %v", err)
+	}
+	if c.ID != id || c.SortBy != "angkatan" || c.Order != "desc" || !c.Before {
+		t.Errorf("cursor tidak sama: %+v", c)
+	}
+	// tipe nilai harus tetap int32, bukan berubah menjadi string/double
+	if v, ok := c.Value.Int32OK(); !ok || v != 2019 {
+		t.Errorf("nilai cursor = %v, mau int32 2019", c.Value)
+	}
+
+	// field tidak ada dianggap null
+	doc, _ = bson.Marshal(bson.M{"_id": id})
+	c, _ = decodeCursor(boundaryCursor(doc, req, false))
+	if c.Value.Type != bsontype.Null {
+		t.Errorf("field kosong harus menjadi null, dapat %v", c.Value.Type)
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	empty, _ := bson.Marshal(bson.M{"s": "nama"})
+	for _, s := range []string{"bukan base64!", "YWJj", base64.RawURLEncoding.EncodeToString(empty)} {
+		if _, err := decodeCursor(s); err != ErrInvalidCursor {
+			t.Errorf("decodeCursor(%q) = %v, mau ErrInvalidCursor", s, err)
+		}
+	}
+}
+
+func TestKeysetFilter(t *testing.T) {
+	id := primitive.NewObjectID()
+	nama := bson.RawValue{Type: bsontype.String, Value: bsoncore.AppendString(nil, "Budi")}
+	null := bson.RawValue{Type: bsontype.Null}
+
+	tests := []struct {
+		name  string
+		field string
+		asc   bool
+		v     bson.RawValue
+		want  bson.M
+	}{
+		{"id asc", "_id", true, null, bson.M{"_id": bson.M{"$gt": id}}},
+		{"id desc", "_id", false, null, bson.M{"_id": bson.M{"$lt": id}}},
+		{"asc", "nama", true, nama, bson.M{"$or": []bson.M{
+			{"nama": bson.M{"$gt": nama}},
+			{"nama": nama, "_id": bson.M{"$gt": id}},
+		}}},
+		{"desc menyertakan null di akhir", "nama", false, nama, bson.M{"$or": []bson.M{
+			{"nama": bson.M{"$lt": nama}},
+			{"nama": nama, "_id": bson.M{"$lt": id}},
+			{"nama": nil},
+		}}},
+		{"null asc lanjut ke nilai non-null", "nama", true, null, bson.M{"$or": []bson.M{
+			{"nama": nil, "_id": bson.M{"$gt": id}},
+			{"nama": bson.M{"$ne": nil}},
+		}}},
+		{"null desc hanya sisa null", "nama", false, null, bson.M{"nama": nil, "_id": bson.M{"$lt": id}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := keysetFilter(tt.field, tt.asc, tt.v, id)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("keysetFilter = %v, mau %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crud_alumni/app/model"
 	"crud_alumni/database"
+	"strconv"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -11,12 +12,13 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-// GetAllPekerjaan – ambil semua pekerjaan
-func GetAllPekerjaan() ([]model.Pekerjaan, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// GetAllPekerjaan – ambil semua pekerjaan, hanya field ro.Fields jika diisi
+func GetAllPekerjaan(ctx context.Context, ro ReadOptions) ([]model.Pekerjaan, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
 	defer cancel()
 
-	cursor, err := database.PekerjaanCollection.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"_id": -1}))
+	opts := options.Find().SetSort(bson.M{"_id": -1})
+	cursor, err := newShape(ro.Fields, "version").find(ctx, database.PekerjaanCollection, bson.M{}, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -29,9 +31,17 @@ func GetAllPekerjaan() ([]model.Pekerjaan, error) {
 	return result, nil
 }
 
+// PekerjaanPage – satu halaman keyset semua pekerjaan (sama seperti GetAllPekerjaan)
+func PekerjaanPage(ctx context.Context, req PageRequest, ro ReadOptions) (Page[model.Pekerjaan], error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	return findPage[model.Pekerjaan](ctx, database.PekerjaanCollection, bson.M{}, req, newShape(ro.Fields, "version"))
+}
+
 // GetPekerjaanByID – ambil 1 dokumen berdasarkan ObjectID Mongo atau id lama (integer)
-func GetPekerjaanByID(idStr string) (*model.Pekerjaan, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func GetPekerjaanByID(ctx context.Context, idStr string) (*model.Pekerjaan, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
 	defer cancel()
 
 	var pekerjaan model.Pekerjaan
@@ -40,21 +50,25 @@ func GetPekerjaanByID(idStr string) (*model.Pekerjaan, error) {
 	objID, err := primitive.ObjectIDFromHex(idStr)
 	filter := bson.M{"_id": objID}
 	if err != nil {
-		// fallback ke pencarian berdasarkan id lama (Postgres ID)
-		filter = bson.M{"id": idStr}
+		// fallback ke pencarian berdasarkan id lama (Postgres ID, integer)
+		legacyID, convErr := strconv.Atoi(idStr)
+		if convErr != nil {
+			return nil, ErrInvalidID
+		}
+		filter = bson.M{"id": legacyID}
 	}
 
 	err = database.PekerjaanCollection.FindOne(ctx, filter).Decode(&pekerjaan)
 	if err != nil {
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	return &pekerjaan, nil
 }
 
 // GetPekerjaanByAlumniID – ambil semua pekerjaan dengan alumni_id tertentu
-func GetPekerjaanByAlumniID(alumniID int) ([]model.Pekerjaan, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func GetPekerjaanByAlumniID(ctx context.Context, alumniID int) ([]model.Pekerjaan, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
 	defer cancel()
 
 	cursor, err := database.PekerjaanCollection.Find(ctx, bson.M{"alumni_id": alumniID})
@@ -70,13 +84,41 @@ func GetPekerjaanByAlumniID(alumniID int) ([]model.Pekerjaan, error) {
 	return result, nil
 }
 
+// pekerjaanSemiJoin - tahap aggregate yang menyisakan alumni yang punya
+// (has=true) atau tidak punya pekerjaan di luar trash. employedOnly membatasi
+// ke pekerjaan yang belum selesai hari ini. Cukup satu pekerjaan per alumni,
+// jadi $lookup berhenti di dokumen pertama; as dihapus lagi dari hasil.
+// Alumni tanpa id lama dianggap tidak punya pekerjaan.
+func pekerjaanSemiJoin(as string, employedOnly, has bool) []bson.M {
+	match := bson.M{
+		"$expr":     bson.M{"$and": bson.A{bson.M{"$gt": bson.A{"$$alumni_id", nil}}, bson.M{"$eq": bson.A{"$alumni_id", "$$alumni_id"}}}},
+		"isdellete": bson.M{"$ne": "yes"},
+	}
+	if employedOnly {
+		match["$or"] = []bson.M{
+			{"tanggal_selesai_kerja": bson.M{"$in": bson.A{nil, ""}}},
+			{"tanggal_selesai_kerja": bson.M{"$gte": time.Now().Format("2006-01-02")}},
+		}
+	}
+	return []bson.M{
+		{"$lookup": bson.M{
+			"from":     database.PekerjaanCollection.Name(),
+			"let":      bson.M{"alumni_id": "$id"},
+			"pipeline": []bson.M{{"$match": match}, {"$limit": 1}, {"$project": bson.M{"_id": 1}}},
+			"as":       as,
+		}},
+		{"$match": bson.M{as + ".0": bson.M{"$exists": has}}},
+		{"$unset": as},
+	}
+}
+
 // CreatePekerjaan – tambah data baru
-func CreatePekerjaan(p model.Pekerjaan) (primitive.ObjectID, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func CreatePekerjaan(ctx context.Context, p model.Pekerjaan) (primitive.ObjectID, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
 	defer cancel()
 
 	p.IsDellete = "no"
-	p.TanggalMulaiKerja = time.Now().Format("2006-01-02")
+	p.Version = InitialVersion
 
 	result, err := database.PekerjaanCollection.InsertOne(ctx, p)
 	if err != nil {
@@ -86,85 +128,75 @@ func CreatePekerjaan(p model.Pekerjaan) (primitive.ObjectID, error) {
 	return result.InsertedID.(primitive.ObjectID), nil
 }
 
-// UpdatePekerjaan – update data pekerjaan
-func UpdatePekerjaan(idStr string, p model.Pekerjaan) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// pekerjaanClearable - field opsional yang di-$unset ReplacePekerjaan jika kosong
+var pekerjaanClearable = []string{"gaji_range", "tanggal_selesai_kerja", "deskripsi_pekerjaan", "deleted_at"}
+
+// ReplacePekerjaan – tulis ulang semua field model pekerjaan jika versinya
+// masih p.Version. Seperti ReplaceAlumni, field opsional yang kosong ikut
+// terhapus dan field dokumen yang tidak ada di model tidak disentuh.
+func ReplacePekerjaan(ctx context.Context, p *model.Pekerjaan) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(idStr)
+	expected := p.Version
+	p.Version++
+	p.UpdatedAt = time.Now()
+	update, err := replaceUpdate(*p, pekerjaanClearable)
 	if err != nil {
 		return err
 	}
-
-	update := bson.M{
-		"$set": bson.M{
-			"alumni_id":            p.AlumniID,
-			"nama_perusahaan":      p.NamaPerusahaan,
-			"posisi_jabatan":       p.PosisiJabatan,
-			"bidang_industri":      p.BidangIndustri,
-			"lokasi_kerja":         p.LokasiKerja,
-			"gaji_range":           p.GajiRange,
-			"tanggal_mulai_kerja":  p.TanggalMulaiKerja,
-			"tanggal_selesai_kerja": p.TanggalSelesaiKerja,
-			"status_pekerjaan":     p.StatusPekerjaan,
-			"deskripsi_pekerjaan":  p.Deskripsi,
-			"updated_at":           time.Now(),
-		},
-	}
-
-	_, err = database.PekerjaanCollection.UpdateByID(ctx, objID, update)
-	return err
+	return checkVersionMatched(database.PekerjaanCollection.UpdateOne(ctx, versionFilter(p.ID, expected), update))
 }
 
-// DeletePekerjaan – hard delete
-func DeletePekerjaan(idStr string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// DeletePekerjaan – hard delete dengan versi tertentu
+func DeletePekerjaan(ctx context.Context, idStr string, version int64) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(idStr)
+	objID, err := parseObjectID(idStr)
 	if err != nil {
 		return err
 	}
 
-	_, err = database.PekerjaanCollection.DeleteOne(ctx, bson.M{"_id": objID})
-	return err
+	return checkVersionDeleted(database.PekerjaanCollection.DeleteOne(ctx, versionFilter(objID, version)))
 }
 
 // Soft delete
-func SoftDeletePekerjaan(idStr string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func SoftDeletePekerjaan(ctx context.Context, idStr string, version int64) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(idStr)
+	objID, err := parseObjectID(idStr)
 	if err != nil {
 		return err
 	}
 
-	_, err = database.PekerjaanCollection.UpdateByID(ctx, objID, bson.M{
-		"$set": bson.M{"isdellete": "yes", "updated_at": time.Now()},
-	})
-	return err
+	return checkVersionMatched(database.PekerjaanCollection.UpdateOne(ctx, versionFilter(objID, version), bson.M{
+		"$set": bson.M{"isdellete": "yes", "deleted_at": time.Now(), "updated_at": time.Now()},
+		"$inc": bson.M{"version": 1},
+	}))
 }
 
 // Restore
-func RestorePekerjaan(idStr string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func RestorePekerjaan(ctx context.Context, idStr string, version int64) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
 	defer cancel()
 
-	objID, err := primitive.ObjectIDFromHex(idStr)
+	objID, err := parseObjectID(idStr)
 	if err != nil {
 		return err
 	}
 
-	_, err = database.PekerjaanCollection.UpdateByID(ctx, objID, bson.M{
-		"$set": bson.M{"isdellete": "no", "updated_at": time.Now()},
-	})
-	return err
+	return checkVersionMatched(database.PekerjaanCollection.UpdateOne(ctx, versionFilter(objID, version), bson.M{
+		"$set":   bson.M{"isdellete": "no", "updated_at": time.Now()},
+		"$unset": bson.M{"deleted_at": ""},
+		"$inc":   bson.M{"version": 1},
+	}))
 }
 
 // GetPekerjaanByTahun – hitung pekerjaan berdasarkan tahun mulai kerja
-func GetPekerjaanByTahun(tahun int) (model.JumlahPekerjaanPerTahun, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func GetPekerjaanByTahun(ctx context.Context, tahun int) (model.JumlahPekerjaanPerTahun, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
 	defer cancel()
 
 	startDate := time.Date(tahun, 1, 1, 0, 0, 0, 0, time.UTC)
@@ -189,8 +221,8 @@ func GetPekerjaanByTahun(tahun int) (model.JumlahPekerjaanPerTahun, error) {
 }
 
 // TrashAll – ambil semua pekerjaan yang sudah soft delete
-func TrashAll() ([]model.Pekerjaan, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func TrashAll(ctx context.Context) ([]model.Pekerjaan, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
 	defer cancel()
 
 	cursor, err := database.PekerjaanCollection.Find(ctx, bson.M{"isdellete": "yes"})
@@ -205,3 +237,36 @@ func TrashAll() ([]model.Pekerjaan, error) {
 	}
 	return result, nil
 }
+
+// expiredTrashPekerjaan - filter pekerjaan di trash yang dihapus sebelum cutoff
+func expiredTrashPekerjaan(cutoff time.Time) bson.M {
+	return bson.M{"isdellete": "yes", "deleted_at": bson.M{"$lte": cutoff}}
+}
+
+// ExpiredTrashPekerjaan – paling banyak limit pekerjaan yang masuk trash
+// sebelum cutoff, yang terlama lebih dulu
+func ExpiredTrashPekerjaan(ctx context.Context, cutoff time.Time, limit int64) ([]model.Pekerjaan, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "deleted_at", Value: 1}, {Key: "_id", Value: 1}}).SetLimit(limit)
+	cursor, err := database.PekerjaanCollection.Find(ctx, expiredTrashPekerjaan(cutoff), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	result := []model.Pekerjaan{}
+	if err = cursor.All(ctx, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CountExpiredTrashPekerjaan – jumlah pekerjaan yang masuk trash sebelum cutoff
+func CountExpiredTrashPekerjaan(ctx context.Context, cutoff time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	return database.PekerjaanCollection.CountDocuments(ctx, expiredTrashPekerjaan(cutoff))
+}
@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"crud_alumni/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Relasi yang bisa disertakan di hasil baca alumni (?include=)
+const (
+	IncludePekerjaan = "pekerjaan"
+	IncludeFiles     = "files"
+)
+
+// ReadOptions - bentuk hasil baca. Fields berisi nama field BSON yang diambil
+// (_id selalu ikut); kosong berarti dokumen utuh. Include menyertakan relasi
+// lewat $lookup, masing-masing paling banyak IncludeLimit dokumen.
+type ReadOptions struct {
+	Fields       []string
+	Include      []string
+	IncludeLimit int
+}
+
+// readShape - projection dan tahap $lookup hasil ReadOptions. join berisi
+// tahap penyaring tambahan yang butuh koleksi lain (semi-join), dijalankan
+// tepat setelah filter.
+type readShape struct {
+	project bson.M
+	lookups []bson.M
+	join    []bson.M
+}
+
+// joined menambahkan tahap penyaring semi-join ke s
+func (s readShape) joined(join []bson.M) readShape {
+	s.join = join
+	return s
+}
+
+// newShape - projection dari fields; always selalu ikut diambil (mis. version
+// untuk ETag). Tanpa fields hasilnya dokumen utuh.
+func newShape(fields []string, always ...string) readShape {
+	var s readShape
+	if len(fields) == 0 {
+		return s
+	}
+	s.project = bson.M{"_id": 1}
+	for _, f := range fields {
+		s.project[f] = 1
+	}
+	for _, f := range always {
+		s.project[f] = 1
+	}
+	return s
+}
+
+// keep memastikan field ikut terambil walau tidak diminta (mis. field urutan
+// untuk cursor)
+func (s readShape) keep(field string) readShape {
+	if s.project != nil {
+		s.project[field] = 1
+	}
+	return s
+}
+
+// lookup menambahkan relasi as; hasilnya selalu ikut walau fields diisi
+func (s *readShape) lookup(as string, stages ...bson.M) {
+	s.lookups = append(s.lookups, stages...)
+	s.keep(as)
+}
+
+// find menjalankan query dengan bentuk s. Tanpa relasi dan semi-join dipakai
+// Find biasa; selain itu aggregate $match, semi-join, $sort, $skip, $limit,
+// lalu $lookup supaya relasi hanya dicari untuk dokumen di halaman ini.
+// Projection di opts hanya boleh berisi field tambahan seperti $meta textScore.
+func (s readShape) find(ctx context.Context, coll *mongo.Collection, filter bson.M, opts *options.FindOptions) (*mongo.Cursor, error) {
+	if opts == nil {
+		opts = options.Find()
+	}
+	if len(s.lookups) == 0 && len(s.join) == 0 {
+		if s.project != nil {
+			project := bson.M{}
+			if extra, ok := opts.Projection.(bson.M); ok {
+				for k, v := range extra {
+					project[k] = v
+				}
+			}
+			for k, v := range s.project {
+				project[k] = v
+			}
+			opts.SetProjection(project)
+		}
+		return coll.Find(ctx, filter, opts)
+	}
+	aggOpts := options.Aggregate()
+	if opts.BatchSize != nil {
+		aggOpts.SetBatchSize(*opts.BatchSize)
+	}
+	return coll.Aggregate(ctx, s.pipeline(filter, opts), aggOpts)
+}
+
+// count - jumlah dokumen yang cocok dengan filter, termasuk penyaring
+// semi-join (jika ada) seperti find
+func (s readShape) count(ctx context.Context, coll *mongo.Collection, filter bson.M) (int64, error) {
+	if len(s.join) == 0 {
+		return coll.CountDocuments(ctx, filter)
+	}
+	pipeline := append(append([]bson.M{{"$match": filter}}, s.join...), bson.M{"$count": "n"})
+	cursor, err := coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+	var result struct {
+		N int64 `bson:"n"`
+	}
+	if cursor.Next(ctx) {
+		err = cursor.Decode(&result)
+	}
+	if err == nil {
+		err = cursor.Err()
+	}
+	return result.N, err
+}
+
+func (s readShape) pipeline(filter bson.M, opts *options.FindOptions) []bson.M {
+	pipeline := append([]bson.M{{"$match": filter}}, s.join...)
+	if opts.Projection != nil {
+		pipeline = append(pipeline, bson.M{"$addFields": opts.Projection})
+	}
+	if opts.Sort != nil {
+		pipeline = append(pipeline, bson.M{"$sort": opts.Sort})
+	}
+	if opts.Skip != nil && *opts.Skip > 0 {
+		pipeline = append(pipeline, bson.M{"$skip": *opts.Skip})
+	}
+	if opts.Limit != nil && *opts.Limit > 0 {
+		pipeline = append(pipeline, bson.M{"$limit": *opts.Limit})
+	}
+	pipeline = append(pipeline, s.lookups...)
+	if s.project != nil {
+		pipeline = append(pipeline, bson.M{"$project": s.project})
+	}
+	return pipeline
+}
+
+// alumniShape - bentuk hasil baca alumni. version selalu diambil untuk ETag.
+// Pekerjaan terhubung lewat id numerik lama (alumni.id = pekerjaan.alumni_id),
+// file lewat akun user dengan email yang sama (files.user_id = users._id).
+// Relasi diurutkan dari yang terbaru.
+func alumniShape(ro ReadOptions) readShape {
+	s := newShape(ro.Fields, "version")
+	for _, rel := range ro.Include {
+		switch rel {
+		case IncludePekerjaan:
+			s.lookup(IncludePekerjaan, bson.M{"$lookup": bson.M{
+				"from": database.PekerjaanCollection.Name(),
+				"let":  bson.M{"alumni_id": "$id"},
+				"pipeline": []bson.M{
+					{"$match": bson.M{
+						// alumni tanpa id lama tidak punya pekerjaan ($gt null juga menolak field kosong)
+						"$expr":     bson.M{"$and": bson.A{bson.M{"$gt": bson.A{"$$alumni_id", nil}}, bson.M{"$eq": bson.A{"$alumni_id", "$$alumni_id"}}}},
+						"isdellete": bson.M{"$ne": "yes"},
+					}},
+					{"$sort": bson.D{{Key: "tanggal_mulai_kerja", Value: -1}, {Key: "_id", Value: -1}}},
+					{"$limit": ro.IncludeLimit},
+				},
+				"as": IncludePekerjaan,
+			}})
+		case IncludeFiles:
+			s.lookup(IncludeFiles,
+				bson.M{"$lookup": bson.M{
+					"from": database.UserCollection.Name(),
+					"let":  bson.M{"email": "$email"},
+					"pipeline": []bson.M{
+						{"$match": bson.M{"$expr": bson.M{"$and": bson.A{bson.M{"$ne": bson.A{"$$email", ""}}, bson.M{"$eq": bson.A{"$email", "$$email"}}}}}},
+						{"$project": bson.M{"_id": 1}},
+					},
+					"as": "_akun",
+				}},
+				bson.M{"$lookup": bson.M{
+					"from": FilesCollection,
+					"let":  bson.M{"users": "$_akun._id"},
+					"pipeline": []bson.M{
+						{"$match": bson.M{"$expr": bson.M{"$in": bson.A{"$user_id", "$$users"}}}},
+						{"$sort": bson.D{{Key: "uploaded_at", Value: -1}, {Key: "_id", Value: -1}}},
+						{"$limit": ro.IncludeLimit},
+					},
+					"as": IncludeFiles,
+				}},
+				bson.M{"$project": bson.M{"_akun": 0}},
+			)
+		}
+	}
+	return s
+}
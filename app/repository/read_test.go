@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestNewShape(t *testing.T) {
+	if s := newShape(nil, "version"); s.project != nil {
+		t.Errorf("tanpa fields harus dokumen utuh, dapat %v", s.project)
+	}
+	got := newShape([]string{"nim", "nama"}, "version").keep("angkatan").project
+	want := bson.M{"_id": 1, "nim": 1, "nama": 1, "version": 1, "angkatan": 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("projection = %v, mau %v", got, want)
+	}
+}
+
+func TestShapePipeline(t *testing.T) {
+	s := newShape([]string{"nama"})
+	lookup := bson.M{"$lookup": bson.M{"from": "pekerjaan", "as": "pekerjaan"}}
+	s.lookup("pekerjaan", lookup)
+
+	score := bson.M{"score": bson.M{"$meta": "textScore"}}
+	opts := options.Find().SetProjection(score).SetSort(bson.D{{Key: "nama", Value: 1}}).SetSkip(20).SetLimit(10)
+	got := s.pipeline(bson.M{"deleted_at": nil}, opts)
+
+	// relasi dicari setelah $limit, jadi hanya untuk dokumen di halaman ini
+	want := []bson.M{
+		{"$match": bson.M{"deleted_at": nil}},
+		{"$addFields": score},
+		{"$sort": bson.D{{Key: "nama", Value: 1}}},
+		{"$skip": int64(20)},
+		{"$limit": int64(10)},
+		lookup,
+		{"$project": bson.M{"_id": 1, "nama": 1, "pekerjaan": 1}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("pipeline =\n%v\nmau\n%v", got, want)
+	}
+}
+
+func TestShapePipeline_Join(t *testing.T) {
+	join := []bson.M{{"$lookup": bson.M{"from": "pekerjaan", "as": "_pekerjaan"}}, {"$match": bson.M{"_pekerjaan.0": bson.M{"$exists": true}}}}
+	opts := options.Find().SetSort(bson.D{{Key: "nama", Value: 1}}).SetLimit(10)
+	got := readShape{}.joined(join).pipeline(bson.M{"deleted_at": nil}, opts)
+
+	// semi-join menyaring sebelum $sort/$limit supaya jumlah per halaman benar
+	want := []bson.M{
+		{"$match": bson.M{"deleted_at": nil}},
+		join[0],
+		join[1],
+		{"$sort": bson.D{{Key: "nama", Value: 1}}},
+		{"$limit": int64(10)},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("pipeline =\n%v\nmau\n%v", got, want)
+	}
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"crud_alumni/config"
+	"time"
+)
+
+// dbConfig - diisi Configure saat startup; unit test memakai nilai default
+var dbConfig = config.Defaults().Database
+
+// Configure menerapkan konfigurasi database (DB_TIMEOUT) ke semua repository
+func Configure(cfg config.DatabaseConfig) {
+	dbConfig = cfg
+}
+
+// dbTimeout - batas waktu setiap operasi MongoDB (DB_TIMEOUT)
+func dbTimeout() time.Duration {
+	return dbConfig.Timeout
+}
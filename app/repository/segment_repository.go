@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"crud_alumni/app/model"
+	"crud_alumni/database"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SegmentCollection - filter alumni yang disimpan dengan nama
+const SegmentCollection = "segments"
+
+// GetSegmentFunc menggantikan GetSegment di test supaya tidak mengakses MongoDB
+var GetSegmentFunc func(ctx context.Context, id string) (model.Segment, error)
+
+func segmentCollection() *mongo.Collection {
+	return database.DB.Collection(SegmentCollection)
+}
+
+// ListSegments - semua segment, urut nama
+func ListSegments(ctx context.Context) ([]model.Segment, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	cursor, err := segmentCollection().Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "name", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	list := []model.Segment{}
+	if err := cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// GetSegment - satu segment berdasarkan _id
+func GetSegment(ctx context.Context, id string) (model.Segment, error) {
+	if GetSegmentFunc != nil {
+		return GetSegmentFunc(ctx, id)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	var s model.Segment
+	objID, err := parseObjectID(id)
+	if err != nil {
+		return s, err
+	}
+	err = segmentCollection().FindOne(ctx, bson.M{"_id": objID}).Decode(&s)
+	return s, mapError(err)
+}
+
+// CreateSegment menyimpan segment baru; nama yang sudah dipakai menghasilkan
+// ErrDuplicate. ID, versi, dan waktu di s diisi.
+func CreateSegment(ctx context.Context, s *model.Segment) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	now := time.Now().Format("2006-01-02 15:04:05")
+	s.ID, s.Version, s.CreatedAt, s.UpdatedAt = primitive.NewObjectID(), InitialVersion, now, now
+	_, err := segmentCollection().InsertOne(ctx, s)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrDuplicate
+	}
+	return err
+}
+
+// UpdateSegment menyimpan nama, deskripsi, dan query s jika versinya masih
+// sama. Versi dan updated_at di s diperbarui.
+func UpdateSegment(ctx context.Context, s *model.Segment) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	expected := s.Version
+	s.Version++
+	s.UpdatedAt = time.Now().Format("2006-01-02 15:04:05")
+	err := checkVersionMatched(segmentCollection().UpdateOne(ctx, versionFilter(s.ID, expected), bson.M{
+		"$set": bson.M{
+			"name":        s.Name,
+			"description": s.Description,
+			"query":       s.Query,
+			"updated_at":  s.UpdatedAt,
+			"version":     s.Version,
+		},
+	}))
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrDuplicate
+	}
+	return err
+}
+
+// DeleteSegment menghapus segment jika versinya masih sama
+func DeleteSegment(ctx context.Context, id primitive.ObjectID, version int64) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	return checkVersionDeleted(segmentCollection().DeleteOne(ctx, versionFilter(id, version)))
+}
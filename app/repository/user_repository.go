@@ -4,23 +4,22 @@ import (
 	"context"
 	"crud_alumni/app/model"
 	"crud_alumni/database"
-	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 )
 
-var FindUserByUsernameOrEmailFunc func(identifier string) (*model.User, string, error)
+var FindUserByUsernameOrEmailFunc func(ctx context.Context, identifier string) (*model.User, string, error)
 
-func FindUserByUsernameOrEmail(identifier string) (*model.User, string, error) {
+func FindUserByUsernameOrEmail(ctx context.Context, identifier string) (*model.User, string, error) {
 	if FindUserByUsernameOrEmailFunc != nil {
-		return FindUserByUsernameOrEmailFunc(identifier)
+		return FindUserByUsernameOrEmailFunc(ctx, identifier)
 	}
 
 	if database.UserCollection == nil {
 		panic("❌ UserCollection belum diinisialisasi")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
 	defer cancel()
 
 	var user model.User
@@ -31,7 +30,54 @@ func FindUserByUsernameOrEmail(identifier string) (*model.User, string, error) {
 		},
 	}).Decode(&user)
 	if err != nil {
-		return nil, "", err
+		return nil, "", mapError(err)
 	}
 	return &user, user.PasswordHash, nil
 }
+
+// UserPage - satu halaman keyset daftar user
+func UserPage(ctx context.Context, req PageRequest) (Page[model.User], error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	return findPage[model.User](ctx, database.UserCollection, bson.M{}, req, readShape{})
+}
+
+// GetUserByID - satu user berdasarkan _id
+func GetUserByID(ctx context.Context, id string) (*model.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	objID, err := parseObjectID(id)
+	if err != nil {
+		return nil, err
+	}
+	var user model.User
+	if err := database.UserCollection.FindOne(ctx, bson.M{"_id": objID}).Decode(&user); err != nil {
+		return nil, mapError(err)
+	}
+	return &user, nil
+}
+
+// CountAdmins - jumlah user dengan role admin
+func CountAdmins(ctx context.Context) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	return database.UserCollection.CountDocuments(ctx, bson.M{"role": "admin"})
+}
+
+// UpdateUserRole mengganti role user jika versinya masih sama
+func UpdateUserRole(ctx context.Context, id string, role string, version int64) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout())
+	defer cancel()
+
+	objID, err := parseObjectID(id)
+	if err != nil {
+		return err
+	}
+	return checkVersionMatched(database.UserCollection.UpdateOne(ctx, versionFilter(objID, version), bson.M{
+		"$set": bson.M{"role": role},
+		"$inc": bson.M{"version": 1},
+	}))
+}
@@ -0,0 +1,289 @@
+package service
+
+import (
+	"cmp"
+	"crud_alumni/app/model"
+	"crud_alumni/app/repository"
+	"crud_alumni/apperror"
+	"crud_alumni/i18n"
+	"crud_alumni/tracing"
+	"crud_alumni/validation"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Bobot skor duplikat. Nama saja (maksimal 30) tidak pernah melewati
+// min_score default; harus didukung NIM, email, atau nomor telepon.
+const (
+	duplicateNIMWeight   = 50
+	duplicateEmailWeight = 40
+	duplicatePhoneWeight = 30
+	duplicateNameWeight  = 30
+
+	duplicateNameThreshold = 0.85 // kemiripan nama minimal agar dihitung
+	duplicateMaxBlock      = 1000 // kelompok nama lebih besar dari ini dilewati
+
+	defaultDuplicateMinScore = 40
+	defaultDuplicateLimit    = 50
+	maxDuplicateLimit        = 200
+)
+
+// GetAlumniDuplicates godoc
+// @Summary Daftar kandidat alumni duplikat
+// @Description Pasangan alumni aktif yang kemungkinan orang yang sama, urut skor tertinggi. Skor dari NIM (50), email (40), nomor telepon (30), dan kemiripan nama (sampai 30), maksimal 100. Pasangan yang sudah ditandai bukan duplikat tidak ditampilkan (admin saja).
+// @Tags Admin
+// @Produce json
+// @Param min_score query int false "Skor minimal 1-100 (default 40)"
+// @Param limit query int false "Jumlah pasangan (default 50, maksimal 200)"
+// @Success 200 {object} model.DuplicateResponse
+// @Failure 400 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Security BearerAuth
+// @Router /admin/alumni/duplicates [get]
+func GetAlumniDuplicates(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "AlumniService.GetAlumniDuplicates")
+	defer span.End()
+
+	minScore, limit := defaultDuplicateMinScore, defaultDuplicateLimit
+	if raw := c.Query("min_score"); raw != "" {
+		v, err := strconv.Atoi(raw)
+		if err != nil || v < 1 || v > 100 {
+			return apperror.BadRequest(apperror.CodeInvalidParam).WithArgs("min_score")
+		}
+		minScore = v
+	}
+	if raw := c.Query("limit"); raw != "" {
+		v, err := strconv.Atoi(raw)
+		if err != nil || v < 1 {
+			return apperror.BadRequest(apperror.CodeInvalidParam).WithArgs("limit")
+		}
+		limit = min(v, maxDuplicateLimit)
+	}
+
+	list, err := repository.ActiveAlumni(ctx)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	dismissed, err := repository.DismissedDuplicates(ctx)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+
+	pairs := findDuplicates(list, dismissed, minScore)
+	resp := model.DuplicateResponse{
+		Data: pairs[:min(limit, len(pairs))],
+		Meta: model.DuplicateMeta{Total: len(pairs), Limit: limit, MinScore: minScore},
+	}
+	return c.JSON(resp)
+}
+
+// DismissAlumniDuplicate godoc
+// @Summary Tandai pasangan bukan duplikat
+// @Description Pasangan yang sudah diperiksa dan ternyata orang berbeda tidak lagi muncul di daftar duplikat (admin saja).
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param body body model.DismissDuplicateRequest true "ID kedua alumni"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} model.Problem
+// @Failure 422 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Security BearerAuth
+// @Router /admin/alumni/duplicates/dismiss [post]
+func DismissAlumniDuplicate(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "AlumniService.DismissAlumniDuplicate")
+	defer span.End()
+
+	var req model.DismissDuplicateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperror.BadRequest(apperror.CodeInvalidBody)
+	}
+	if err := validation.Struct(req); err != nil {
+		return err
+	}
+	a, errA := primitive.ObjectIDFromHex(req.A)
+	b, errB := primitive.ObjectIDFromHex(req.B)
+	if errA != nil || errB != nil {
+		return apperror.BadRequest(apperror.CodeInvalidID)
+	}
+	if a == b {
+		return apperror.BadRequest(apperror.CodeInvalidParam).WithArgs("b")
+	}
+
+	userID, _ := c.Locals("user_id").(string)
+	if err := repository.DismissDuplicate(ctx, a, b, userID); err != nil {
+		return apperror.Internal(err)
+	}
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "alumni.duplicate_dismissed")})
+}
+
+// findDuplicates - semua pasangan dengan skor >= minScore, urut skor
+// tertinggi. Hanya alumni yang berbagi NIM, email, nomor telepon, atau awal
+// kata pertama/terakhir nama yang dibandingkan, supaya tidak semua pasangan
+// dihitung.
+func findDuplicates(list []model.Alumni, dismissed map[string]bool, minScore int) []model.DuplicatePair {
+	blocks := map[string][]int{}
+	for i, a := range list {
+		for _, key := range duplicateBlockKeys(a) {
+			blocks[key] = append(blocks[key], i)
+		}
+	}
+
+	seen := map[string]bool{}
+	pairs := []model.DuplicatePair{}
+	for key, members := range blocks {
+		if strings.HasPrefix(key, "nama:") && len(members) > duplicateMaxBlock {
+			continue
+		}
+		for x := 0; x < len(members); x++ {
+			for y := x + 1; y < len(members); y++ {
+				a, b := list[members[x]], list[members[y]]
+				pk := repository.DuplicateKey(a.ID, b.ID)
+				if seen[pk] || dismissed[pk] {
+					continue
+				}
+				seen[pk] = true
+				if p := scoreDuplicate(a, b); p.Score >= minScore {
+					pairs = append(pairs, p)
+				}
+			}
+		}
+	}
+	slices.SortFunc(pairs, func(p, q model.DuplicatePair) int {
+		return cmp.Or(q.Score-p.Score, strings.Compare(p.A.ID.Hex(), q.A.ID.Hex()), strings.Compare(p.B.ID.Hex(), q.B.ID.Hex()))
+	})
+	return pairs
+}
+
+// duplicateBlockKeys - kelompok tempat alumni dibandingkan
+func duplicateBlockKeys(a model.Alumni) []string {
+	var keys []string
+	if nim := strings.ToUpper(strings.TrimSpace(a.NIM)); nim != "" {
+		keys = append(keys, "nim:"+nim)
+	}
+	if email := strings.ToLower(strings.TrimSpace(a.Email)); email != "" {
+		keys = append(keys, "email:"+email)
+	}
+	if a.NoTelepon != "" {
+		keys = append(keys, "tel:"+a.NoTelepon)
+	}
+	if tokens := strings.Fields(normalizeName(a.Nama)); len(tokens) > 0 {
+		keys = append(keys, "nama:"+prefix(tokens[0], 3))
+		if len(tokens) > 1 {
+			keys = append(keys, "nama:"+prefix(tokens[len(tokens)-1], 3))
+		}
+	}
+	return keys
+}
+
+// scoreDuplicate - skor dan alasan kemiripan a dan b; A selalu ID yang lebih kecil
+func scoreDuplicate(a, b model.Alumni) model.DuplicatePair {
+	if a.ID.Hex() > b.ID.Hex() {
+		a, b = b, a
+	}
+	p := model.DuplicatePair{A: a, B: b, Reasons: []string{}}
+	if nim := strings.TrimSpace(a.NIM); nim != "" && strings.EqualFold(nim, strings.TrimSpace(b.NIM)) {
+		p.Score += duplicateNIMWeight
+		p.Reasons = append(p.Reasons, model.DuplicateByNIM)
+	}
+	if email := strings.TrimSpace(a.Email); email != "" && strings.EqualFold(email, strings.TrimSpace(b.Email)) {
+		p.Score += duplicateEmailWeight
+		p.Reasons = append(p.Reasons, model.DuplicateByEmail)
+	}
+	if a.NoTelepon != "" && a.NoTelepon == b.NoTelepon {
+		p.Score += duplicatePhoneWeight
+		p.Reasons = append(p.Reasons, model.DuplicateByPhone)
+	}
+	p.NameSimilarity = math.Round(nameSimilarity(a.Nama, b.Nama)*100) / 100
+	if p.NameSimilarity >= duplicateNameThreshold {
+		p.Score += int(math.Round(duplicateNameWeight * p.NameSimilarity))
+		p.Reasons = append(p.Reasons, model.DuplicateByName)
+	}
+	p.Score = min(p.Score, 100)
+	return p
+}
+
+// nameSimilarity - Jaro-Winkler nama yang dinormalisasi; urutan kata
+// diabaikan ("Santoso Budi" sama dengan "Budi Santoso")
+func nameSimilarity(a, b string) float64 {
+	na, nb := normalizeName(a), normalizeName(b)
+	sorted := func(s string) string {
+		tokens := strings.Fields(s)
+		slices.Sort(tokens)
+		return strings.Join(tokens, " ")
+	}
+	return max(jaroWinkler(na, nb), jaroWinkler(sorted(na), sorted(nb)))
+}
+
+// normalizeName - huruf kecil, hanya huruf dan spasi tunggal
+func normalizeName(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if !unicode.IsLetter(r) {
+			r = ' '
+		}
+		b.WriteRune(r)
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+func prefix(s string, n int) string {
+	r := []rune(s)
+	return string(r[:min(n, len(r))])
+}
+
+// jaroWinkler - kemiripan dua string 0-1; awalan yang sama (sampai 4 huruf)
+// menambah skor
+func jaroWinkler(a, b string) float64 {
+	r1, r2 := []rune(a), []rune(b)
+	if len(r1) == 0 && len(r2) == 0 {
+		return 1
+	}
+	if len(r1) == 0 || len(r2) == 0 {
+		return 0
+	}
+
+	window := max(max(len(r1), len(r2))/2-1, 0)
+	m1, m2 := make([]bool, len(r1)), make([]bool, len(r2))
+	matches := 0
+	for i := range r1 {
+		for j := max(0, i-window); j < min(len(r2), i+window+1); j++ {
+			if !m2[j] && r1[i] == r2[j] {
+				m1[i], m2[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions, k := 0, 0
+	for i := range r1 {
+		if !m1[i] {
+			continue
+		}
+		for !m2[k] {
+			k++
+		}
+		if r1[i] != r2[k] {
+			transpositions++
+		}
+		k++
+	}
+	m := float64(matches)
+	jaro := (m/float64(len(r1)) + m/float64(len(r2)) + (m-float64(transpositions)/2)/m) / 3
+
+	common := 0
+	for common < min(4, len(r1), len(r2)) && r1[common] == r2[common] {
+		common++
+	}
+	return jaro + float64(common)*0.1*(1-jaro)
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"crud_alumni/app/model"
+	"crud_alumni/app/repository"
+	"maps"
+	"math"
+	"slices"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestJaroWinkler(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want float64
+	}{
+		{"martha", "marhta", 0.961},
+		{"dwayne", "duane", 0.84},
+		{"budi", "budi", 1},
+		{"budi", "", 0},
+	}
+	for _, tc := range cases {
+		if got := jaroWinkler(tc.a, tc.b); math.Abs(got-tc.want) > 0.001 {
+			t.Errorf("jaroWinkler(%q, %q) = %.3f, want %.3f", tc.a, tc.b, got, tc.want)
+		}
+	}
+	if got := nameSimilarity("Santoso, Budi", "budi santoso"); got != 1 {
+		t.Errorf("word order and punctuation must be ignored, got %.3f", got)
+	}
+}
+
+func TestFindDuplicates(t *testing.T) {
+	ids := make([]primitive.ObjectID, 5)
+	for i := range ids {
+		ids[i] = primitive.NewObjectID()
+	}
+	list := []model.Alumni{
+		{ID: ids[0], NIM: "434221001", Nama: "Budi Santoso", Email: "budi@example.com"},
+		{ID: ids[1], NIM: "434221001", Nama: "Budi Santosa", Email: "BUDI@example.com"}, // NIM, email, nama
+		{ID: ids[2], NIM: "434221099", Nama: "Sari Dewi", Email: "sari@example.com", NoTelepon: "+6281234567"},
+		{ID: ids[3], NIM: "434221100", Nama: "Andi Wijaya", Email: "andi@example.com", NoTelepon: "+6281234567"}, // hanya telepon
+		{ID: ids[4], NIM: "434221200", Nama: "Budi Santoso", Email: "lain@example.com"},                          // hanya nama
+	}
+
+	pairs := findDuplicates(list, nil, defaultDuplicateMinScore)
+	if len(pairs) != 1 {
+		t.Fatalf("expected 1 pair above default score, got %+v", pairs)
+	}
+	top := pairs[0]
+	if top.Score != 100 || !slices.Equal(top.Reasons, []string{model.DuplicateByNIM, model.DuplicateByEmail, model.DuplicateByName}) {
+		t.Errorf("unexpected top pair: score %d reasons %v", top.Score, top.Reasons)
+	}
+	if top.A.ID.Hex() > top.B.ID.Hex() {
+		t.Errorf("A must be the smaller id")
+	}
+
+	// skor rendah ikut jika min_score diturunkan
+	if got := findDuplicates(list, nil, 1); len(got) < 3 {
+		t.Errorf("expected phone-only and name-only pairs with min_score 1, got %d pairs", len(got))
+	}
+
+	dismissed := map[string]bool{repository.DuplicateKey(ids[1], ids[0]): true}
+	if got := findDuplicates(list, dismissed, defaultDuplicateMinScore); len(got) != 0 {
+		t.Errorf("dismissed pair must be hidden, got %+v", got)
+	}
+}
+
+func TestMergeAlumni(t *testing.T) {
+	survivor := model.Alumni{ID: primitive.NewObjectID(), NIM: "434221001", Nama: "Budi", Email: "budi@example.com", Version: 4}
+	dup := model.Alumni{ID: primitive.NewObjectID(), LegacyID: 17, NIM: "434221001", Nama: "Budi Santoso", Email: "budi@kampus.ac.id", Alamat: "Medan", NoTelepon: "+6281234567"}
+
+	merged, taken, err := mergeAlumni(survivor, dup, map[string]string{"nama": "duplicate"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if merged.ID != survivor.ID || merged.Version != 4 {
+		t.Errorf("survivor identity must be kept, got %+v", merged)
+	}
+	if merged.Nama != "Budi Santoso" || merged.Email != "budi@example.com" || merged.Alamat != "Medan" || merged.NoTelepon != "+6281234567" {
+		t.Errorf("unexpected merged values: %+v", merged)
+	}
+	if !slices.Equal(taken, []string{"nama", "no_telepon", "alamat"}) {
+		t.Errorf("unexpected taken fields: %v", taken)
+	}
+	if merged.LegacyID != 17 {
+		t.Errorf("survivor without legacy id must adopt the duplicate's, got %d", merged.LegacyID)
+	}
+
+	for _, pick := range []map[string]string{{"version": "duplicate"}, {"nama": "keduanya"}} {
+		if _, _, err := mergeAlumni(survivor, dup, pick); err == nil {
+			t.Errorf("expected error for %v", pick)
+		}
+	}
+}
+
+func TestMergeAlumni_CustomAndTags(t *testing.T) {
+	survivor := model.Alumni{NIM: "434221001", Custom: map[string]any{"kota": "Medan", "ipk": 3.2}, Tags: []string{"narasumber"}}
+	dup := model.Alumni{NIM: "434221001", Custom: map[string]any{"kota": "Binjai", "ipk": 3.6, "beasiswa": "KIP"}, Tags: []string{"beasiswa", "narasumber"}}
+
+	merged, taken, err := mergeAlumni(survivor, dup, map[string]string{"custom.ipk": "duplicate"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]any{"kota": "Medan", "ipk": 3.6, "beasiswa": "KIP"}
+	if !maps.Equal(merged.Custom, want) {
+		t.Errorf("expected %v, got %v", want, merged.Custom)
+	}
+	if !slices.Equal(merged.Tags, []string{"narasumber", "beasiswa"}) {
+		t.Errorf("expected tags of both records, got %v", merged.Tags)
+	}
+	if !slices.Equal(taken, []string{"custom.beasiswa", "custom.ipk", "tags"}) {
+		t.Errorf("unexpected taken fields: %v", taken)
+	}
+	if survivor.Custom["ipk"] != 3.2 {
+		t.Errorf("survivor map must not be modified, got %v", survivor.Custom)
+	}
+	if _, _, err := mergeAlumni(survivor, dup, map[string]string{"custom.alamat": "duplicate"}); err == nil {
+		t.Error("expected error for custom field not present in either record")
+	}
+}
+
+func TestUsersToRepoint(t *testing.T) {
+	budi := model.User{ID: primitive.NewObjectID(), Username: "budi", Email: "BUDI@example.com"}
+	lama := model.User{ID: primitive.NewObjectID(), Username: "budi_lama", Email: "budi.lama@example.com"}
+
+	moving, err := usersToRepoint([]model.User{budi}, "budi@example.com")
+	if err != nil || len(moving) != 0 {
+		t.Errorf("account already on the merged email must stay, got %v %v", moving, err)
+	}
+	moving, err = usersToRepoint([]model.User{lama}, "budi@example.com")
+	if err != nil || len(moving) != 1 || moving[0].ID != lama.ID {
+		t.Errorf("account on the old email must move, got %v %v", moving, err)
+	}
+	if _, err := usersToRepoint([]model.User{budi, lama}, "budi@example.com"); err == nil {
+		t.Errorf("two accounts must not end up with the same email")
+	}
+}
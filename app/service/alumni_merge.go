@@ -0,0 +1,236 @@
+package service
+
+import (
+	"crud_alumni/app/model"
+	"crud_alumni/app/repository"
+	"crud_alumni/apperror"
+	"crud_alumni/i18n"
+	"crud_alumni/middleware"
+	"crud_alumni/tracing"
+	"crud_alumni/validation"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Sumber nilai field saat merge
+const (
+	mergeFromSurvivor  = "survivor"
+	mergeFromDuplicate = "duplicate"
+)
+
+// mergeFields - field alumni yang bisa dipilih sumbernya saat merge (nama JSON)
+var mergeFields = []struct {
+	name  string
+	empty func(a model.Alumni) bool
+	take  func(dst *model.Alumni, src model.Alumni)
+}{
+	{"nim", func(a model.Alumni) bool { return strings.TrimSpace(a.NIM) == "" }, func(d *model.Alumni, s model.Alumni) { d.NIM = s.NIM }},
+	{"nama", func(a model.Alumni) bool { return strings.TrimSpace(a.Nama) == "" }, func(d *model.Alumni, s model.Alumni) { d.Nama = s.Nama }},
+	{"jurusan", func(a model.Alumni) bool { return strings.TrimSpace(a.Jurusan) == "" }, func(d *model.Alumni, s model.Alumni) { d.Jurusan = s.Jurusan }},
+	{"angkatan", func(a model.Alumni) bool { return a.Angkatan == 0 }, func(d *model.Alumni, s model.Alumni) { d.Angkatan = s.Angkatan }},
+	{"tahun_lulus", func(a model.Alumni) bool { return a.TahunLulus == 0 }, func(d *model.Alumni, s model.Alumni) { d.TahunLulus = s.TahunLulus }},
+	{"email", func(a model.Alumni) bool { return strings.TrimSpace(a.Email) == "" }, func(d *model.Alumni, s model.Alumni) { d.Email = s.Email }},
+	{"no_telepon", func(a model.Alumni) bool { return strings.TrimSpace(a.NoTelepon) == "" }, func(d *model.Alumni, s model.Alumni) { d.NoTelepon = s.NoTelepon }},
+	{"alamat", func(a model.Alumni) bool { return strings.TrimSpace(a.Alamat) == "" }, func(d *model.Alumni, s model.Alumni) { d.Alamat = s.Alamat }},
+}
+
+// mergeAlumni - isi survivor setelah merge dan daftar field yang diambil
+// dari duplikat. pick berisi nama field -> "survivor"/"duplicate" (custom
+// field: "custom.<name>"); field lain memakai nilai survivor, atau nilai
+// duplikat jika survivor kosong. Survivor tanpa id lama mengambil id lama
+// duplikat supaya pekerjaannya ikut.
+func mergeAlumni(survivor, dup model.Alumni, pick map[string]string) (model.Alumni, []string, error) {
+	for name, source := range pick {
+		known := false
+		for _, f := range mergeFields {
+			known = known || f.name == name
+		}
+		if custom, ok := strings.CutPrefix(name, "custom."); ok {
+			_, inSurvivor := survivor.Custom[custom]
+			_, inDup := dup.Custom[custom]
+			known = inSurvivor || inDup
+		}
+		if !known || (source != mergeFromSurvivor && source != mergeFromDuplicate) {
+			return survivor, nil, apperror.BadRequest(apperror.CodeInvalidParam).WithArgs("fields." + name)
+		}
+	}
+
+	merged := survivor
+	taken := []string{}
+	for _, f := range mergeFields {
+		source, ok := pick[f.name]
+		if !ok && f.empty(survivor) && !f.empty(dup) {
+			source = mergeFromDuplicate
+		}
+		if source == mergeFromDuplicate {
+			f.take(&merged, dup)
+			taken = append(taken, f.name)
+		}
+	}
+
+	merged.Custom = maps.Clone(survivor.Custom)
+	names := slices.Collect(maps.Keys(survivor.Custom))
+	for name := range dup.Custom {
+		if _, ok := survivor.Custom[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		source, ok := pick["custom."+name]
+		_, has := survivor.Custom[name]
+		if !ok && !has {
+			source = mergeFromDuplicate
+		}
+		if source != mergeFromDuplicate {
+			continue
+		}
+		if merged.Custom == nil {
+			merged.Custom = map[string]any{}
+		}
+		if v, ok := dup.Custom[name]; ok {
+			merged.Custom[name] = v
+		} else {
+			delete(merged.Custom, name)
+		}
+		taken = append(taken, "custom."+name)
+	}
+
+	// tag tidak dipilih per sumber: survivor mendapat semua tag keduanya
+	merged.Tags = normalizeTags(append(slices.Clone(survivor.Tags), dup.Tags...))
+	if len(merged.Tags) > len(normalizeTags(survivor.Tags)) {
+		taken = append(taken, "tags")
+	}
+
+	if merged.LegacyID == 0 {
+		merged.LegacyID = dup.LegacyID
+	}
+	return merged, taken, nil
+}
+
+// usersToRepoint - akun di accounts yang email-nya perlu diganti ke email
+// hasil merge. Setiap email hanya boleh dipakai satu akun, jadi merge ditolak
+// jika accounts (pemilik email survivor, duplikat, atau hasil merge) lebih
+// dari satu.
+func usersToRepoint(accounts []model.User, email string) ([]model.User, error) {
+	if len(accounts) > 1 {
+		names := make([]string, len(accounts))
+		for i, u := range accounts {
+			names[i] = u.Username
+		}
+		return nil, apperror.New(fiber.StatusConflict, apperror.CodeMergeEmailConflict).WithArgs(email, strings.Join(names, ", "))
+	}
+	var moving []model.User
+	for _, u := range accounts {
+		if !strings.EqualFold(u.Email, email) {
+			moving = append(moving, u)
+		}
+	}
+	return moving, nil
+}
+
+// MergeAlumni godoc
+// @Summary Gabungkan alumni duplikat
+// @Description Alumni {id} (survivor) menerima nilai field yang dipilih dari duplikat, lalu pekerjaan duplikat dipindah ke survivor, akun user dengan email survivor lama/duplikat diganti ke email hasil merge (file miliknya ikut, dicatat di riwayat user), dan duplikat dipindah ke trash dengan merged_into. NIM duplikat yang berbeda dari NIM survivor tidak bisa dipilih (409, NIM masih dipakai duplikat); jadikan duplikat sebagai survivor. Merge juga ditolak (409) jika lebih dari satu akun user akan memakai email hasil merge. Merge dicatat di riwayat dan audit log (admin saja).
+// @Tags Alumni
+// @Accept json
+// @Produce json
+// @Param id path string true "ID Alumni survivor"
+// @Param body body model.MergeRequest true "Duplikat dan sumber nilai per field"
+// @Param If-Match header string false "ETag survivor; 412 jika data sudah berubah"
+// @Success 200 {object} map[string]interface{} "data berisi model.MergeResult"
+// @Failure 400 {object} model.Problem
+// @Failure 404 {object} model.Problem
+// @Failure 409 {object} model.Problem
+// @Failure 412 {object} model.Problem
+// @Failure 422 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Security BearerAuth
+// @Router /alumni/{id}/merge [post]
+func MergeAlumni(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "AlumniService.MergeAlumni")
+	defer span.End()
+
+	var req model.MergeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperror.BadRequest(apperror.CodeInvalidBody)
+	}
+	if err := validation.Struct(req); err != nil {
+		return err
+	}
+	id := c.Params("id")
+	if req.DuplicateID == id {
+		return apperror.BadRequest(apperror.CodeInvalidParam).WithArgs("duplicate_id")
+	}
+
+	survivor, err := repository.GetAlumniByID(ctx, id)
+	if err != nil {
+		return repoError(err, apperror.CodeAlumniNotFound)
+	}
+	if err := checkIfMatch(c, survivor.Version); err != nil {
+		return err
+	}
+	dup, err := repository.GetAlumniByID(ctx, req.DuplicateID)
+	if err != nil {
+		return repoError(err, apperror.CodeAlumniNotFound)
+	}
+	middleware.AuditDetail(c, "duplicate_id", dup.ID.Hex())
+
+	merged, taken, err := mergeAlumni(survivor, dup, req.Fields)
+	if err != nil {
+		return err
+	}
+	if err := validateAlumni(ctx, &merged); err != nil {
+		return err
+	}
+	accounts, err := repository.FindUsersByEmails(ctx, []string{survivor.Email, dup.Email, merged.Email})
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	moving, err := usersToRepoint(accounts, merged.Email)
+	if err != nil {
+		return err
+	}
+
+	// survivor disimpan lebih dulu; jika langkah berikutnya gagal, merge
+	// bisa diulang dengan ETag baru (pemindahan pekerjaan dan user aman diulang)
+	if err := repository.ReplaceAlumni(ctx, &merged); err != nil {
+		return alumniSaveError(err, merged.NIM)
+	}
+	result := model.MergeResult{Survivor: merged, DuplicateID: dup.ID, TakenFromDuplicate: taken}
+	if dup.LegacyID != 0 && dup.LegacyID != merged.LegacyID {
+		if result.PekerjaanMoved, err = repository.RepointPekerjaan(ctx, dup.LegacyID, merged.LegacyID); err != nil {
+			return apperror.Internal(err)
+		}
+	}
+	if result.UsersMoved, result.FilesMoved, err = repository.RepointUsers(ctx, moving, merged.Email); err != nil {
+		return apperror.Internal(err)
+	}
+	userID, _ := c.Locals("user_id").(string)
+	if err := repository.MergeAlumniInto(ctx, dup.ID, dup.Version, userID, merged.ID); err != nil {
+		return alumniSaveError(err, dup.NIM)
+	}
+
+	recordHistory(c, model.HistoryEntry{Resource: model.ResourceAlumni, RecordID: merged.ID, Version: merged.Version, Action: model.HistoryUpdate}, survivor, merged)
+	deleted, now := dup, time.Now()
+	deleted.DeletedAt, deleted.DeletedBy, deleted.MergedInto, deleted.Version = &now, userID, &merged.ID, dup.Version+1
+	recordHistory(c, model.HistoryEntry{Resource: model.ResourceAlumni, RecordID: dup.ID, Version: deleted.Version, Action: model.HistoryDelete}, dup, deleted)
+	for _, u := range moving {
+		after := u
+		after.Email, after.Version = merged.Email, u.Version+1
+		recordUserHistory(c, model.HistoryUpdate, &u, &after)
+	}
+
+	middleware.AuditDetail(c, "fields_from_duplicate", strings.Join(taken, ","))
+	middleware.AuditDetail(c, "pekerjaan_moved", strconv.FormatInt(result.PekerjaanMoved, 10))
+	middleware.AuditDetail(c, "users_moved", strconv.FormatInt(result.UsersMoved, 10))
+	middleware.AuditDetail(c, "files_moved", strconv.FormatInt(result.FilesMoved, 10))
+
+	setETag(c, merged.Version)
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "alumni.merged"), "data": result})
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"cmp"
+	"crud_alumni/app/model"
+	"crud_alumni/app/repository"
+	"crud_alumni/apperror"
+	"crud_alumni/tracing"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// profileRelationLimit - batas pekerjaan dan file yang dibaca untuk satu profil
+const profileRelationLimit = 100
+
+// GetAlumniProfile godoc
+// @Summary Profil lengkap alumni
+// @Description Data alumni, riwayat pekerjaan (urut kronologis), pekerjaan saat ini, URL foto terbaru, sertifikat yang sudah diverifikasi, dan skor kelengkapan data dalam satu response.
+// @Description Untuk non-admin email disamarkan, sedangkan no_telepon, alamat, dan gaji_range tidak ditampilkan.
+// @Tags Alumni
+// @Produce json
+// @Param id path string true "ID Alumni"
+// @Success 200 {object} model.AlumniProfile
+// @Failure 400 {object} model.Problem
+// @Failure 404 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Security BearerAuth
+// @Router /alumni/{id}/profile [get]
+func GetAlumniProfile(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "AlumniService.GetAlumniProfile")
+	defer span.End()
+
+	a, err := repository.GetAlumniDetail(ctx, c.Params("id"), repository.ReadOptions{
+		Include:      []string{repository.IncludePekerjaan, repository.IncludeFiles},
+		IncludeLimit: profileRelationLimit,
+	})
+	if err != nil {
+		return repoError(err, apperror.CodeAlumniNotFound)
+	}
+	if err := hideCustomFields(c, &a.Alumni); err != nil {
+		return err
+	}
+	return c.JSON(buildProfile(a, isAdmin(c), time.Now().Format("2006-01-02")))
+}
+
+// buildProfile menyusun profil dari alumni beserta relasinya. Skor
+// kelengkapan dihitung dari data asli sebelum disamarkan.
+func buildProfile(a model.AlumniDetail, admin bool, today string) model.AlumniProfile {
+	p := model.AlumniProfile{
+		Alumni:           a.Alumni,
+		RiwayatPekerjaan: []model.Pekerjaan{},
+		Sertifikat:       []model.ProfileFile{},
+		Redacted:         !admin,
+	}
+	if a.Pekerjaan != nil {
+		p.RiwayatPekerjaan = slices.Clone(*a.Pekerjaan)
+	}
+	slices.SortStableFunc(p.RiwayatPekerjaan, func(x, y model.Pekerjaan) int {
+		return cmp.Or(strings.Compare(x.TanggalMulaiKerja, y.TanggalMulaiKerja), x.ID.Timestamp().Compare(y.ID.Timestamp()))
+	})
+
+	var files []model.File
+	if a.Files != nil {
+		files = *a.Files
+	}
+	for _, f := range files { // terbaru lebih dulu
+		switch {
+		case f.Category == "foto" && p.FotoURL == "":
+			p.FotoURL = fileURL(f.FilePath)
+		case f.Category == "sertifikat" && f.VerifiedAt != nil:
+			p.Sertifikat = append(p.Sertifikat, model.ProfileFile{
+				ID:           f.ID,
+				OriginalName: f.OriginalName,
+				URL:          fileURL(f.FilePath),
+				VerifiedAt:   f.VerifiedAt,
+			})
+		}
+	}
+
+	p.Kelengkapan = profileCompleteness(p)
+	if !admin {
+		redactProfile(&p)
+	}
+
+	// pekerjaan yang belum selesai (tanpa tanggal selesai atau belum lewat) dan mulai paling akhir
+	for i := len(p.RiwayatPekerjaan) - 1; i >= 0; i-- {
+		job := p.RiwayatPekerjaan[i]
+		if job.TanggalSelesaiKerja == nil || *job.TanggalSelesaiKerja == "" || *job.TanggalSelesaiKerja >= today {
+			p.PekerjaanSaatIni = &job
+			break
+		}
+	}
+	return p
+}
+
+// profileCompleteness - persentase isian profil yang sudah ada; nama di
+// BelumDiisi sama dengan nama field JSON
+func profileCompleteness(p model.AlumniProfile) model.ProfileCompleteness {
+	a := p.Alumni
+	checks := []struct {
+		name   string
+		filled bool
+	}{
+		{"nim", a.NIM != ""},
+		{"nama", a.Nama != ""},
+		{"jurusan", a.Jurusan != ""},
+		{"angkatan", a.Angkatan != 0},
+		{"tahun_lulus", a.TahunLulus != 0},
+		{"email", a.Email != ""},
+		{"no_telepon", a.NoTelepon != ""},
+		{"alamat", strings.TrimSpace(a.Alamat) != ""},
+		{"riwayat_pekerjaan", len(p.RiwayatPekerjaan) > 0},
+		{"foto_url", p.FotoURL != ""},
+		{"sertifikat", len(p.Sertifikat) > 0},
+	}
+	result := model.ProfileCompleteness{BelumDiisi: []string{}}
+	filled := 0
+	for _, c := range checks {
+		if c.filled {
+			filled++
+		} else {
+			result.BelumDiisi = append(result.BelumDiisi, c.name)
+		}
+	}
+	result.Skor = filled * 100 / len(checks)
+	return result
+}
+
+// redactProfile menyembunyikan data kontak dan gaji untuk non-admin
+func redactProfile(p *model.AlumniProfile) {
+	redactAlumni(&p.Alumni, p.RiwayatPekerjaan)
+}
+
+// redactAlumni - penyamaran yang sama dengan profil untuk alumni dan
+// pekerjaannya: email disamarkan, no_telepon, alamat, deleted_by, dan
+// gaji_range dikosongkan
+func redactAlumni(a *model.Alumni, pekerjaan []model.Pekerjaan) {
+	a.Email = maskEmail(a.Email)
+	a.NoTelepon = ""
+	a.Alamat = ""
+	a.DeletedBy = ""
+	for i := range pekerjaan {
+		pekerjaan[i].GajiRange = ""
+	}
+}
+
+// redactAlumniDetails menjalankan redactAlumni pada setiap item (termasuk
+// pekerjaan dari ?include=) jika yang meminta bukan admin
+func redactAlumniDetails(c *fiber.Ctx, list ...*model.AlumniDetail) {
+	if isAdmin(c) {
+		return
+	}
+	for _, a := range list {
+		var pekerjaan []model.Pekerjaan
+		if a.Pekerjaan != nil {
+			pekerjaan = *a.Pekerjaan
+		}
+		redactAlumni(&a.Alumni, pekerjaan)
+	}
+}
+
+// maskEmail - huruf pertama nama email lalu ***, domain tetap (budi@x.id -> b***@x.id)
+func maskEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 1 {
+		return ""
+	}
+	return email[:1] + "***" + email[at:]
+}
+
+// fileURL - URL publik file di folder uploads (disajikan app.Static("/uploads"));
+// kosong jika file disimpan di luar folder tersebut
+func fileURL(path string) string {
+	rel, err := filepath.Rel("uploads", path)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return ""
+	}
+	return "/uploads/" + filepath.ToSlash(rel)
+}
+
+// detailsOf - pointer ke setiap item list (untuk redactAlumniDetails)
+func detailsOf(list []model.AlumniDetail) []*model.AlumniDetail {
+	out := make([]*model.AlumniDetail, len(list))
+	for i := range list {
+		out[i] = &list[i]
+	}
+	return out
+}
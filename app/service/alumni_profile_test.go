@@ -0,0 +1,93 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"crud_alumni/app/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func profileFixture() model.AlumniDetail {
+	selesai, belum := "2021-06-30", "2030-01-01"
+	verified := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	pekerjaan := []model.Pekerjaan{
+		{ID: primitive.NewObjectID(), NamaPerusahaan: "Kontrak", TanggalMulaiKerja: "2025-03-01", TanggalSelesaiKerja: &belum, GajiRange: "10-15jt"},
+		{ID: primitive.NewObjectID(), NamaPerusahaan: "Tetap", TanggalMulaiKerja: "2021-07-01", GajiRange: "8-10jt"},
+		{ID: primitive.NewObjectID(), NamaPerusahaan: "Magang", TanggalMulaiKerja: "2020-01-01", TanggalSelesaiKerja: &selesai},
+	}
+	files := []model.File{ // terbaru lebih dulu, seperti hasil $lookup
+		{ID: primitive.NewObjectID(), Category: "sertifikat", FilePath: "uploads/sertifikat/b.pdf"},
+		{ID: primitive.NewObjectID(), Category: "foto", FilePath: "uploads/foto/baru.jpg"},
+		{ID: primitive.NewObjectID(), Category: "sertifikat", OriginalName: "aws.pdf", FilePath: "uploads/sertifikat/a.pdf", VerifiedAt: &verified},
+		{ID: primitive.NewObjectID(), Category: "foto", FilePath: "uploads/foto/lama.jpg"},
+	}
+	return model.AlumniDetail{
+		Alumni: model.Alumni{
+			NIM: "2019001", Nama: "Budi", Jurusan: "Informatika", Angkatan: 2019, TahunLulus: 2023,
+			Email: "budi@kampus.ac.id", NoTelepon: "+6281234567",
+		},
+		Pekerjaan: &pekerjaan,
+		Files:     &files,
+	}
+}
+
+func TestBuildProfile_Admin(t *testing.T) {
+	p := buildProfile(profileFixture(), true, "2026-10-19")
+
+	var urutan []string
+	for _, job := range p.RiwayatPekerjaan {
+		urutan = append(urutan, job.NamaPerusahaan)
+	}
+	if !reflect.DeepEqual(urutan, []string{"Magang", "Tetap", "Kontrak"}) {
+		t.Errorf("riwayat harus kronologis, dapat %v", urutan)
+	}
+	if p.PekerjaanSaatIni == nil || p.PekerjaanSaatIni.NamaPerusahaan != "Kontrak" {
+		t.Errorf("pekerjaan saat ini = %+v, mau Kontrak", p.PekerjaanSaatIni)
+	}
+	if p.FotoURL != "/uploads/foto/baru.jpg" {
+		t.Errorf("foto_url = %q", p.FotoURL)
+	}
+	if len(p.Sertifikat) != 1 || p.Sertifikat[0].OriginalName != "aws.pdf" || p.Sertifikat[0].URL != "/uploads/sertifikat/a.pdf" {
+		t.Errorf("hanya sertifikat terverifikasi yang tampil: %+v", p.Sertifikat)
+	}
+	// 10 dari 11 isian (alamat kosong)
+	if p.Kelengkapan.Skor != 90 || !reflect.DeepEqual(p.Kelengkapan.BelumDiisi, []string{"alamat"}) {
+		t.Errorf("kelengkapan = %+v", p.Kelengkapan)
+	}
+	if p.Redacted || p.Alumni.Email != "budi@kampus.ac.id" || p.RiwayatPekerjaan[2].GajiRange == "" {
+		t.Errorf("admin melihat data lengkap: %+v", p)
+	}
+}
+
+func TestBuildProfile_RedactedForUser(t *testing.T) {
+	p := buildProfile(profileFixture(), false, "2026-10-19")
+
+	if !p.Redacted || p.Alumni.Email != "b***@kampus.ac.id" || p.Alumni.NoTelepon != "" {
+		t.Errorf("data kontak harus disamarkan: %+v", p.Alumni)
+	}
+	for _, job := range p.RiwayatPekerjaan {
+		if job.GajiRange != "" {
+			t.Errorf("gaji_range harus disembunyikan: %+v", job)
+		}
+	}
+	if p.PekerjaanSaatIni.GajiRange != "" {
+		t.Errorf("gaji_range pekerjaan saat ini harus disembunyikan")
+	}
+	// skor dihitung sebelum disamarkan
+	if p.Kelengkapan.Skor != 90 {
+		t.Errorf("skor = %d, mau 90", p.Kelengkapan.Skor)
+	}
+}
+
+func TestBuildProfile_Empty(t *testing.T) {
+	p := buildProfile(model.AlumniDetail{Alumni: model.Alumni{NIM: "1", Nama: "A"}}, true, "2026-10-19")
+	if p.RiwayatPekerjaan == nil || p.Sertifikat == nil || p.PekerjaanSaatIni != nil || p.FotoURL != "" {
+		t.Errorf("profil kosong = %+v", p)
+	}
+	if p.Kelengkapan.Skor != 18 {
+		t.Errorf("skor = %d, mau 18", p.Kelengkapan.Skor)
+	}
+}
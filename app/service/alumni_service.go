@@ -1,30 +1,64 @@
 package service
 
 import (
+	"context"
 	"crud_alumni/app/model"
 	"crud_alumni/app/repository"
+	"crud_alumni/apperror"
+	"crud_alumni/config"
+	"crud_alumni/i18n"
+	"crud_alumni/mergepatch"
+	"crud_alumni/phone"
+	"crud_alumni/tracing"
+	"crud_alumni/validation"
+	"errors"
+	"net/url"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // GetAllAlumni godoc
 // @Summary Dapatkan semua data alumni
 // @Description Mengambil seluruh data alumni dari database
+// @Description Untuk non-admin email disamarkan, sedangkan no_telepon, alamat, dan gaji_range (include=pekerjaan) tidak ditampilkan.
 // @Tags Alumni
 // @Accept json
 // @Produce json
+// @Param fields query string false "Field yang dikembalikan, dipisah koma (mis. nim,nama); id selalu ikut"
+// @Param include query string false "Relasi yang disertakan: pekerjaan, files (dipisah koma); files hanya untuk admin"
+// @Param include_limit query int false "Maksimal data per relasi (default 10, maksimal 50)"
 // @Success 200 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Failure 400 {object} model.Problem
+// @Failure 403 {object} model.Problem
+// @Failure 500 {object} model.Problem
 // @Security BearerAuth
 // @Router /alumni [get]
 func GetAllAlumni(c *fiber.Ctx) error {
-	data, err := repository.GetAllAlumni()
+	ctx, span := tracing.Start(c.UserContext(), "AlumniService.GetAllAlumni")
+	defer span.End()
+
+	read, err := readOptions(c, alumniFields, repository.IncludePekerjaan, repository.IncludeFiles)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Gagal ambil data"})
+		return err
 	}
-	return c.JSON(fiber.Map{"success": true, "data": data})
+	if err := checkFilesInclude(c, read, nil); err != nil {
+		return err
+	}
+	data, err := repository.GetAllAlumni(ctx, read.Repo)
+	if err != nil {
+		return repoError(err, apperror.CodeAlumniNotFound)
+	}
+	if err := hideCustomFields(c, alumniOf(data)...); err != nil {
+		return err
+	}
+	redactAlumniDetails(c, detailsOf(data)...)
+	return sendSparse(c, fiber.Map{"success": true, "data": data}, read.Keys)
 }
 
 // CreateAlumni godoc
@@ -35,128 +69,660 @@ func GetAllAlumni(c *fiber.Ctx) error {
 // @Produce json
 // @Param alumni body model.Alumni true "Data Alumni"
 // @Success 201 {object} map[string]interface{}
-// @Failure 400 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Failure 400 {object} model.Problem
+// @Failure 409 {object} model.Problem
+// @Failure 422 {object} model.Problem
+// @Failure 500 {object} model.Problem
 // @Security BearerAuth
 // @Router /alumni [post]
 func CreateAlumni(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "AlumniService.CreateAlumni")
+	defer span.End()
+
 	var a model.Alumni
 	if err := c.BodyParser(&a); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Body tidak valid"})
+		return apperror.BadRequest(apperror.CodeInvalidBody)
+	}
+	if err := validateAlumni(ctx, &a); err != nil {
+		return err
 	}
-	id, err := repository.CreateAlumni(a)
+	id, err := repository.CreateAlumni(ctx, a)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Gagal tambah"})
+		return alumniSaveError(err, a.NIM)
 	}
-	a.ID = id
+	a.ID, a.Version = id, repository.InitialVersion
+	recordHistory(c, model.HistoryEntry{Resource: model.ResourceAlumni, RecordID: a.ID, Version: a.Version, Action: model.HistoryCreate}, nil, a)
+	setETag(c, a.Version)
 	return c.Status(201).JSON(fiber.Map{"success": true, "data": a})
 }
 
 // UpdateAlumni godoc
-// @Summary Perbarui data alumni
-// @Description Admin dapat memperbarui data alumni berdasarkan ID
+// @Summary Ganti data alumni
+// @Description Admin mengganti seluruh data alumni berdasarkan ID (full replacement, termasuk nim). Field opsional yang tidak dikirim akan dihapus.
 // @Tags Alumni
 // @Accept json
 // @Produce json
 // @Param id path string true "ID Alumni"
+// @Param If-Match header string false "ETag dari GET; 412 jika data sudah berubah"
 // @Param alumni body model.Alumni true "Data Alumni"
 // @Success 200 {object} map[string]interface{}
-// @Failure 400 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Failure 400 {object} model.Problem
+// @Failure 404 {object} model.Problem
+// @Failure 409 {object} model.Problem
+// @Failure 412 {object} model.Problem
+// @Failure 422 {object} model.Problem
+// @Failure 500 {object} model.Problem
 // @Security BearerAuth
 // @Router /alumni/{id} [put]
 func UpdateAlumni(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "AlumniService.UpdateAlumni")
+	defer span.End()
+
 	id := c.Params("id")
 	var a model.Alumni
 	if err := c.BodyParser(&a); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Body tidak valid"})
+		return apperror.BadRequest(apperror.CodeInvalidBody)
+	}
+	if err := validateAlumni(ctx, &a); err != nil {
+		return err
+	}
+
+	existing, err := repository.GetAlumniByID(ctx, id)
+	if err != nil {
+		return repoError(err, apperror.CodeAlumniNotFound)
+	}
+	if err := checkIfMatch(c, existing.Version); err != nil {
+		return err
 	}
-	if err := repository.UpdateAlumni(id, a); err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Gagal update"})
+	keepAlumniMeta(&a, existing)
+
+	if err := repository.ReplaceAlumni(ctx, &a); err != nil {
+		return alumniSaveError(err, a.NIM)
+	}
+	recordHistory(c, model.HistoryEntry{Resource: model.ResourceAlumni, RecordID: a.ID, Version: a.Version, Action: model.HistoryUpdate}, existing, a)
+	setETag(c, a.Version)
+	return c.JSON(fiber.Map{"success": true, "data": a})
+}
+
+// PatchAlumni godoc
+// @Summary Ubah sebagian data alumni
+// @Description Admin mengubah field tertentu saja dengan JSON Merge Patch (RFC 7396). Field bernilai null dihapus, field yang tidak dikirim tidak berubah.
+// @Tags Alumni
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param id path string true "ID Alumni"
+// @Param If-Match header string false "ETag dari GET; 412 jika data sudah berubah"
+// @Param alumni body model.Alumni true "Field yang diubah"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} model.Problem
+// @Failure 404 {object} model.Problem
+// @Failure 409 {object} model.Problem
+// @Failure 412 {object} model.Problem
+// @Failure 415 {object} model.Problem
+// @Failure 422 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Security BearerAuth
+// @Router /alumni/{id} [patch]
+func PatchAlumni(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "AlumniService.PatchAlumni")
+	defer span.End()
+
+	patch, err := mergePatchBody(c)
+	if err != nil {
+		return err
+	}
+
+	existing, err := repository.GetAlumniByID(ctx, c.Params("id"))
+	if err != nil {
+		return repoError(err, apperror.CodeAlumniNotFound)
+	}
+	if err := checkIfMatch(c, existing.Version); err != nil {
+		return err
+	}
+	a := existing
+	if err := mergepatch.ApplyTo(&a, patch); err != nil {
+		return apperror.BadRequest(apperror.CodeInvalidBody)
+	}
+	keepAlumniMeta(&a, existing)
+
+	if err := validateAlumni(ctx, &a); err != nil {
+		return err
+	}
+	if err := repository.ReplaceAlumni(ctx, &a); err != nil {
+		return alumniSaveError(err, a.NIM)
+	}
+	recordHistory(c, model.HistoryEntry{Resource: model.ResourceAlumni, RecordID: a.ID, Version: a.Version, Action: model.HistoryUpdate}, existing, a)
+	setETag(c, a.Version)
+	return c.JSON(fiber.Map{"success": true, "data": a})
+}
+
+// checkFilesInclude - metadata file (?include=files) milik akun user, jadi di
+// daftar alumni hanya untuk admin. Untuk satu alumni (owner), akun user
+// dengan email yang sama dengan alumni tersebut juga boleh.
+func checkFilesInclude(c *fiber.Ctx, read readParams, owner *model.Alumni) error {
+	if isAdmin(c) || !slices.Contains(read.Repo.Include, repository.IncludeFiles) {
+		return nil
+	}
+	if owner != nil && owner.Email != "" {
+		userID, _ := c.Locals("user_id").(string)
+		u, err := repository.GetUserByID(c.UserContext(), userID)
+		switch {
+		case err == nil && u.Email == owner.Email:
+			return nil
+		case err != nil && !errors.Is(err, repository.ErrNotFound) && !errors.Is(err, repository.ErrInvalidID):
+			return apperror.Internal(err)
+		}
+	}
+	return apperror.Forbidden(apperror.CodeForbidden).WithKey("include_files_forbidden")
+}
+
+// keepAlumniMeta mempertahankan field yang tidak boleh diubah client
+// lewat PUT/PATCH (id, created_at, version, status trash)
+func keepAlumniMeta(a *model.Alumni, existing model.Alumni) {
+	a.ID = existing.ID
+	a.LegacyID = existing.LegacyID
+	a.CreatedAt = existing.CreatedAt
+	a.Version = existing.Version
+	a.DeletedAt = existing.DeletedAt
+	a.DeletedBy = existing.DeletedBy
+	a.MergedInto = existing.MergedInto
+}
+
+// validateAlumni memeriksa a dengan aturan model lalu dengan definisi custom
+// field, dan menyimpan hasil rapinya ke a (custom field dirapikan, no_telepon
+// E.164). Definisi custom field baru dibaca setelah aturan model lolos, jadi
+// data yang jelas salah ditolak tanpa menyentuh database.
+func validateAlumni(ctx context.Context, a *model.Alumni) error {
+	if err := validation.Struct(*a); err != nil {
+		return err
+	}
+	defs, err := repository.ListCustomFields(ctx)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	custom, fields := validateCustom(a.Custom, defs)
+	if len(fields) > 0 {
+		return apperror.Validation(fields...)
+	}
+	a.Custom = custom
+	normalizeAlumni(a)
+	return nil
+}
+
+// normalizeAlumni mengubah isian yang sudah lolos validasi ke bentuk yang
+// disimpan: no_telepon menjadi E.164 dan tag dirapikan (normalizeTags).
+// score dan purges_at hanya hasil baca, jadi kiriman client dibuang.
+func normalizeAlumni(a *model.Alumni) {
+	a.Score, a.PurgesAt = 0, nil
+	a.Tags = normalizeTags(a.Tags)
+	if n, err := phone.Normalize(a.NoTelepon); err == nil {
+		a.NoTelepon = n
 	}
-	return c.JSON(fiber.Map{"success": true})
 }
 
 // DeleteAlumni godoc
-// @Summary Hapus data alumni
-// @Description Admin dapat menghapus alumni berdasarkan ID
+// @Summary Pindahkan alumni ke trash
+// @Description Admin menghapus alumni (soft delete). Data masih bisa dipulihkan lewat /alumni/{id}/restore sampai di-purge.
 // @Tags Alumni
 // @Param id path string true "ID Alumni"
+// @Param If-Match header string false "ETag dari GET; 412 jika data sudah berubah"
 // @Success 200 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Failure 404 {object} model.Problem
+// @Failure 412 {object} model.Problem
+// @Failure 500 {object} model.Problem
 // @Security BearerAuth
 // @Router /alumni/{id} [delete]
 func DeleteAlumni(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "AlumniService.DeleteAlumni")
+	defer span.End()
+
+	id := c.Params("id")
+	existing, err := repository.GetAlumniByID(ctx, id)
+	if err != nil {
+		return repoError(err, apperror.CodeAlumniNotFound)
+	}
+	if err := checkIfMatch(c, existing.Version); err != nil {
+		return err
+	}
+	userID, _ := c.Locals("user_id").(string)
+	if err := repository.SoftDeleteAlumni(ctx, id, existing.Version, userID); err != nil {
+		return repoError(err, apperror.CodeAlumniNotFound)
+	}
+	deleted, now := existing, time.Now()
+	deleted.DeletedAt, deleted.DeletedBy, deleted.Version = &now, userID, existing.Version+1
+	recordHistory(c, model.HistoryEntry{Resource: model.ResourceAlumni, RecordID: existing.ID, Version: deleted.Version, Action: model.HistoryDelete}, existing, deleted)
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "alumni.soft_deleted")})
+}
+
+// GetAlumniTrash godoc
+// @Summary Lihat alumni di trash
+// @Description Menampilkan alumni yang sudah dihapus (soft delete), yang terakhir dihapus lebih dulu, beserta purges_at (waktu paling cepat dihapus permanen otomatis)
+// @Tags Alumni
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} model.Problem
+// @Security BearerAuth
+// @Router /alumni/trash [get]
+func GetAlumniTrash(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "AlumniService.GetAlumniTrash")
+	defer span.End()
+
+	data, err := repository.TrashAlumni(ctx)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	trash := settings.Trash
+	for i := range data {
+		data[i].PurgesAt = trash.PurgesAt(*data[i].DeletedAt)
+	}
+	return c.JSON(fiber.Map{"success": true, "data": data})
+}
+
+// RestoreAlumni godoc
+// @Summary Pulihkan alumni dari trash
+// @Description Admin mengembalikan alumni yang sudah di-soft delete
+// @Tags Alumni
+// @Param id path string true "ID Alumni"
+// @Param If-Match header string false "ETag dari GET; 412 jika data sudah berubah"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} model.Problem
+// @Failure 409 {object} model.Problem
+// @Failure 412 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Security BearerAuth
+// @Router /alumni/{id}/restore [put]
+func RestoreAlumni(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "AlumniService.RestoreAlumni")
+	defer span.End()
+
 	id := c.Params("id")
-	if err := repository.DeleteAlumni(id); err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Gagal hapus"})
+	existing, err := repository.GetTrashedAlumniByID(ctx, id)
+	if err != nil {
+		return repoError(err, apperror.CodeAlumniNotInTrash)
 	}
-	return c.JSON(fiber.Map{"success": true})
+	if err := checkIfMatch(c, existing.Version); err != nil {
+		return err
+	}
+	if err := repository.RestoreAlumni(ctx, id, existing.Version); err != nil {
+		// duplikat hasil merge yang NIM-nya sama dengan survivor
+		if errors.Is(err, repository.ErrDuplicate) {
+			return alumniSaveError(err, existing.NIM)
+		}
+		return repoError(err, apperror.CodeAlumniNotInTrash)
+	}
+	restored := existing
+	restored.DeletedAt, restored.DeletedBy, restored.MergedInto, restored.Version = nil, "", nil, existing.Version+1
+	recordHistory(c, model.HistoryEntry{Resource: model.ResourceAlumni, RecordID: existing.ID, Version: restored.Version, Action: model.HistoryRestore}, existing, restored)
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "alumni.restored")})
+}
+
+// PurgeAlumni godoc
+// @Summary Hapus permanen alumni dari trash
+// @Description Admin menghapus permanen alumni. Alumni harus sudah ada di trash.
+// @Tags Alumni
+// @Param id path string true "ID Alumni"
+// @Param If-Match header string false "ETag dari GET; 412 jika data sudah berubah"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} model.Problem
+// @Failure 412 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Security BearerAuth
+// @Router /alumni/{id}/purge [delete]
+func PurgeAlumni(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "AlumniService.PurgeAlumni")
+	defer span.End()
+
+	id := c.Params("id")
+	existing, err := repository.GetTrashedAlumniByID(ctx, id)
+	if err != nil {
+		return repoError(err, apperror.CodeAlumniNotInTrash)
+	}
+	if err := checkIfMatch(c, existing.Version); err != nil {
+		return err
+	}
+	if err := repository.PurgeAlumni(ctx, id, existing.Version); err != nil {
+		return repoError(err, apperror.CodeAlumniNotInTrash)
+	}
+	// alumni sudah terhapus; kegagalan menghapus relasi hanya di-log
+	if _, err := purgeAlumniRelations(ctx, actorOf(c), existing); err != nil {
+		config.Logger.Error().Err(err).Str("alumni_id", existing.ID.Hex()).Msg("gagal menghapus pekerjaan alumni yang di-purge")
+	}
+	recordHistory(c, model.HistoryEntry{Resource: model.ResourceAlumni, RecordID: existing.ID, Version: existing.Version, Action: model.HistoryPurge}, existing, nil)
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "alumni.purged")})
 }
 
 // GetAlumniByID godoc
 // @Summary Dapatkan detail alumni berdasarkan ID
 // @Description Mengambil data detail 1 alumni berdasarkan ID
+// @Description Untuk non-admin email disamarkan, sedangkan no_telepon, alamat, dan gaji_range (include=pekerjaan) tidak ditampilkan.
 // @Tags Alumni
 // @Param id path string true "ID Alumni"
-// @Success 200 {object} model.Alumni
-// @Failure 404 {object} map[string]interface{}
+// @Param If-None-Match header string false "ETag terakhir; 304 jika data tidak berubah (tidak berlaku dengan include)"
+// @Param fields query string false "Field yang dikembalikan, dipisah koma (mis. nim,nama); id selalu ikut"
+// @Param include query string false "Relasi yang disertakan: pekerjaan, files (dipisah koma); files hanya untuk admin atau akun dengan email alumni ini"
+// @Param include_limit query int false "Maksimal data per relasi (default 10, maksimal 50)"
+// @Success 200 {object} model.AlumniDetail
+// @Success 304 "Data tidak berubah"
+// @Failure 400 {object} model.Problem
+// @Failure 403 {object} model.Problem
+// @Failure 404 {object} model.Problem
 // @Security BearerAuth
 // @Router /alumni/{id} [get]
 func GetAlumniByID(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "AlumniService.GetAlumniByID")
+	defer span.End()
+
+	read, err := readOptions(c, alumniFields, repository.IncludePekerjaan, repository.IncludeFiles)
+	if err != nil {
+		return err
+	}
 	id := c.Params("id")
-	a, err := repository.GetAlumniByID(id)
+	a, err := repository.GetAlumniDetail(ctx, id, read.Repo)
 	if err != nil {
-		return c.Status(404).JSON(fiber.Map{"success": false, "message": "Alumni tidak ditemukan"})
+		return repoError(err, apperror.CodeAlumniNotFound)
 	}
-	return c.JSON(fiber.Map{"success": true, "data": a})
+	if err := checkFilesInclude(c, read, &a.Alumni); err != nil {
+		return err
+	}
+	// ETag hanya mewakili versi alumni (dipakai If-Match saat mengubah);
+	// relasi bisa berubah tanpa menaikkan versi, jadi dengan include tidak
+	// ada 304
+	setETag(c, a.Version)
+	if len(read.Repo.Include) == 0 && notModified(c, a.Version) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+	if err := hideCustomFields(c, &a.Alumni); err != nil {
+		return err
+	}
+	redactAlumniDetails(c, &a)
+	return sendSparse(c, fiber.Map{"success": true, "data": a}, read.Keys)
 }
 
 // GetAlumniPagination godoc
 // @Summary Dapatkan daftar alumni dengan pagination dan pencarian
-// @Description Menampilkan daftar alumni berdasarkan halaman, urutan, dan kata kunci pencarian
+// @Description Menampilkan daftar alumni berdasarkan halaman, urutan, kata kunci pencarian, dan filter terstruktur. Semua filter digabung (AND) dengan pencarian; meta.filter berisi filter yang diterapkan.
+// @Description Untuk non-admin email disamarkan, sedangkan no_telepon, alamat, dan gaji_range (include=pekerjaan) tidak ditampilkan.
+// @Description Dengan `cursor` (kosong untuk halaman pertama) dipakai pagination cursor: response berbentuk model.AlumniCursorResponse dengan meta.next/meta.prev, `page` diabaikan, dan total hanya dihitung jika `count=true`.
 // @Tags Alumni
 // @Param page query int false "Nomor halaman (default 1)"
-// @Param limit query int false "Jumlah data per halaman (default 10)"
-// @Param sortBy query string false "Kolom pengurutan (nama/nim/angkatan/tahun_lulus/email)"
+// @Param limit query int false "Jumlah data per halaman (default 10, mode cursor default 20 maksimal 100)"
+// @Param cursor query string false "Cursor meta.next/meta.prev; kosong untuk halaman pertama mode cursor"
+// @Param count query bool false "Mode cursor: hitung total data"
+// @Param sortBy query string false "Kolom pengurutan (nama/nim/angkatan/tahun_lulus/email/relevance)"
 // @Param order query string false "Arah pengurutan (asc/desc)"
 // @Param search query string false "Kata kunci pencarian"
+// @Param search_mode query string false "contains (default): potongan teks di nama/jurusan/email atau awalan NIM; text: text index per kata dengan skor relevansi"
+// @Param jurusan query []string false "Jurusan (boleh diulang atau dipisah koma)" collectionFormat(multi)
+// @Param angkatan_min query int false "Angkatan minimal"
+// @Param angkatan_max query int false "Angkatan maksimal"
+// @Param tahun_lulus_min query int false "Tahun lulus minimal"
+// @Param tahun_lulus_max query int false "Tahun lulus maksimal"
+// @Param has_email query bool false "true = punya email, false = email kosong"
+// @Param created_from query string false "Dibuat sejak (YYYY-MM-DD)"
+// @Param created_to query string false "Dibuat sampai (YYYY-MM-DD, inklusif)"
+// @Param updated_from query string false "Diubah sejak (YYYY-MM-DD)"
+// @Param updated_to query string false "Diubah sampai (YYYY-MM-DD, inklusif)"
+// @Param has_pekerjaan query bool false "Punya data pekerjaan"
+// @Param currently_employed query bool false "Sedang bekerja (pekerjaan tanpa tanggal selesai atau belum selesai)"
+// @Param tag query []string false "Hanya alumni yang punya semua tag ini (boleh diulang atau dipisah koma)" collectionFormat(multi)
+// @Param custom.{name} query string false "Filter custom field: nilai dipisah koma, atau min..max untuk number/date"
+// @Param segment query string false "ID segment; parameternya dipakai untuk filter yang tidak dikirim"
+// @Param fields query string false "Field yang dikembalikan, dipisah koma (mis. nim,nama); id selalu ikut"
+// @Param include query string false "Relasi yang disertakan: pekerjaan, files (dipisah koma); files hanya untuk admin"
+// @Param include_limit query int false "Maksimal data per relasi (default 10, maksimal 50)"
 // @Success 200 {object} model.AlumniResponse
-// @Failure 500 {object} map[string]interface{}
+// @Failure 400 {object} model.Problem
+// @Failure 403 {object} model.Problem
+// @Failure 500 {object} model.Problem
 // @Security BearerAuth
 // @Router /alumni/pag [get]
 func GetAlumniPagination(c *fiber.Ctx) error {
-	page, _ := strconv.Atoi(c.Query("page", "1"))
-	limit, _ := strconv.Atoi(c.Query("limit", "10"))
-	sortBy := c.Query("sortBy", "nama")
-	order := c.Query("order", "asc")
-	search := c.Query("search", "")
+	ctx, span := tracing.Start(c.UserContext(), "AlumniService.GetAlumniPagination")
+	defer span.End()
 
-	offset := (page - 1) * limit
-	whitelist := map[string]bool{"nama": true, "nim": true, "angkatan": true, "tahun_lulus": true, "email": true}
-	if !whitelist[sortBy] {
-		sortBy = "nama"
+	q, err := alumniListQuery(c)
+	if err != nil {
+		return err
 	}
-	if strings.ToLower(order) != "desc" {
-		order = "asc"
+	read, err := readOptions(c, alumniFields, repository.IncludePekerjaan, repository.IncludeFiles)
+	if err != nil {
+		return err
+	}
+	if err := checkFilesInclude(c, read, nil); err != nil {
+		return err
+	}
+	if cursorRequested(c) {
+		return alumniCursorPage(c, q, read)
 	}
 
-	alumni, err := repository.GetAlumniWithPagination(search, sortBy, order, limit, offset)
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	offset := (page - 1) * limit
+
+	span.SetAttributes(
+		attribute.String("alumni.search", q.Search.Query),
+		attribute.String("alumni.search_mode", q.Search.Mode),
+		attribute.String("alumni.sort_by", q.SortBy),
+		attribute.Int("alumni.page", page),
+		attribute.Int("alumni.limit", limit),
+	)
+
+	alumni, err := repository.GetAlumniWithPagination(ctx, q.Search, q.Filter, q.SortBy, q.Order, limit, offset, read.Repo)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+		return apperror.Internal(err)
+	}
+	total, err := repository.CountAlumni(ctx, q.Search, q.Filter)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if err := hideCustomFields(c, alumniOf(alumni)...); err != nil {
+		return err
 	}
-	total, _ := repository.CountAlumni(search)
+	redactAlumniDetails(c, detailsOf(alumni)...)
 
-	return c.JSON(model.AlumniResponse{
+	return sendSparse(c, model.AlumniResponse{
 		Data: alumni,
 		Meta: model.MetaInfo{
 			Page:   page,
 			Limit:  limit,
 			Total:  total,
 			Pages:  (total + limit - 1) / limit,
-			SortBy: sortBy,
-			Order:  order,
-			Search: search,
+			SortBy: q.SortBy,
+			Order:  q.Order,
+			Search:     q.Search.Query,
+			SearchMode: q.Search.Mode,
+			Filter:     &q.Filter,
+		},
+	}, read.Keys)
+}
+
+// alumniCursorPage - /alumni/pag dengan ?cursor: keyset tanpa skip, total
+// hanya dihitung jika ?count=true. Urutan relevance tidak bisa dipakai.
+func alumniCursorPage(c *fiber.Ctx, q alumniQuery, read readParams) error {
+	ctx, span := tracing.Start(c.UserContext(), "AlumniService.alumniCursorPage")
+	defer span.End()
+
+	req, err := pageRequest(c, []string{"nama", "nim", "angkatan", "tahun_lulus", "email"}, "nama", "asc")
+	if err != nil {
+		return err
+	}
+	page, err := repository.AlumniPage(ctx, q.Search, q.Filter, req, read.Repo)
+	if err != nil {
+		return repoError(err, apperror.CodeAlumniNotFound)
+	}
+	if err := hideCustomFields(c, alumniOf(page.Items)...); err != nil {
+		return err
+	}
+	redactAlumniDetails(c, detailsOf(page.Items)...)
+
+	meta := cursorMeta(req, page)
+	meta.Search, meta.SearchMode, meta.Filter = q.Search.Query, q.Search.Mode, &q.Filter
+	return sendSparse(c, model.AlumniCursorResponse{Data: page.Items, Meta: meta}, read.Keys)
+}
+
+// SuggestAlumni godoc
+// @Summary Autocomplete alumni
+// @Description Saran alumni untuk kotak pencarian: NIM yang diawali q, atau nama yang salah satu katanya diawali q (tanpa peduli huruf besar/kecil dan diakritik). q kurang dari 2 karakter menghasilkan daftar kosong.
+// @Tags Alumni
+// @Produce json
+// @Param q query string true "Awalan nama atau NIM"
+// @Param limit query int false "Jumlah saran (default 10, maksimal 20)"
+// @Success 200 {array} model.AlumniSuggestion
+// @Failure 500 {object} model.Problem
+// @Security BearerAuth
+// @Router /alumni/suggest [get]
+func SuggestAlumni(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "AlumniService.SuggestAlumni")
+	defer span.End()
+
+	q := strings.TrimSpace(c.Query("q"))
+	if utf8.RuneCountInString(q) < 2 {
+		return c.JSON([]model.AlumniSuggestion{})
+	}
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	if limit <= 0 || limit > 20 {
+		limit = 10
+	}
+
+	list, err := repository.SuggestAlumni(ctx, q, limit)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	return c.JSON(list)
+}
+
+// alumniQuery - parameter pencarian, filter, dan urutan daftar alumni,
+// dipakai bersama oleh /alumni/pag dan /alumni/export
+type alumniQuery struct {
+	Search model.AlumniSearch
+	Filter model.AlumniFilter
+	SortBy string
+	Order  string
+}
+
+func alumniListQuery(c *fiber.Ctx) (alumniQuery, error) {
+	values, err := alumniQueryValues(c)
+	if err != nil {
+		return alumniQuery{}, err
+	}
+	return parseAlumniQuery(c.UserContext(), values, isAdmin(c))
+}
+
+// parseAlumniQuery membaca pencarian, filter, dan urutan dari values (query
+// string request, atau query segment). admin menentukan custom field mana
+// yang boleh difilter.
+func parseAlumniQuery(ctx context.Context, values url.Values, admin bool) (alumniQuery, error) {
+	get := func(name, def string) string {
+		if v := values.Get(name); v != "" {
+			return v
+		}
+		return def
+	}
+	q := alumniQuery{
+		Search: model.AlumniSearch{
+			Query: strings.TrimSpace(get("search", "")),
+			Mode:  get("search_mode", model.AlumniSearchContains),
 		},
-	})
+		SortBy: get("sortBy", "nama"),
+		Order:  get("order", "asc"),
+	}
+	if q.Search.Mode != model.AlumniSearchContains && q.Search.Mode != model.AlumniSearchText {
+		return q, apperror.BadRequest(apperror.CodeInvalidParam).WithArgs("search_mode")
+	}
+	// pencarian text index default-nya diurutkan dari yang paling relevan
+	if q.Search.Mode == model.AlumniSearchText && q.Search.Query != "" && values.Get("sortBy") == "" {
+		q.SortBy = repository.SortRelevance
+	}
+	whitelist := map[string]bool{"nama": true, "nim": true, "angkatan": true, "tahun_lulus": true, "email": true, repository.SortRelevance: true}
+	if !whitelist[q.SortBy] {
+		q.SortBy = "nama"
+	}
+	if strings.ToLower(q.Order) != "desc" {
+		q.Order = "asc"
+	}
+
+	filter, err := parseAlumniFilter(ctx, values, admin)
+	if err != nil {
+		return q, err
+	}
+	q.Filter = filter
+	return q, nil
+}
+
+// parseAlumniFilter membaca filter terstruktur dari query string. Nilai yang
+// tidak valid ditolak (400) supaya filter tidak diam-diam diabaikan.
+func parseAlumniFilter(ctx context.Context, values url.Values, admin bool) (model.AlumniFilter, error) {
+	var f model.AlumniFilter
+	// jurusan dan tag boleh diulang (?jurusan=A&jurusan=B) atau dipisah koma
+	for _, raw := range values["jurusan"] {
+		for _, j := range strings.Split(raw, ",") {
+			if j = strings.TrimSpace(j); j != "" {
+				f.Jurusan = append(f.Jurusan, j)
+			}
+		}
+	}
+
+	for _, raw := range values["tag"] {
+		f.Tags = append(f.Tags, strings.Split(raw, ",")...)
+	}
+	f.Tags = normalizeTags(f.Tags)
+
+	var err error
+	ints := []struct {
+		name string
+		dst  *int
+	}{
+		{"angkatan_min", &f.AngkatanMin}, {"angkatan_max", &f.AngkatanMax},
+		{"tahun_lulus_min", &f.TahunLulusMin}, {"tahun_lulus_max", &f.TahunLulusMax},
+	}
+	for _, p := range ints {
+		if raw := values.Get(p.name); raw != "" {
+			if *p.dst, err = strconv.Atoi(raw); err != nil {
+				return f, apperror.BadRequest(apperror.CodeInvalidParam).WithArgs(p.name)
+			}
+		}
+	}
+	if f.AngkatanMin != 0 && f.AngkatanMax != 0 && f.AngkatanMin > f.AngkatanMax {
+		return f, apperror.BadRequest(apperror.CodeInvalidParam).WithArgs("angkatan_max")
+	}
+	if f.TahunLulusMin != 0 && f.TahunLulusMax != 0 && f.TahunLulusMin > f.TahunLulusMax {
+		return f, apperror.BadRequest(apperror.CodeInvalidParam).WithArgs("tahun_lulus_max")
+	}
+
+	dates := []struct {
+		name string
+		dst  *string
+	}{
+		{"created_from", &f.CreatedFrom}, {"created_to", &f.CreatedTo},
+		{"updated_from", &f.UpdatedFrom}, {"updated_to", &f.UpdatedTo},
+	}
+	for _, p := range dates {
+		if raw := values.Get(p.name); raw != "" {
+			if _, err := time.Parse("2006-01-02", raw); err != nil {
+				return f, apperror.BadRequest(apperror.CodeInvalidParam).WithArgs(p.name)
+			}
+			*p.dst = raw
+		}
+	}
+
+	bools := []struct {
+		name string
+		dst  **bool
+	}{
+		{"has_email", &f.HasEmail}, {"has_pekerjaan", &f.HasPekerjaan}, {"currently_employed", &f.CurrentlyEmployed},
+	}
+	for _, p := range bools {
+		if raw := values.Get(p.name); raw != "" {
+			v, err := strconv.ParseBool(raw)
+			if err != nil {
+				return f, apperror.BadRequest(apperror.CodeInvalidParam).WithArgs(p.name)
+			}
+			*p.dst = &v
+		}
+	}
+
+	if f.Custom, err = parseCustomFilter(ctx, values, admin); err != nil {
+		return f, err
+	}
+	return f, nil
 }
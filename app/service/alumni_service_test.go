@@ -0,0 +1,232 @@
+package service
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"crud_alumni/app/model"
+	"crud_alumni/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCreateAlumni_ValidationFailed(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+	app.Post("/alumni", CreateAlumni)
+
+	// body invalid harus ditolak sebelum menyentuh database
+	body := `{"nim":"","nama":"Budi","jurusan":"Kedokteran","angkatan":2020,"tahun_lulus":2019,"email":"budi"}`
+	req := httptest.NewRequest(http.MethodPost, "/alumni", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept-Language", "en")
+
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422, got %d", resp.StatusCode)
+	}
+
+	var problem model.Problem
+	if err := json.NewDecoder(resp.Body).Decode(&problem); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	got := map[string]string{}
+	for _, f := range problem.Errors {
+		got[f.Field] = f.Message
+	}
+	for _, field := range []string{"nim", "jurusan", "tahun_lulus", "email"} {
+		if got[field] == "" {
+			t.Errorf("expected error untuk field %s, got %v", field, got)
+		}
+	}
+	if got["nim"] != "This field is required" {
+		t.Errorf("expected pesan terjemahan, got %q", got["nim"])
+	}
+}
+
+func TestNormalizeAlumni_DropsReadOnlyFields(t *testing.T) {
+	purgesAt := time.Now()
+	a := model.Alumni{NoTelepon: "0812-3456-7890", Score: 9.5, PurgesAt: &purgesAt}
+	normalizeAlumni(&a)
+	if a.Score != 0 || a.PurgesAt != nil {
+		t.Errorf("score dan purges_at dari client harus dibuang, got %v / %v", a.Score, a.PurgesAt)
+	}
+	if a.NoTelepon != "+6281234567890" {
+		t.Errorf("expected E.164 phone, got %q", a.NoTelepon)
+	}
+}
+
+func TestPatchAlumni_RejectsBadPatch(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+	app.Patch("/alumni/:id", PatchAlumni)
+
+	cases := []struct {
+		name        string
+		contentType string
+		body        string
+		want        int
+	}{
+		{"content-type salah", "text/plain", `{"nama":"Budi"}`, http.StatusUnsupportedMediaType},
+		{"patch bukan object", "application/merge-patch+json", `["nama"]`, http.StatusBadRequest},
+		{"json rusak", "application/merge-patch+json", `{"nama":`, http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPatch, "/alumni/"+primitive.NewObjectID().Hex(), strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", tc.contentType)
+			resp, err := app.Test(req, -1)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			if resp.StatusCode != tc.want {
+				t.Errorf("expected %d, got %d", tc.want, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestAlumniListQuery_ParsesFilter(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+	app.Get("/alumni/pag", func(c *fiber.Ctx) error {
+		q, err := alumniListQuery(c)
+		if err != nil {
+			return err
+		}
+		return c.JSON(q.Filter)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/alumni/pag?jurusan=Manajemen,Akuntansi&jurusan=Teknik+Sipil&angkatan_min=2018&has_pekerjaan=false&updated_from=2026-01-01", nil)
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	var f model.AlumniFilter
+	if err := json.NewDecoder(resp.Body).Decode(&f); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if strings.Join(f.Jurusan, "|") != "Manajemen|Akuntansi|Teknik Sipil" {
+		t.Errorf("unexpected jurusan: %q", f.Jurusan)
+	}
+	if f.AngkatanMin != 2018 || f.HasPekerjaan == nil || *f.HasPekerjaan || f.UpdatedFrom != "2026-01-01" {
+		t.Errorf("unexpected filter: %+v", f)
+	}
+
+	for _, query := range []string{"angkatan_min=abc", "angkatan_min=2020&angkatan_max=2019", "has_email=maybe", "created_to=31-12-2024"} {
+		req := httptest.NewRequest(http.MethodGet, "/alumni/pag?"+query, nil)
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", query, resp.StatusCode)
+		}
+	}
+}
+
+func TestAlumniListQuery_SearchMode(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+	app.Get("/alumni/pag", func(c *fiber.Ctx) error {
+		q, err := alumniListQuery(c)
+		if err != nil {
+			return err
+		}
+		return c.SendString(q.Search.Mode + " " + q.SortBy)
+	})
+
+	cases := map[string]string{
+		"search=budi":                             "contains nama",
+		"search=budi&search_mode=text":            "text relevance",
+		"search=budi&search_mode=text&sortBy=nim": "text nim",
+		"search_mode=text":                        "text nama",
+	}
+	for query, want := range cases {
+		req := httptest.NewRequest(http.MethodGet, "/alumni/pag?"+query, nil)
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		if string(body) != want {
+			t.Errorf("%s: expected %q, got %q", query, want, body)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/alumni/pag?search_mode=regex", nil)
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected 400 untuk search_mode tidak dikenal, got %d", resp.StatusCode)
+	}
+}
+
+func TestCheckFilesInclude(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+	app.Get("/alumni", func(c *fiber.Ctx) error {
+		c.Locals("role", c.Query("role"))
+		read, err := readOptions(c, alumniFields, "pekerjaan", "files")
+		if err != nil {
+			return err
+		}
+		if err := checkFilesInclude(c, read, nil); err != nil {
+			return err
+		}
+		return c.SendStatus(http.StatusNoContent)
+	})
+
+	cases := map[string]int{
+		"role=user&include=pekerjaan":       http.StatusNoContent,
+		"role=user&include=pekerjaan,files": http.StatusForbidden,
+		"role=admin&include=files":          http.StatusNoContent,
+	}
+	for query, want := range cases {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/alumni?"+query, nil), -1)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		if resp.StatusCode != want {
+			t.Errorf("%s: expected %d, got %d", query, want, resp.StatusCode)
+		}
+	}
+}
+
+func TestRedactAlumniDetails(t *testing.T) {
+	app := fiber.New()
+	var got []model.AlumniDetail
+	app.Get("/alumni", func(c *fiber.Ctx) error {
+		c.Locals("role", c.Query("role"))
+		got = []model.AlumniDetail{profileFixture()}
+		got[0].Alamat = "Jl. Mawar 1"
+		redactAlumniDetails(c, detailsOf(got)...)
+		return c.SendStatus(http.StatusNoContent)
+	})
+
+	if _, err := app.Test(httptest.NewRequest(http.MethodGet, "/alumni?role=user", nil), -1); err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	a := got[0]
+	if a.Email != "b***@kampus.ac.id" || a.NoTelepon != "" || a.Alamat != "" {
+		t.Errorf("data kontak harus disamarkan untuk non-admin: %+v", a.Alumni)
+	}
+	for _, job := range *a.Pekerjaan {
+		if job.GajiRange != "" {
+			t.Errorf("gaji_range harus disembunyikan: %+v", job)
+		}
+	}
+
+	if _, err := app.Test(httptest.NewRequest(http.MethodGet, "/alumni?role=admin", nil), -1); err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if a := got[0]; a.Email != "budi@kampus.ac.id" || a.NoTelepon == "" || (*a.Pekerjaan)[0].GajiRange == "" {
+		t.Errorf("admin harus melihat data lengkap: %+v", a.Alumni)
+	}
+}
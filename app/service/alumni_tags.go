@@ -0,0 +1,187 @@
+package service
+
+import (
+	"crud_alumni/app/model"
+	"crud_alumni/app/repository"
+	"crud_alumni/apperror"
+	"crud_alumni/i18n"
+	"crud_alumni/middleware"
+	"crud_alumni/tracing"
+	"crud_alumni/validation"
+	"errors"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// normalizeTags - tag dalam bentuk yang disimpan: huruf kecil, spasi di
+// dalam diringkas menjadi satu, tanpa tag kosong dan duplikat (urutan
+// kemunculan pertama dipertahankan). nil jika tidak ada tag.
+func normalizeTags(tags []string) []string {
+	var out []string
+	for _, t := range tags {
+		t = strings.ToLower(strings.Join(strings.Fields(t), " "))
+		if t != "" && !slices.Contains(out, t) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// maxAlumniTags - batas tag per alumni, sama dengan validate:"max=20" pada
+// model.Alumni.Tags
+const maxAlumniTags = 20
+
+// tagUpdateBatch - jumlah alumni yang dibaca sekali jalan saat bulk tag
+const tagUpdateBatch = 500
+
+// applyTags - tags setelah tag remove dihapus dan tag add ditambahkan di
+// belakang, beserta apakah ada tag yang bertambah/berkurang
+func applyTags(tags, add, remove []string) (out []string, gained, lost bool) {
+	for _, t := range tags {
+		if slices.Contains(remove, t) {
+			lost = true
+			continue
+		}
+		out = append(out, t)
+	}
+	for _, t := range add {
+		if !slices.Contains(out, t) {
+			out = append(out, t)
+			gained = true
+		}
+	}
+	return out, gained, lost
+}
+
+// bulkTag mengubah tag setiap alumni yang cocok dengan q satu per satu
+// dengan cek versi, lalu mencatatnya di riwayat. Alumni yang akan punya
+// lebih dari maxAlumniTags tag, atau yang berubah oleh request lain di tengah
+// proses, dilewati. result diisi juga saat error.
+func bulkTag(c *fiber.Ctx, q alumniQuery, add, remove []string, result *model.TagUpdateResult) error {
+	ctx := c.UserContext()
+	var after primitive.ObjectID
+	for {
+		list, err := repository.AlumniTagTargets(ctx, q.Search, q.Filter, add, remove, after, tagUpdateBatch)
+		if err != nil {
+			return err
+		}
+		for _, a := range list {
+			after = a.ID
+			tags, gained, lost := applyTags(a.Tags, add, remove)
+			if len(tags) > maxAlumniTags {
+				result.TagLimit++
+				continue
+			}
+			updated := a
+			updated.Tags = tags
+			if err := repository.SetAlumniTags(ctx, &updated); err != nil {
+				if errors.Is(err, repository.ErrVersionConflict) {
+					result.Conflicts++
+					continue
+				}
+				return err
+			}
+			if gained {
+				result.Tagged++
+			}
+			if lost {
+				result.Untagged++
+			}
+			recordHistory(c, model.HistoryEntry{Resource: model.ResourceAlumni, RecordID: a.ID, Version: updated.Version, Action: model.HistoryUpdate}, a, updated)
+		}
+		if len(list) < tagUpdateBatch {
+			return nil
+		}
+	}
+}
+
+// GetAlumniTags godoc
+// @Summary Daftar tag alumni
+// @Description Semua tag yang dipakai alumni di luar trash beserta jumlah alumninya, urut dari yang paling banyak.
+// @Tags Alumni
+// @Produce json
+// @Success 200 {object} map[string]interface{} "data berisi []model.TagCount"
+// @Failure 500 {object} model.Problem
+// @Security BearerAuth
+// @Router /alumni/tags [get]
+func GetAlumniTags(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "AlumniService.GetAlumniTags")
+	defer span.End()
+
+	list, err := repository.AlumniTagCounts(ctx)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	return c.JSON(fiber.Map{"success": true, "data": list})
+}
+
+// BulkTagAlumni godoc
+// @Summary Tambah/hapus tag pada banyak alumni
+// @Description Admin menambah tag `add` dan menghapus tag `remove` pada semua alumni di luar trash yang cocok dengan pencarian, filter, atau segment di query string (parameter sama dengan /alumni/pag). Minimal satu filter wajib diisi. Versi alumni yang berubah naik dan setiap perubahan dicatat di riwayat alumni; ringkasannya dicatat di audit log. Alumni yang akan punya lebih dari 20 tag dilewati (tag_limit), begitu juga alumni yang diubah request lain selama proses (conflicts).
+// @Tags Alumni
+// @Accept json
+// @Produce json
+// @Param segment query string false "ID segment"
+// @Param search query string false "Kata kunci pencarian"
+// @Param tag query []string false "Hanya alumni yang punya semua tag ini" collectionFormat(multi)
+// @Param body body model.TagUpdateRequest true "Tag yang ditambah dan dihapus"
+// @Success 200 {object} map[string]interface{} "data berisi model.TagUpdateResult"
+// @Failure 400 {object} model.Problem
+// @Failure 404 {object} model.Problem
+// @Failure 422 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Security BearerAuth
+// @Router /alumni/tags [post]
+func BulkTagAlumni(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "AlumniService.BulkTagAlumni")
+	defer span.End()
+
+	var req model.TagUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperror.BadRequest(apperror.CodeInvalidBody)
+	}
+	if err := validation.Struct(req); err != nil {
+		return err
+	}
+	req.Add, req.Remove = normalizeTags(req.Add), normalizeTags(req.Remove)
+	if len(req.Add) == 0 && len(req.Remove) == 0 {
+		return apperror.Validation(apperror.FieldError{Field: "add", Code: "required"})
+	}
+	// tag yang ditambah sekaligus dihapus tidak jelas maksudnya
+	for _, t := range req.Add {
+		if slices.Contains(req.Remove, t) {
+			return apperror.BadRequest(apperror.CodeInvalidParam).WithArgs("remove")
+		}
+	}
+
+	q, err := alumniListQuery(c)
+	if err != nil {
+		return err
+	}
+	if q.Search.Query == "" && reflect.ValueOf(q.Filter).IsZero() {
+		return apperror.BadRequest(apperror.CodeTagFilterRequired)
+	}
+	middleware.AuditDetail(c, "query", string(c.Context().QueryArgs().QueryString()))
+	middleware.AuditDetail(c, "add", strings.Join(req.Add, ","))
+	middleware.AuditDetail(c, "remove", strings.Join(req.Remove, ","))
+
+	matched, err := repository.CountAlumni(ctx, q.Search, q.Filter)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	result := model.TagUpdateResult{Matched: matched}
+	err = bulkTag(c, q, req.Add, req.Remove, &result)
+	middleware.AuditDetail(c, "tagged", strconv.FormatInt(result.Tagged, 10))
+	middleware.AuditDetail(c, "untagged", strconv.FormatInt(result.Untagged, 10))
+	middleware.AuditDetail(c, "tag_limit", strconv.FormatInt(result.TagLimit, 10))
+	middleware.AuditDetail(c, "conflicts", strconv.FormatInt(result.Conflicts, 10))
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "alumni.tags_updated", matched), "data": result})
+}
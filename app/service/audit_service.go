@@ -0,0 +1,191 @@
+package service
+
+import (
+	"bufio"
+	"crud_alumni/app/model"
+	"crud_alumni/app/repository"
+	"crud_alumni/apperror"
+	"crud_alumni/config"
+	"crud_alumni/middleware"
+	"crud_alumni/tabular"
+	"crud_alumni/tracing"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// auditColumns - kolom export CSV audit log, sama dengan field JSON
+var auditColumns = []string{
+	"seq", "at", "actor_id", "actor_name", "actor_role", "action", "resource", "resource_id",
+	"status", "method", "path", "ip", "request_id", "detail", "prev_hash", "hash",
+}
+
+// auditRows - sumber baris export (repository.AuditIterator, atau slice di test)
+type auditRows interface {
+	Next(e *model.AuditEntry) (bool, error)
+}
+
+// auditFilter membaca ?actor=, ?action=, ?resource=, ?from=, dan ?to=
+func auditFilter(c *fiber.Ctx) (model.AuditFilter, error) {
+	f := model.AuditFilter{
+		Actor:    c.Query("actor"),
+		Action:   c.Query("action"),
+		Resource: c.Query("resource"),
+		From:     c.Query("from"),
+		To:       c.Query("to"),
+	}
+	for name, raw := range map[string]string{"from": f.From, "to": f.To} {
+		if raw == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", raw); err != nil {
+			return f, apperror.BadRequest(apperror.CodeInvalidParam).WithArgs(name)
+		}
+	}
+	if f.From != "" && f.To != "" && f.From > f.To {
+		return f, apperror.BadRequest(apperror.CodeInvalidParam).WithArgs("to")
+	}
+	return f, nil
+}
+
+// GetAuditLog godoc
+// @Summary Audit log
+// @Description Kejadian penting di seluruh sistem (login, export, import, download file, perubahan role, hapus permanen, merge alumni), yang terbaru lebih dulu, dengan pagination cursor (admin saja).
+// @Tags Admin
+// @Produce json
+// @Param actor query string false "ID atau username pelaku"
+// @Param action query string false "login, login_failed, export, import, file_download, role_change, hard_delete, trash_purge, merge"
+// @Param resource query string false "alumni, pekerjaan, users, files, audit_log"
+// @Param from query string false "Sejak tanggal (YYYY-MM-DD, UTC)"
+// @Param to query string false "Sampai tanggal (YYYY-MM-DD, UTC, inklusif)"
+// @Param cursor query string false "Cursor meta.next/meta.prev"
+// @Param limit query int false "Jumlah data per halaman (default 20, maksimal 100)"
+// @Param order query string false "asc/desc (default desc)"
+// @Param count query bool false "Hitung total entri"
+// @Success 200 {object} model.AuditCursorResponse
+// @Failure 400 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Security BearerAuth
+// @Router /admin/audit [get]
+func GetAuditLog(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "AuditService.GetAuditLog")
+	defer span.End()
+
+	f, err := auditFilter(c)
+	if err != nil {
+		return err
+	}
+	req, err := pageRequest(c, []string{"seq"}, "seq", "desc")
+	if err != nil {
+		return err
+	}
+	page, err := repository.AuditPage(ctx, f, req)
+	if err != nil {
+		return repoError(err, apperror.CodeInternal)
+	}
+	return c.JSON(model.AuditCursorResponse{Data: page.Items, Meta: cursorMeta(req, page)})
+}
+
+// ExportAuditLog godoc
+// @Summary Export audit log ke CSV
+// @Description Mengunduh audit log dengan filter yang sama seperti /admin/audit, urut seq naik, termasuk prev_hash dan hash untuk diperiksa di luar sistem. Export ini sendiri juga dicatat di audit log.
+// @Tags Admin
+// @Produce text/csv
+// @Param actor query string false "ID atau username pelaku"
+// @Param action query string false "Aksi"
+// @Param resource query string false "Resource"
+// @Param from query string false "Sejak tanggal (YYYY-MM-DD, UTC)"
+// @Param to query string false "Sampai tanggal (YYYY-MM-DD, UTC, inklusif)"
+// @Success 200 {file} file
+// @Failure 400 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Security BearerAuth
+// @Router /admin/audit/export [get]
+func ExportAuditLog(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "AuditService.ExportAuditLog")
+	defer span.End()
+
+	f, err := auditFilter(c)
+	if err != nil {
+		return err
+	}
+	it, err := repository.IterateAudit(ctx, f, settings.Export.Timeout)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	middleware.AuditDetail(c, "format", "csv")
+
+	c.Attachment("audit-log-" + time.Now().Format("20060102") + ".csv")
+	c.Set(fiber.HeaderContentType, tabular.ContentType("csv"))
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer it.Close()
+		if err := writeAuditCSV(w, it); err != nil {
+			config.Logger.Error().Err(err).Msg("export audit log gagal di tengah streaming")
+		}
+	})
+	return nil
+}
+
+// writeAuditCSV menulis header auditColumns lalu setiap entri dari rows.
+// Nilai dari input client (mis. actor_name dari login gagal) bisa diawali
+// "=": writer csv tabular memberi awalan ' lewat tabular.EscapeFormula.
+func writeAuditCSV(w *bufio.Writer, rows auditRows) error {
+	tw, err := tabular.NewWriter(w, "csv", tabular.PDFHeader{})
+	if err != nil {
+		return err
+	}
+	if err := tw.Write(auditColumns); err != nil {
+		return err
+	}
+	for {
+		var e model.AuditEntry
+		ok, err := rows.Next(&e)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		detail := ""
+		if len(e.Detail) > 0 {
+			raw, _ := json.Marshal(e.Detail)
+			detail = string(raw)
+		}
+		status := ""
+		if e.Status != 0 {
+			status = strconv.Itoa(e.Status)
+		}
+		if err := tw.Write([]string{
+			strconv.FormatInt(e.Seq, 10), e.At.UTC().Format(time.RFC3339Nano), e.ActorID, e.ActorName, e.ActorRole,
+			e.Action, e.Resource, e.ResourceID, status, e.Method, e.Path, e.IP, e.RequestID, detail, e.PrevHash, e.Hash,
+		}); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// VerifyAuditLog godoc
+// @Summary Periksa rantai hash audit log
+// @Description Menghitung ulang hash setiap entri dari awal. valid=false beserta broken_seq jika ada entri yang diubah, dihapus di tengah, atau disisipkan. Simpan last_hash di luar sistem untuk mendeteksi penghapusan entri terakhir.
+// @Tags Admin
+// @Produce json
+// @Success 200 {object} model.AuditVerifyResult
+// @Failure 500 {object} model.Problem
+// @Security BearerAuth
+// @Router /admin/audit/verify [get]
+func VerifyAuditLog(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "AuditService.VerifyAuditLog")
+	defer span.End()
+
+	result, err := repository.VerifyAudit(ctx, settings.Export.Timeout)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	return c.JSON(result)
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"crud_alumni/app/model"
+	"encoding/csv"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type sliceAuditRows []model.AuditEntry
+
+func (r *sliceAuditRows) Next(e *model.AuditEntry) (bool, error) {
+	if len(*r) == 0 {
+		return false, nil
+	}
+	*e = (*r)[0]
+	*r = (*r)[1:]
+	return true, nil
+}
+
+func TestWriteAuditCSV(t *testing.T) {
+	rows := sliceAuditRows{{
+		Seq: 7, At: time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC), ActorID: "u1", ActorName: "admin",
+		Action: model.AuditExport, Resource: model.ResourceAlumni, Status: 200,
+		Detail: map[string]string{"format": "csv"}, PrevHash: "aa", Hash: "bb",
+	}}
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	if err := writeAuditCSV(w, &rows); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid csv: %v", err)
+	}
+	if len(records) != 2 || len(records[1]) != len(auditColumns) {
+		t.Fatalf("expected header + 1 row, got %v", records)
+	}
+	got := map[string]string{}
+	for i, col := range auditColumns {
+		got[col] = records[1][i]
+	}
+	if got["seq"] != "7" || got["at"] != "2026-10-01T08:00:00Z" || got["status"] != "200" ||
+		got["detail"] != `{"format":"csv"}` || got["hash"] != "bb" || got["resource_id"] != "" {
+		t.Errorf("unexpected row: %v", got)
+	}
+}
+
+func TestWriteAuditCSV_FormulaEscaped(t *testing.T) {
+	rows := sliceAuditRows{{Seq: 1, ActorName: "=HYPERLINK(\"http://x\")", Action: model.AuditLoginFailed, Resource: model.ResourceUsers}}
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	if err := writeAuditCSV(w, &rows); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil || len(records) != 2 {
+		t.Fatalf("invalid csv: %v %v", records, err)
+	}
+	if got := records[1][3]; got != `'=HYPERLINK("http://x")` {
+		t.Errorf("actor_name must be escaped, got %q", got)
+	}
+}
+
+func TestAuditFilter_InvalidDates(t *testing.T) {
+	app := fiber.New()
+	app.Get("/audit", func(c *fiber.Ctx) error {
+		if _, err := auditFilter(c); err != nil {
+			return c.SendStatus(fiber.StatusBadRequest)
+		}
+		return c.SendStatus(fiber.StatusOK)
+	})
+	cases := map[string]int{
+		"/audit?actor=admin&from=2026-10-01&to=2026-10-31": 200,
+		"/audit?from=01-10-2026":                           400,
+		"/audit?from=2026-10-31&to=2026-10-01":             400,
+	}
+	for url, want := range cases {
+		resp, err := app.Test(httptest.NewRequest("GET", url, nil))
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		if resp.StatusCode != want {
+			t.Errorf("%s: expected %d, got %d", url, want, resp.StatusCode)
+		}
+	}
+}
@@ -2,6 +2,10 @@ package service
 
 import (
 	"crud_alumni/app/model"
+	"crud_alumni/apperror"
+	"crud_alumni/metrics"
+	"crud_alumni/middleware"
+	"crud_alumni/tracing"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -14,19 +18,38 @@ import (
 // @Produce json
 // @Param login body model.LoginRequest true "Login credentials"
 // @Success 200 {object} model.LoginResponse
-// @Failure 400 {object} map[string]interface{}
-// @Failure 401 {object} map[string]interface{}
+// @Failure 400 {object} model.Problem
+// @Failure 401 {object} model.Problem
 // @Router /login [post]
 func LoginHandler(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "AuthService.Login")
+	defer span.End()
+
 	var req model.LoginRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Request tidak valid"})
+		return apperror.BadRequest(apperror.CodeInvalidBody)
 	}
 
-	resp, err := Login(req)
+	resp, err := Login(ctx, req)
+	metrics.ObserveLogin(err == nil)
 	if err != nil {
-		return c.Status(401).JSON(fiber.Map{"error": err.Error()})
+		status := fiber.StatusInternalServerError
+		if appErr := apperror.As(err); appErr != nil {
+			status = appErr.Status
+		}
+		// belum ada token, jadi pelaku dicatat dari username/email yang dicoba
+		middleware.RecordAudit(c, model.AuditEntry{Action: model.AuditLoginFailed, Resource: model.ResourceUsers, ActorName: req.Username, Status: status})
+		return err
 	}
+	middleware.RecordAudit(c, model.AuditEntry{
+		Action:     model.AuditLogin,
+		Resource:   model.ResourceUsers,
+		ResourceID: resp.User.ID.Hex(),
+		ActorID:    resp.User.ID.Hex(),
+		ActorName:  resp.User.Username,
+		ActorRole:  resp.User.Role,
+		Status:     fiber.StatusOK,
+	})
 
 	return c.JSON(resp)
 }
@@ -47,8 +47,10 @@
 package service
 
 import (
+	"context"
 	"crud_alumni/app/model"
 	"crud_alumni/app/repository"
+	"crud_alumni/apperror"
 	"crud_alumni/utils"
 	"errors"
 	"fmt"
@@ -57,16 +59,19 @@ import (
 )
 
 // LoginMongo - versi login untuk MongoDB dengan debug hash
-func Login(req model.LoginRequest) (*model.LoginResponse, error) {
+func Login(ctx context.Context, req model.LoginRequest) (*model.LoginResponse, error) {
 	fmt.Println("=== DEBUG LOGIN ===")
 	fmt.Println("Input Username:", req.Username)
 	fmt.Println("Input Password:", req.Password)
 
 	// 1. Ambil user + hash password dari MongoDB
-	user, passwordHashDB, err := repository.FindUserByUsernameOrEmail(req.Username)
+	user, passwordHashDB, err := repository.FindUserByUsernameOrEmail(ctx, req.Username)
 	if err != nil {
 		fmt.Println("User tidak ditemukan atau DB error:", err)
-		return nil, errors.New("username atau password salah")
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, apperror.Unauthorized(apperror.CodeInvalidCredentials)
+		}
+		return nil, apperror.Internal(err)
 	}
 
 	fmt.Println("User dari DB:", user.Username, user.Email, user.Role)
@@ -76,7 +81,7 @@ func Login(req model.LoginRequest) (*model.LoginResponse, error) {
 	err = bcrypt.CompareHashAndPassword([]byte(passwordHashDB), []byte(req.Password))
 	if err != nil {
 		fmt.Println("Password tidak cocok ❌")
-		return nil, errors.New("username atau password salah")
+		return nil, apperror.Unauthorized(apperror.CodeInvalidCredentials)
 	}
 
 	fmt.Println("Password cocok ✅")
@@ -85,7 +90,7 @@ func Login(req model.LoginRequest) (*model.LoginResponse, error) {
 	token, err := utils.GenerateToken(*user)
 	if err != nil {
 		fmt.Println("Gagal generate token:", err)
-		return nil, errors.New("gagal generate token")
+		return nil, apperror.Internal(err)
 	}
 
 	// 4. Return response
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"crud_alumni/apperror"
+	"crud_alumni/config"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// settings - konfigurasi untuk handler package-level (alumni, pekerjaan,
+// export, audit). Diisi Configure saat setup route; unit test memakai default.
+var settings = config.Defaults()
+
+// Configure menerapkan cfg ke handler package-level
+func Configure(cfg *config.Config) {
+	settings = *cfg
+}
+
+type ConfigService struct {
+	Config *config.Config
+}
+
+func NewConfigService(cfg *config.Config) *ConfigService {
+	return &ConfigService{Config: cfg}
+}
+
+// GetConfig godoc
+// @Summary Lihat konfigurasi aktif
+// @Description Menampilkan konfigurasi yang sedang dipakai (secret disamarkan) untuk debugging (admin saja)
+// @Tags Admin
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} model.Problem
+// @Security BearerAuth
+// @Router /admin/config [get]
+func (s *ConfigService) GetConfig(c *fiber.Ctx) error {
+	view, err := s.Config.Redacted()
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	return c.JSON(view)
+}
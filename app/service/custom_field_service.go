@@ -0,0 +1,446 @@
+package service
+
+import (
+	"context"
+	"crud_alumni/app/model"
+	"crud_alumni/app/repository"
+	"crud_alumni/apperror"
+	"crud_alumni/i18n"
+	"crud_alumni/middleware"
+	"crud_alumni/tracing"
+	"crud_alumni/validation"
+	"errors"
+	"maps"
+	"math"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// customStringMaxLen - panjang maksimal nilai custom field bertipe string
+const customStringMaxLen = 500
+
+func isAdmin(c *fiber.Ctx) bool {
+	role, _ := c.Locals("role").(string)
+	return role == "admin"
+}
+
+// GetCustomFields godoc
+// @Summary Daftar custom field alumni
+// @Description Definisi atribut tambahan alumni (nama, tipe, pilihan enum, wajib/tidak). Non-admin hanya melihat field dengan visibility public.
+// @Tags Alumni
+// @Produce json
+// @Success 200 {object} map[string]interface{} "data berisi []model.CustomField"
+// @Failure 500 {object} model.Problem
+// @Security BearerAuth
+// @Router /custom-fields [get]
+func GetCustomFields(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "CustomFieldService.GetCustomFields")
+	defer span.End()
+
+	list, err := repository.ListCustomFields(ctx)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if !isAdmin(c) {
+		list = slices.DeleteFunc(list, func(f model.CustomField) bool { return f.Visibility != model.CustomFieldPublic })
+	}
+	return c.JSON(fiber.Map{"success": true, "data": list})
+}
+
+// CreateCustomField godoc
+// @Summary Tambah custom field alumni
+// @Description Admin menambah atribut alumni. name (huruf kecil, angka, _) menjadi key di alumni.custom dan tidak bisa diubah, begitu juga type. visibility default public.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param body body model.CustomField true "Definisi custom field"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} model.Problem
+// @Failure 409 {object} model.Problem
+// @Failure 422 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Security BearerAuth
+// @Router /admin/custom-fields [post]
+func CreateCustomField(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "CustomFieldService.CreateCustomField")
+	defer span.End()
+
+	var f model.CustomField
+	if err := c.BodyParser(&f); err != nil {
+		return apperror.BadRequest(apperror.CodeInvalidBody)
+	}
+	f.Name = strings.TrimSpace(f.Name)
+	if err := validateCustomField(&f); err != nil {
+		return err
+	}
+	if err := repository.CreateCustomField(ctx, &f); err != nil {
+		if errors.Is(err, repository.ErrDuplicate) {
+			return apperror.New(http.StatusConflict, apperror.CodeCustomFieldExists).WithArgs(f.Name)
+		}
+		return apperror.Internal(err)
+	}
+	setETag(c, f.Version)
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"success": true, "data": f})
+}
+
+// UpdateCustomField godoc
+// @Summary Ubah custom field alumni
+// @Description Admin mengubah label, options, required, dan visibility. name dan type boleh dikirim tetapi harus sama dengan yang tersimpan. Aturan baru (mis. required) berlaku saat alumni disimpan berikutnya.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path string true "ID custom field"
+// @Param If-Match header string false "ETag; 412 jika data sudah berubah"
+// @Param body body model.CustomField true "Definisi custom field"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} model.Problem
+// @Failure 404 {object} model.Problem
+// @Failure 412 {object} model.Problem
+// @Failure 422 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Security BearerAuth
+// @Router /admin/custom-fields/{id} [put]
+func UpdateCustomField(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "CustomFieldService.UpdateCustomField")
+	defer span.End()
+
+	var f model.CustomField
+	if err := c.BodyParser(&f); err != nil {
+		return apperror.BadRequest(apperror.CodeInvalidBody)
+	}
+	existing, err := repository.GetCustomField(ctx, c.Params("id"))
+	if err != nil {
+		return repoError(err, apperror.CodeCustomFieldNotFound)
+	}
+	if err := checkIfMatch(c, existing.Version); err != nil {
+		return err
+	}
+	if name := strings.TrimSpace(f.Name); name != "" && name != existing.Name {
+		return apperror.BadRequest(apperror.CodeInvalidParam).WithArgs("name")
+	}
+	if f.Type != "" && f.Type != existing.Type {
+		return apperror.BadRequest(apperror.CodeInvalidParam).WithArgs("type")
+	}
+	f.ID, f.Name, f.Type = existing.ID, existing.Name, existing.Type
+	f.CreatedAt, f.Version = existing.CreatedAt, existing.Version
+	if err := validateCustomField(&f); err != nil {
+		return err
+	}
+	if err := repository.UpdateCustomField(ctx, &f); err != nil {
+		return repoError(err, apperror.CodeCustomFieldNotFound)
+	}
+	setETag(c, f.Version)
+	return c.JSON(fiber.Map{"success": true, "data": f})
+}
+
+// DeleteCustomField godoc
+// @Summary Hapus custom field alumni
+// @Description Admin menghapus definisi custom field beserta nilainya di semua alumni, termasuk yang di trash (versi alumni tersebut naik dan perubahannya dicatat di riwayat alumni). Dicatat di audit log.
+// @Tags Admin
+// @Produce json
+// @Param id path string true "ID custom field"
+// @Param If-Match header string false "ETag; 412 jika data sudah berubah"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} model.Problem
+// @Failure 404 {object} model.Problem
+// @Failure 412 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Security BearerAuth
+// @Router /admin/custom-fields/{id} [delete]
+func DeleteCustomField(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "CustomFieldService.DeleteCustomField")
+	defer span.End()
+
+	existing, err := repository.GetCustomField(ctx, c.Params("id"))
+	if err != nil {
+		return repoError(err, apperror.CodeCustomFieldNotFound)
+	}
+	if err := checkIfMatch(c, existing.Version); err != nil {
+		return err
+	}
+	middleware.AuditDetail(c, "name", existing.Name)
+	if err := repository.DeleteCustomField(ctx, existing.ID, existing.Version); err != nil {
+		return repoError(err, apperror.CodeCustomFieldNotFound)
+	}
+	// nilai yang tertinggal akan ditolak sebagai field tidak dikenal saat
+	// alumni disimpan, jadi kegagalan di sini dilaporkan sebagai 500
+	cleared, err := clearCustomField(c, existing.Name)
+	middleware.AuditDetail(c, "alumni_cleared", strconv.FormatInt(cleared, 10))
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "custom_field.deleted", cleared)})
+}
+
+// customFieldBatch - jumlah alumni yang dibaca sekali jalan saat nilai custom
+// field dihapus
+const customFieldBatch = 500
+
+// clearCustomField menghapus nilai custom field name dari setiap alumni
+// (termasuk yang di trash) dengan cek versi dan mencatatnya di riwayat.
+// Alumni yang berubah di tengah proses dibaca ulang lalu dicoba lagi, karena
+// nilainya tidak boleh tertinggal. Mengembalikan jumlah alumni yang berubah.
+func clearCustomField(c *fiber.Ctx, name string) (int64, error) {
+	ctx := c.UserContext()
+	var cleared int64
+	var after primitive.ObjectID
+	for {
+		list, err := repository.AlumniWithCustom(ctx, name, after, customFieldBatch)
+		if err != nil {
+			return cleared, err
+		}
+		for _, a := range list {
+			updated := a
+			if err := repository.UnsetAlumniCustom(ctx, &updated, name); err != nil {
+				if errors.Is(err, repository.ErrVersionConflict) {
+					break // dibaca ulang mulai dari a
+				}
+				return cleared, err
+			}
+			after = a.ID
+			cleared++
+			recordHistory(c, model.HistoryEntry{Resource: model.ResourceAlumni, RecordID: a.ID, Version: updated.Version, Action: model.HistoryUpdate}, a, updated)
+		}
+		if len(list) < customFieldBatch && (len(list) == 0 || after == list[len(list)-1].ID) {
+			return cleared, nil
+		}
+	}
+}
+
+// validateCustomField merapikan dan memvalidasi definisi f. Options hanya
+// dipakai type enum (wajib, tanpa duplikat); untuk type lain dikosongkan.
+func validateCustomField(f *model.CustomField) error {
+	f.Label = strings.TrimSpace(f.Label)
+	if f.Visibility == "" {
+		f.Visibility = model.CustomFieldPublic
+	}
+	var options []string
+	for _, o := range f.Options {
+		if o = strings.TrimSpace(o); o != "" && !slices.Contains(options, o) {
+			options = append(options, o)
+		}
+	}
+	f.Options = nil
+	if f.Type == model.CustomFieldEnum {
+		f.Options = options
+	}
+
+	fields := validation.Fields(f)
+	if f.Type == model.CustomFieldEnum && len(f.Options) == 0 {
+		fields = append(fields, apperror.FieldError{Field: "options", Code: "required"})
+	}
+	if len(fields) > 0 {
+		return apperror.Validation(fields...)
+	}
+	return nil
+}
+
+// validateCustom memeriksa nilai custom field values terhadap definisi defs.
+// Hasilnya nilai yang sudah dirapikan (teks tanpa spasi di tepi, angka
+// float64, nilai kosong dibuang; nil jika tidak ada isinya) dan pelanggaran
+// dengan nama field "custom.<name>". Key yang tidak ada definisinya ditolak.
+func validateCustom(values map[string]any, defs []model.CustomField) (map[string]any, []apperror.FieldError) {
+	var out []apperror.FieldError
+	known := make(map[string]bool, len(defs))
+	for _, d := range defs {
+		known[d.Name] = true
+	}
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		if !known[name] {
+			out = append(out, apperror.FieldError{Field: "custom." + name, Code: "unknown"})
+		}
+	}
+
+	clean := map[string]any{}
+	for _, d := range defs {
+		v, fe := customValue(d, values[d.Name])
+		switch {
+		case fe != nil:
+			fe.Field = "custom." + d.Name
+			out = append(out, *fe)
+		case v == nil && d.Required:
+			out = append(out, apperror.FieldError{Field: "custom." + d.Name, Code: "required"})
+		case v != nil:
+			clean[d.Name] = v
+		}
+	}
+	if len(clean) == 0 {
+		clean = nil
+	}
+	return clean, out
+}
+
+// customValue - v yang dirapikan sesuai tipe d, atau nil jika kosong
+func customValue(d model.CustomField, v any) (any, *apperror.FieldError) {
+	if s, ok := v.(string); ok {
+		if s = strings.TrimSpace(s); s == "" {
+			return nil, nil
+		}
+		v = s
+	}
+	if v == nil {
+		return nil, nil
+	}
+
+	if d.Type == model.CustomFieldNumber {
+		// JSON menghasilkan float64; dokumen lama dari MongoDB bisa int32/int64
+		switch n := v.(type) {
+		case float64:
+			if !math.IsNaN(n) && !math.IsInf(n, 0) {
+				return n, nil
+			}
+		case int32:
+			return float64(n), nil
+		case int64:
+			return float64(n), nil
+		}
+		return nil, &apperror.FieldError{Code: "number"}
+	}
+
+	s, ok := v.(string)
+	if !ok {
+		return nil, &apperror.FieldError{Code: "string"}
+	}
+	switch d.Type {
+	case model.CustomFieldString:
+		if utf8.RuneCountInString(s) > customStringMaxLen {
+			return nil, &apperror.FieldError{Code: "max_len", Args: []any{customStringMaxLen}}
+		}
+	case model.CustomFieldDate:
+		if _, err := time.Parse("2006-01-02", s); err != nil {
+			return nil, &apperror.FieldError{Code: "date"}
+		}
+	case model.CustomFieldEnum:
+		if !slices.Contains(d.Options, s) {
+			return nil, &apperror.FieldError{Code: "oneof", Args: []any{strings.Join(d.Options, ", ")}}
+		}
+	case model.CustomFieldURL:
+		u, err := url.Parse(s)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, &apperror.FieldError{Code: "url"}
+		}
+	}
+	return s, nil
+}
+
+// customCell - nilai custom field sebagai teks (export)
+func customCell(v any) string {
+	switch n := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return n
+	case float64:
+		return strconv.FormatFloat(n, 'f', -1, 64)
+	case int32:
+		return strconv.Itoa(int(n))
+	case int64:
+		return strconv.FormatInt(n, 10)
+	}
+	return ""
+}
+
+// parseCustomFilter membaca ?custom.<name>=. Number dan date menerima satu
+// nilai atau rentang "min..max" (salah satu sisi boleh kosong); tipe lain
+// menerima beberapa nilai dipisah koma. Definisi hanya dibaca dari database
+// jika ada parameter custom. Non-admin tidak bisa memfilter field admin.
+func parseCustomFilter(ctx context.Context, values url.Values, admin bool) ([]model.CustomFilter, error) {
+	raw := map[string]string{}
+	for key := range values {
+		if name, ok := strings.CutPrefix(key, "custom."); ok {
+			raw[name] = values.Get(key)
+		}
+	}
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	defs, err := repository.ListCustomFields(ctx)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	var filters []model.CustomFilter
+	for _, name := range slices.Sorted(maps.Keys(raw)) {
+		i := slices.IndexFunc(defs, func(d model.CustomField) bool { return d.Name == name })
+		if i < 0 || (!admin && defs[i].Visibility != model.CustomFieldPublic) {
+			return nil, apperror.BadRequest(apperror.CodeInvalidParam).WithArgs("custom." + name)
+		}
+		f, ok := customFilter(defs[i], raw[name])
+		if !ok {
+			return nil, apperror.BadRequest(apperror.CodeInvalidParam).WithArgs("custom." + name)
+		}
+		filters = append(filters, f)
+	}
+	return filters, nil
+}
+
+func customFilter(d model.CustomField, raw string) (model.CustomFilter, bool) {
+	f := model.CustomFilter{Name: d.Name, Type: d.Type}
+	if d.Type != model.CustomFieldNumber && d.Type != model.CustomFieldDate {
+		f.Values = splitList(raw)
+		return f, len(f.Values) > 0
+	}
+
+	lo, hi, isRange := strings.Cut(raw, "..")
+	if !isRange {
+		hi = lo
+	}
+	lo, hi = strings.TrimSpace(lo), strings.TrimSpace(hi)
+	valid := func(s string) bool {
+		if s == "" {
+			return true
+		}
+		if d.Type == model.CustomFieldNumber {
+			_, err := strconv.ParseFloat(s, 64)
+			return err == nil
+		}
+		_, err := time.Parse("2006-01-02", s)
+		return err == nil
+	}
+	f.Min, f.Max = lo, hi
+	return f, (lo != "" || hi != "") && valid(lo) && valid(hi)
+}
+
+// hideCustomFields menghapus nilai custom field ber-visibility admin dari
+// setiap alumni jika c bukan admin. Definisi hanya dibaca jika ada nilai
+// custom yang perlu diperiksa.
+func hideCustomFields(c *fiber.Ctx, list ...*model.Alumni) error {
+	if isAdmin(c) || !slices.ContainsFunc(list, func(a *model.Alumni) bool { return len(a.Custom) > 0 }) {
+		return nil
+	}
+	defs, err := repository.ListCustomFields(c.UserContext())
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	for _, d := range defs {
+		if d.Visibility == model.CustomFieldPublic {
+			continue
+		}
+		for _, a := range list {
+			delete(a.Custom, d.Name)
+		}
+	}
+	for _, a := range list {
+		if len(a.Custom) == 0 {
+			a.Custom = nil
+		}
+	}
+	return nil
+}
+
+// alumniOf - pointer ke Alumni di setiap item list (untuk hideCustomFields)
+func alumniOf(list []model.AlumniDetail) []*model.Alumni {
+	out := make([]*model.Alumni, len(list))
+	for i := range list {
+		out[i] = &list[i].Alumni
+	}
+	return out
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"crud_alumni/app/model"
+	"crud_alumni/app/repository"
+	"crud_alumni/middleware"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var testCustomFields = []model.CustomField{
+	{Name: "beasiswa", Type: model.CustomFieldEnum, Options: []string{"KIP", "LPDP"}, Visibility: model.CustomFieldPublic},
+	{Name: "catatan", Type: model.CustomFieldString, Visibility: model.CustomFieldAdmin},
+	{Name: "ipk", Type: model.CustomFieldNumber, Required: true, Visibility: model.CustomFieldPublic},
+	{Name: "linkedin", Type: model.CustomFieldURL, Visibility: model.CustomFieldPublic},
+	{Name: "wisuda", Type: model.CustomFieldDate, Visibility: model.CustomFieldPublic},
+}
+
+func useTestCustomFields(t *testing.T) {
+	repository.ListCustomFieldsFunc = func(ctx context.Context) ([]model.CustomField, error) {
+		return testCustomFields, nil
+	}
+	t.Cleanup(func() { repository.ListCustomFieldsFunc = nil })
+}
+
+func TestValidateCustom(t *testing.T) {
+	clean, fields := validateCustom(map[string]any{
+		"ipk":      3.5,
+		"beasiswa": " KIP ",
+		"linkedin": "",
+		"wisuda":   "2024-09-14",
+	}, testCustomFields)
+	if len(fields) != 0 {
+		t.Fatalf("expected valid, got %+v", fields)
+	}
+	if clean["beasiswa"] != "KIP" || clean["ipk"] != 3.5 {
+		t.Errorf("expected trimmed values, got %v", clean)
+	}
+	if _, ok := clean["linkedin"]; ok {
+		t.Errorf("expected empty value dropped, got %v", clean)
+	}
+
+	_, fields = validateCustom(map[string]any{
+		"beasiswa": "Mandiri",
+		"linkedin": "ftp://example.com",
+		"wisuda":   "14-09-2024",
+		"catatan":  42.0,
+		"hobi":     "catur",
+	}, testCustomFields)
+	codes := map[string]string{}
+	for _, f := range fields {
+		codes[f.Field] = f.Code
+	}
+	want := map[string]string{
+		"custom.beasiswa": "oneof", "custom.linkedin": "url", "custom.wisuda": "date",
+		"custom.catatan": "string", "custom.hobi": "unknown", "custom.ipk": "required",
+	}
+	for field, code := range want {
+		if codes[field] != code {
+			t.Errorf("%s: expected %q, got %q (%+v)", field, code, codes[field], fields)
+		}
+	}
+}
+
+func TestGetAlumniPagination_InvalidCustomFilter(t *testing.T) {
+	useTestCustomFields(t)
+	app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("role", "user")
+		return c.Next()
+	})
+	app.Get("/alumni/pag", GetAlumniPagination)
+
+	// field tidak dikenal, field khusus admin, dan nilai yang tidak sesuai tipe
+	for _, query := range []string{"custom.hobi=catur", "custom.catatan=x", "custom.ipk=tinggi", "custom.wisuda=..", "custom.beasiswa=,"} {
+		req := httptest.NewRequest(http.MethodGet, "/alumni/pag?"+query, nil)
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", query, resp.StatusCode)
+		}
+	}
+}
+
+func TestParseCustomFilter(t *testing.T) {
+	useTestCustomFields(t)
+	values, _ := url.ParseQuery("custom.wisuda=2024-01-01..&custom.ipk=3.5&custom.catatan=a,b&jurusan=x")
+	got, err := parseCustomFilter(context.Background(), values, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	raw, _ := json.Marshal(got)
+	want := `[{"name":"catatan","type":"string","values":["a","b"]},` +
+		`{"name":"ipk","type":"number","min":"3.5","max":"3.5"},` +
+		`{"name":"wisuda","type":"date","min":"2024-01-01"}]`
+	if string(raw) != want {
+		t.Errorf("got %s\nwant %s", raw, want)
+	}
+	// field khusus admin tidak bisa dipakai non-admin
+	if _, err := parseCustomFilter(context.Background(), values, false); err == nil {
+		t.Error("expected error for admin-only field")
+	}
+}
+
+func TestHideCustomFields(t *testing.T) {
+	useTestCustomFields(t)
+	app := fiber.New()
+	var list []model.Alumni
+	app.Get("/:role", func(c *fiber.Ctx) error {
+		c.Locals("role", c.Params("role"))
+		list = []model.Alumni{
+			{Custom: map[string]any{"catatan": "rahasia", "ipk": 3.1}},
+			{Custom: map[string]any{"catatan": "rahasia"}},
+		}
+		return hideCustomFields(c, &list[0], &list[1])
+	})
+
+	for _, role := range []string{"admin", "user"} {
+		if _, err := app.Test(httptest.NewRequest(http.MethodGet, "/"+role, nil), -1); err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		_, visible := list[0].Custom["catatan"]
+		if visible != (role == "admin") {
+			t.Errorf("%s: unexpected catatan visibility %v", role, list[0].Custom)
+		}
+	}
+	if list[0].Custom["ipk"] != 3.1 || list[1].Custom != nil {
+		t.Errorf("expected public values kept and empty map removed, got %v / %v", list[0].Custom, list[1].Custom)
+	}
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"crud_alumni/app/repository"
+	"crud_alumni/apperror"
+	"errors"
+	"net/http"
+)
+
+// repoError memetakan sentinel error repository ke apperror: ErrNotFound
+// menjadi 404 dengan notFoundCode, ErrInvalidID dan ErrInvalidCursor menjadi
+// 400, ErrVersionConflict menjadi 412, sisanya 500.
+func repoError(err error, notFoundCode string) error {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		return apperror.NotFound(notFoundCode)
+	case errors.Is(err, repository.ErrInvalidID):
+		return apperror.BadRequest(apperror.CodeInvalidID)
+	case errors.Is(err, repository.ErrInvalidCursor):
+		return apperror.BadRequest(apperror.CodeInvalidCursor)
+	case errors.Is(err, repository.ErrVersionConflict):
+		return apperror.New(http.StatusPreconditionFailed, apperror.CodePreconditionFailed)
+	}
+	return apperror.Internal(err)
+}
+
+// alumniSaveError - seperti repoError untuk penyimpanan alumni; NIM yang
+// sudah dipakai alumni lain (ErrDuplicate) menjadi 409
+func alumniSaveError(err error, nim string) error {
+	if errors.Is(err, repository.ErrDuplicate) {
+		return apperror.New(http.StatusConflict, apperror.CodeAlumniNIMExists).WithArgs(nim)
+	}
+	return repoError(err, apperror.CodeAlumniNotFound)
+}
@@ -0,0 +1,271 @@
+package service
+
+import (
+	"bufio"
+	"crud_alumni/app/model"
+	"crud_alumni/app/repository"
+	"crud_alumni/apperror"
+	"crud_alumni/config"
+	"crud_alumni/i18n"
+	"crud_alumni/middleware"
+	"crud_alumni/tabular"
+	"crud_alumni/tracing"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// alumniRows - sumber baris export (repository.AlumniIterator, atau slice di test)
+type alumniRows interface {
+	Next(a *model.Alumni) (bool, error)
+}
+
+// ExportAlumni godoc
+// @Summary Export alumni ke CSV/XLSX/PDF
+// @Description Mengunduh daftar alumni dengan pencarian, filter, dan urutan yang sama seperti /alumni/pag (tanpa pagination). Data dikirim bertahap (streaming) dari database. Kolom bisa dipilih lewat `columns`; header kolom sama dengan nama field sehingga file CSV/XLSX bisa di-import ulang. PDF memuat judul dan filter yang dipakai, dan dibatasi EXPORT_PDF_MAX_ROWS baris.
+// @Tags Alumni
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce application/pdf
+// @Param format query string false "csv (default), xlsx, atau pdf"
+// @Param columns query string false "Kolom dipisah koma: nim,nama,jurusan,angkatan,tahun_lulus,email,no_telepon,alamat,tags,custom.<name> (default semua termasuk seluruh custom field)"
+// @Param sortBy query string false "Kolom pengurutan (nama/nim/angkatan/tahun_lulus/email/relevance)"
+// @Param order query string false "Arah pengurutan (asc/desc)"
+// @Param search query string false "Kata kunci pencarian"
+// @Param search_mode query string false "contains (default) atau text"
+// @Param jurusan query []string false "Jurusan (boleh diulang atau dipisah koma)" collectionFormat(multi)
+// @Param angkatan_min query int false "Angkatan minimal"
+// @Param angkatan_max query int false "Angkatan maksimal"
+// @Param tahun_lulus_min query int false "Tahun lulus minimal"
+// @Param tahun_lulus_max query int false "Tahun lulus maksimal"
+// @Param has_email query bool false "true = punya email, false = email kosong"
+// @Param created_from query string false "Dibuat sejak (YYYY-MM-DD)"
+// @Param created_to query string false "Dibuat sampai (YYYY-MM-DD, inklusif)"
+// @Param updated_from query string false "Diubah sejak (YYYY-MM-DD)"
+// @Param updated_to query string false "Diubah sampai (YYYY-MM-DD, inklusif)"
+// @Param has_pekerjaan query bool false "Punya data pekerjaan"
+// @Param currently_employed query bool false "Sedang bekerja (pekerjaan tanpa tanggal selesai atau belum selesai)"
+// @Param tag query []string false "Hanya alumni yang punya semua tag ini (boleh diulang atau dipisah koma)" collectionFormat(multi)
+// @Param custom.{name} query string false "Filter custom field: nilai dipisah koma, atau min..max untuk number/date"
+// @Param segment query string false "ID segment; parameternya dipakai untuk filter yang tidak dikirim"
+// @Success 200 {file} file
+// @Failure 400 {object} model.Problem
+// @Failure 403 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Security BearerAuth
+// @Router /alumni/export [get]
+func ExportAlumni(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "AlumniService.ExportAlumni")
+	defer span.End()
+
+	format := strings.ToLower(c.Query("format", "csv"))
+	if format != "csv" && format != "xlsx" && format != "pdf" {
+		return apperror.BadRequest(apperror.CodeInvalidParam).WithArgs("format")
+	}
+	// definisi custom field hanya dibutuhkan untuk kolom default atau custom.<name>
+	var defs []model.CustomField
+	if raw := c.Query("columns"); strings.TrimSpace(raw) == "" || strings.Contains(raw, "custom.") {
+		list, err := repository.ListCustomFields(ctx)
+		if err != nil {
+			return apperror.Internal(err)
+		}
+		defs = list
+	}
+	columns, err := exportColumns(c.Query("columns"), defs)
+	if err != nil {
+		return err
+	}
+	q, err := alumniListQuery(c)
+	if err != nil {
+		return err
+	}
+	cfg := settings.Export
+
+	span.SetAttributes(
+		attribute.String("alumni.export.format", format),
+		attribute.String("alumni.search", q.Search.Query),
+	)
+
+	var header tabular.PDFHeader
+	if format == "pdf" {
+		total, err := repository.CountAlumni(ctx, q.Search, q.Filter)
+		if err != nil {
+			return apperror.Internal(err)
+		}
+		if total > cfg.PDFMaxRows {
+			return apperror.BadRequest(apperror.CodeExportTooManyRows).WithArgs(total, cfg.PDFMaxRows)
+		}
+		header = exportPDFHeader(i18n.Lang(c), q, total, time.Now())
+	}
+
+	// query dibuka sebelum response dimulai supaya error koneksi masih bisa
+	// dijawab 500; setelah streaming dimulai status tidak bisa diubah lagi
+	it, err := repository.IterateAlumni(ctx, q.Search, q.Filter, q.SortBy, q.Order, cfg.Timeout)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+
+	middleware.AuditDetail(c, "format", format)
+	middleware.AuditDetail(c, "columns", strings.Join(columns, ","))
+	if q.Search.Query != "" {
+		middleware.AuditDetail(c, "search", q.Search.Query)
+	}
+	c.Attachment("alumni-" + time.Now().Format("20060102") + "." + format)
+	c.Set(fiber.HeaderContentType, tabular.ContentType(format))
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer it.Close()
+		if err := writeAlumniTable(w, format, columns, header, it); err != nil {
+			// klien menerima file terpotong; hanya bisa dicatat di log
+			config.Logger.Error().Err(err).Str("format", format).Msg("export alumni gagal di tengah streaming")
+		}
+	})
+	return nil
+}
+
+// writeAlumniTable menulis header kolom lalu setiap alumni dari rows
+func writeAlumniTable(w *bufio.Writer, format string, columns []string, header tabular.PDFHeader, rows alumniRows) error {
+	tw, err := tabular.NewWriter(w, format, header)
+	if err != nil {
+		return err
+	}
+	if err := tw.Write(columns); err != nil {
+		return err
+	}
+
+	row := make([]string, len(columns))
+	for {
+		var a model.Alumni
+		ok, err := rows.Next(&a)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		for i, col := range columns {
+			row[i] = alumniCell(a, col)
+		}
+		if err := tw.Write(row); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// exportColumns - kolom yang diminta (dipisah koma), default semua kolom
+// alumni, tags, lalu custom.<name> untuk setiap definisi di defs
+func exportColumns(raw string, defs []model.CustomField) ([]string, error) {
+	known := append(slices.Clone(alumniColumns), "tags")
+	for _, d := range defs {
+		known = append(known, "custom."+d.Name)
+	}
+	if strings.TrimSpace(raw) == "" {
+		return known, nil
+	}
+	var columns []string
+	for _, col := range strings.Split(raw, ",") {
+		col = strings.ToLower(strings.TrimSpace(col))
+		if !slices.Contains(known, col) {
+			return nil, apperror.BadRequest(apperror.CodeInvalidParam).WithArgs("columns")
+		}
+		columns = append(columns, col)
+	}
+	return columns, nil
+}
+
+func alumniCell(a model.Alumni, column string) string {
+	number := func(n int) string {
+		if n == 0 {
+			return ""
+		}
+		return strconv.Itoa(n)
+	}
+	switch column {
+	case "nim":
+		return a.NIM
+	case "nama":
+		return a.Nama
+	case "jurusan":
+		return a.Jurusan
+	case "angkatan":
+		return number(a.Angkatan)
+	case "tahun_lulus":
+		return number(a.TahunLulus)
+	case "email":
+		return a.Email
+	case "no_telepon":
+		return a.NoTelepon
+	case "alamat":
+		return a.Alamat
+	case "tags":
+		return strings.Join(a.Tags, ", ")
+	}
+	if name, ok := strings.CutPrefix(column, "custom."); ok {
+		return customCell(a.Custom[name])
+	}
+	return ""
+}
+
+// exportPDFHeader - judul dan keterangan filter di atas tabel PDF
+func exportPDFHeader(lang string, q alumniQuery, total int, now time.Time) tabular.PDFHeader {
+	search := q.Search.Query
+	if search == "" {
+		search = "-"
+	}
+	return tabular.PDFHeader{
+		Title: i18n.Message(lang, "export.title"),
+		Lines: []string{
+			i18n.Message(lang, "export.generated_at", now.Format("2006-01-02 15:04")),
+			i18n.Message(lang, "export.search", search),
+			i18n.Message(lang, "export.filter", describeAlumniFilter(q.Filter)),
+			i18n.Message(lang, "export.sort", q.SortBy, q.Order),
+			i18n.Message(lang, "export.total", total),
+		},
+	}
+}
+
+// describeAlumniFilter - filter dalam bentuk query parameter, mis.
+// "angkatan_min=2018, jurusan=Manajemen|Akuntansi", atau "-" jika kosong
+func describeAlumniFilter(f model.AlumniFilter) string {
+	// nama field JSON AlumniFilter sama dengan nama query parameter
+	raw, _ := json.Marshal(f)
+	var fields map[string]any
+	json.Unmarshal(raw, &fields)
+	delete(fields, "custom")
+
+	parts := make([]string, 0, len(fields)+len(f.Custom))
+	for name, v := range fields {
+		if list, ok := v.([]any); ok {
+			values := make([]string, len(list))
+			for i, item := range list {
+				values[i] = fmt.Sprint(item)
+			}
+			v = strings.Join(values, "|")
+		}
+		parts = append(parts, fmt.Sprintf("%s=%v", name, v))
+	}
+	for _, cf := range f.Custom {
+		v := strings.Join(cf.Values, "|")
+		if cf.Type == model.CustomFieldNumber || cf.Type == model.CustomFieldDate {
+			v = cf.Min + ".." + cf.Max
+			if cf.Min == cf.Max {
+				v = cf.Min
+			}
+		}
+		parts = append(parts, "custom."+cf.Name+"="+v)
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	slices.Sort(parts)
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"crud_alumni/app/model"
+	"crud_alumni/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type sliceRows []model.Alumni
+
+func (s *sliceRows) Next(a *model.Alumni) (bool, error) {
+	if len(*s) == 0 {
+		return false, nil
+	}
+	*a, *s = (*s)[0], (*s)[1:]
+	return true, nil
+}
+
+func TestWriteAlumniTable_CSVSelectedColumns(t *testing.T) {
+	rows := sliceRows{
+		{NIM: "NIM00001", Nama: "Budi, S.Kom", Angkatan: 2018},
+		{NIM: "NIM00002", Nama: "Sari"},
+	}
+	columns, err := exportColumns(" nim, Nama ,angkatan", nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	if err := writeAlumniTable(w, "csv", columns, exportPDFHeader("id", alumniQuery{}, 0, time.Now()), &rows); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := "\xef\xbb\xbfnim,nama,angkatan\nNIM00001,\"Budi, S.Kom\",2018\nNIM00002,Sari,\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestExportAlumni_InvalidParams(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+	app.Get("/alumni/export", ExportAlumni)
+
+	// parameter ditolak sebelum menyentuh database
+	for _, query := range []string{"format=docx", "columns=nim,password"} {
+		req := httptest.NewRequest(http.MethodGet, "/alumni/export?"+query, nil)
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", query, resp.StatusCode)
+		}
+	}
+}
+
+func TestExportPDFHeader_DescribesFilter(t *testing.T) {
+	now := time.Date(2026, 10, 19, 9, 30, 0, 0, time.UTC)
+	employed := true
+	q := alumniQuery{
+		Search: model.AlumniSearch{Query: "informatika"},
+		Filter: model.AlumniFilter{Jurusan: []string{"Manajemen", "Akuntansi"}, AngkatanMin: 2018, CurrentlyEmployed: &employed},
+		SortBy: "angkatan",
+		Order:  "desc",
+	}
+	header := exportPDFHeader("en", q, 42, now)
+
+	joined := strings.Join(header.Lines, "\n")
+	for _, want := range []string{
+		"2026-10-19 09:30", "Search: informatika", "angkatan (desc)", "Total rows: 42",
+		"Filters: angkatan_min=2018, currently_employed=true, jurusan=Manajemen|Akuntansi",
+	} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("expected header to contain %q, got:\n%s", want, joined)
+		}
+	}
+}
+
+func TestDescribeAlumniFilter_Custom(t *testing.T) {
+	got := describeAlumniFilter(model.AlumniFilter{AngkatanMin: 2018, Custom: []model.CustomFilter{
+		{Name: "ipk", Type: model.CustomFieldNumber, Min: "3", Max: "4"},
+		{Name: "beasiswa", Type: model.CustomFieldEnum, Values: []string{"KIP", "LPDP"}},
+	}})
+	if want := "angkatan_min=2018, custom.beasiswa=KIP|LPDP, custom.ipk=3..4"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -3,8 +3,14 @@ package service
 import (
 	"crud_alumni/app/model"
 	"crud_alumni/app/repository"
+	"crud_alumni/apperror"
+	"crud_alumni/config"
+	"crud_alumni/i18n"
+	"crud_alumni/metrics"
+	"crud_alumni/tracing"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -12,11 +18,12 @@ import (
 )
 
 type FileService struct {
-	Repo repository.FileRepo
+	Repo   repository.FileRepo
+	Upload config.UploadConfig
 }
 
-func NewFileService(repo repository.FileRepo) *FileService {
-	return &FileService{Repo: repo}
+func NewFileService(repo repository.FileRepo, upload config.UploadConfig) *FileService {
+	return &FileService{Repo: repo, Upload: upload}
 }
 
 
@@ -30,9 +37,12 @@ func NewFileService(repo repository.FileRepo) *FileService {
 // @Param file formData file true "File to upload"
 // @Param category path string true "Jenis file (foto/sertifikat)"
 // @Success 201 {object} model.File
-// @Failure 400 {object} map[string]interface{}
+// @Failure 400 {object} model.Problem
 // @Router /file/{category} [post]
 func (s *FileService) UploadFile(c *fiber.Ctx, category string) error {
+	ctx, span := tracing.Start(c.UserContext(), "FileService.UploadFile")
+	defer span.End()
+
 	// === Ambil user & role dari token JWT ===
 	userID := c.Locals("user_id").(string)
 	role := c.Locals("role").(string)
@@ -42,7 +52,7 @@ func (s *FileService) UploadFile(c *fiber.Ctx, category string) error {
 
 	// === Validasi role: user tidak boleh upload untuk orang lain ===
 	if role == "user" && targetUserID != "" && targetUserID != userID {
-		return c.Status(403).JSON(fiber.Map{"error": "Unauthorized: user tidak boleh upload file untuk orang lain"})
+		return apperror.Forbidden(apperror.CodeForbidden).WithKey("upload_for_other_forbidden")
 	}
 
 	// === Tentukan pemilik file ===
@@ -58,30 +68,24 @@ func (s *FileService) UploadFile(c *fiber.Ctx, category string) error {
 	// === Ambil file dari form-data ===
 	fileHeader, err := c.FormFile("file")
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "No file uploaded"})
+		return apperror.BadRequest(apperror.CodeUploadMissingFile)
 	}
 
-	// === Validasi tipe file & ukuran ===
+	// === Validasi tipe file & ukuran (aturan dari konfigurasi upload) ===
 	contentType := fileHeader.Header.Get("Content-Type")
-	var uploadPath string
-
-	if category == "foto" {
-		if contentType != "image/jpeg" && contentType != "image/png" {
-			return c.Status(400).JSON(fiber.Map{"error": "Only jpeg/jpg/png allowed"})
-		}
-		if fileHeader.Size > 1*1024*1024 {
-			return c.Status(400).JSON(fiber.Map{"error": "Max 1MB allowed"})
-		}
-		uploadPath = "./uploads/foto"
-	} else if category == "sertifikat" {
-		if contentType != "application/pdf" {
-			return c.Status(400).JSON(fiber.Map{"error": "Only pdf allowed"})
-		}
-		if fileHeader.Size > 2*1024*1024 {
-			return c.Status(400).JSON(fiber.Map{"error": "Max 2MB allowed"})
-		}
-		uploadPath = "./uploads/sertifikat"
+	rules, ok := s.Upload.Category(category)
+	if !ok {
+		return apperror.BadRequest(apperror.CodeUploadUnknownCategory)
+	}
+	if !rules.Allows(contentType) {
+		return apperror.New(fiber.StatusUnsupportedMediaType, apperror.CodeUploadTypeNotAllowed).
+			WithArgs(strings.Join(rules.AllowedTypes, ", "))
 	}
+	if fileHeader.Size > int64(rules.MaxSize) {
+		return apperror.New(fiber.StatusRequestEntityTooLarge, apperror.CodeUploadTooLarge).
+			WithArgs(rules.MaxSize.String())
+	}
+	uploadPath := rules.Dir
 
 	// === Simpan file ke folder ===
 	ext := filepath.Ext(fileHeader.Filename)
@@ -90,7 +94,7 @@ func (s *FileService) UploadFile(c *fiber.Ctx, category string) error {
 
 	os.MkdirAll(uploadPath, os.ModePerm)
 	if err := c.SaveFile(fileHeader, filePath); err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to save file"})
+		return apperror.Internal(err)
 	}
 
 	// === Simpan metadata ke database ===
@@ -104,49 +108,79 @@ func (s *FileService) UploadFile(c *fiber.Ctx, category string) error {
 		Category:     category,
 	}
 
-	if err := s.Repo.Create(file); err != nil {
+	if err := s.Repo.Create(ctx, file); err != nil {
 		os.Remove(filePath)
-		return c.Status(500).JSON(fiber.Map{"error": "Failed to save metadata"})
+		return apperror.Internal(err)
 	}
+	metrics.ObserveUpload(category, file.FileSize)
 
 	return c.Status(201).JSON(fiber.Map{
-		"message": "File uploaded successfully",
+		"message": i18n.T(c, "file.uploaded"),
 		"data":    file,
 	})
 }
 
 // GetAllFiles godoc
 // @Summary Dapatkan semua file
-// @Description Admin dapat melihat semua file, user hanya file miliknya sendiri
+// @Description Admin dapat melihat semua file, user hanya file miliknya sendiri. Dengan `cursor` (kosong untuk halaman pertama) hasilnya dipaginasi per halaman keyset dan berbentuk model.FileCursorResponse.
 // @Tags File
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param cursor query string false "Cursor meta.next/meta.prev; kosong untuk halaman pertama"
+// @Param limit query int false "Mode cursor: jumlah data per halaman (default 20, maksimal 100)"
+// @Param sortBy query string false "Mode cursor: uploaded_at (default), original_name, file_size"
+// @Param order query string false "Mode cursor: asc/desc (default desc)"
+// @Param count query bool false "Mode cursor: hitung total data"
+// @Param fields query string false "Field yang dikembalikan, dipisah koma (mis. original_name,file_path); id selalu ikut"
 // @Success 200 {array} model.File
-// @Failure 500 {object} map[string]interface{}
+// @Failure 400 {object} model.Problem
+// @Failure 500 {object} model.Problem
 // @Router /file [get]
 // === GET SEMUA FILE (admin bisa semua, user hanya miliknya sendiri)
 func (s *FileService) GetAllFiles(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "FileService.GetAllFiles")
+	defer span.End()
+
 	role := c.Locals("role").(string)
 	userID := c.Locals("user_id").(string)
+	read, err := readOptions(c, fileFields)
+	if err != nil {
+		return err
+	}
+
+	if cursorRequested(c) {
+		req, err := pageRequest(c, []string{"uploaded_at", "original_name", "file_size"}, "uploaded_at", "desc")
+		if err != nil {
+			return err
+		}
+		owner := userID
+		if role == "admin" {
+			owner = ""
+		}
+		page, err := s.Repo.Page(ctx, owner, req)
+		if err != nil {
+			return repoError(err, apperror.CodeFileNotFound)
+		}
+		return sendSparse(c, model.FileCursorResponse{Data: page.Items, Meta: cursorMeta(req, page)}, read.Keys)
+	}
 
 	var files []model.File
-	var err error
 
 	if role == "admin" {
-		files, err = s.Repo.GetAll() // ambil semua file
+		files, err = s.Repo.GetAll(ctx) // ambil semua file
 	} else {
-		files, err = s.Repo.GetByUserID(userID) // ambil hanya miliknya sendiri
+		files, err = s.Repo.GetByUserID(ctx, userID) // ambil hanya miliknya sendiri
 	}
 
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil data file"})
+		return apperror.Internal(err)
 	}
 
-	return c.JSON(fiber.Map{
+	return sendSparse(c, fiber.Map{
 		"count": len(files),
 		"data":  files,
-	})
+	}, read.Keys)
 }
 
 // GetFileByID godoc
@@ -157,26 +191,98 @@ func (s *FileService) GetAllFiles(c *fiber.Ctx) error {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "File ID"
+// @Param fields query string false "Field yang dikembalikan, dipisah koma (mis. original_name,file_path); id selalu ikut"
 // @Success 200 {object} model.File
-// @Failure 404 {object} map[string]interface{}
+// @Failure 400 {object} model.Problem
+// @Failure 404 {object} model.Problem
 // @Router /file/{id} [get]
 // === GET FILE BY ID (admin bisa semua, user hanya miliknya sendiri)
 func (s *FileService) GetFileByID(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "FileService.GetFileByID")
+	defer span.End()
+
 	role := c.Locals("role").(string)
 	userID := c.Locals("user_id").(string)
 	fileID := c.Params("id")
+	read, err := readOptions(c, fileFields)
+	if err != nil {
+		return err
+	}
 
-	file, err := s.Repo.GetByID(fileID)
+	file, err := s.Repo.GetByID(ctx, fileID)
 	if err != nil {
-		return c.Status(404).JSON(fiber.Map{"error": "File tidak ditemukan"})
+		return repoError(err, apperror.CodeFileNotFound)
 	}
 
 	// Jika user biasa, pastikan file miliknya
 	if role != "admin" && file.UserID.Hex() != userID {
-		return c.Status(403).JSON(fiber.Map{"error": "Unauthorized"})
+		return apperror.Forbidden(apperror.CodeForbidden)
+	}
+
+	return sendSparse(c, file, read.Keys)
+}
+
+// DownloadFile godoc
+// @Summary Download file
+// @Description Mengunduh isi file dengan nama asli. Admin dapat mengunduh semua file, user hanya miliknya sendiri. Setiap download dicatat di audit log.
+// @Tags File
+// @Produce octet-stream
+// @Security BearerAuth
+// @Param id path string true "File ID"
+// @Success 200 {file} file
+// @Failure 400 {object} model.Problem
+// @Failure 403 {object} model.Problem
+// @Failure 404 {object} model.Problem
+// @Router /file/{id}/download [get]
+func (s *FileService) DownloadFile(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "FileService.DownloadFile")
+	defer span.End()
+
+	role := c.Locals("role").(string)
+	userID := c.Locals("user_id").(string)
+
+	file, err := s.Repo.GetByID(ctx, c.Params("id"))
+	if err != nil {
+		return repoError(err, apperror.CodeFileNotFound)
+	}
+	if role != "admin" && file.UserID.Hex() != userID {
+		return apperror.Forbidden(apperror.CodeForbidden)
 	}
+	if _, err := os.Stat(file.FilePath); err != nil {
+		return apperror.NotFound(apperror.CodeFileNotFound)
+	}
+	return c.Download(file.FilePath, file.OriginalName)
+}
 
-	return c.JSON(file)
+// VerifyFile godoc
+// @Summary Verifikasi sertifikat
+// @Description Admin menandai sertifikat sudah diperiksa. Hanya sertifikat terverifikasi yang tampil di profil alumni.
+// @Tags File
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "File ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} model.Problem
+// @Failure 404 {object} model.Problem
+// @Router /file/{id}/verify [put]
+func (s *FileService) VerifyFile(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "FileService.VerifyFile")
+	defer span.End()
+
+	fileID := c.Params("id")
+	file, err := s.Repo.GetByID(ctx, fileID)
+	if err != nil {
+		return repoError(err, apperror.CodeFileNotFound)
+	}
+	if file.Category != "sertifikat" {
+		return apperror.BadRequest(apperror.CodeFileNotCertificate)
+	}
+
+	userID, _ := c.Locals("user_id").(string)
+	if err := s.Repo.Verify(ctx, fileID, userID); err != nil {
+		return repoError(err, apperror.CodeFileNotFound)
+	}
+	return c.JSON(fiber.Map{"message": i18n.T(c, "file.verified")})
 }
 
 // DeleteFile godoc
@@ -188,32 +294,35 @@ func (s *FileService) GetFileByID(c *fiber.Ctx) error {
 // @Security BearerAuth
 // @Param id path string true "File ID"
 // @Success 200 {object} map[string]interface{}
-// @Failure 403 {object} map[string]interface{}
-// @Failure 404 {object} map[string]interface{}
+// @Failure 403 {object} model.Problem
+// @Failure 404 {object} model.Problem
 // @Router /file/{id} [delete]
 // === DELETE FILE (admin bisa semua, user hanya miliknya sendiri)
 func (s *FileService) DeleteFile(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "FileService.DeleteFile")
+	defer span.End()
+
 	role := c.Locals("role").(string)
 	userID := c.Locals("user_id").(string)
 	fileID := c.Params("id")
 
-	file, err := s.Repo.GetByID(fileID)
+	file, err := s.Repo.GetByID(ctx, fileID)
 	if err != nil {
-		return c.Status(404).JSON(fiber.Map{"error": "File tidak ditemukan"})
+		return repoError(err, apperror.CodeFileNotFound)
 	}
 
 	// User hanya boleh hapus file miliknya
 	if role != "admin" && file.UserID.Hex() != userID {
-		return c.Status(403).JSON(fiber.Map{"error": "Unauthorized"})
+		return apperror.Forbidden(apperror.CodeForbidden)
 	}
 
 	// Hapus file fisik
 	os.Remove(file.FilePath)
 
 	// Hapus metadata dari database
-	if err := s.Repo.DeleteByID(fileID); err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Gagal menghapus data file"})
+	if err := s.Repo.DeleteByID(ctx, fileID); err != nil {
+		return repoError(err, apperror.CodeFileNotFound)
 	}
 
-	return c.JSON(fiber.Map{"message": "File berhasil dihapus"})
+	return c.JSON(fiber.Map{"message": i18n.T(c, "file.deleted")})
 }
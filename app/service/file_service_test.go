@@ -2,6 +2,7 @@ package service
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"mime/multipart"
@@ -14,6 +15,9 @@ import (
 	"time"
 
 	"crud_alumni/app/model"
+	"crud_alumni/app/repository"
+	"crud_alumni/config"
+	"crud_alumni/middleware"
 
 	"github.com/gofiber/fiber/v2"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -26,9 +30,12 @@ type mockFileRepo struct {
 	getByIDResult *model.File
 	deleteErr     error
 	createErr     error
+	pageOwner     string
+	verifiedBy    string
+	pageReq       repository.PageRequest
 }
 
-func (m *mockFileRepo) Create(file *model.File) error {
+func (m *mockFileRepo) Create(ctx context.Context, file *model.File) error {
 	if m.createErr != nil {
 		return m.createErr
 	}
@@ -38,22 +45,44 @@ func (m *mockFileRepo) Create(file *model.File) error {
 	return nil
 }
 
-func (m *mockFileRepo) GetAll() ([]model.File, error) {
+func (m *mockFileRepo) GetAll(ctx context.Context) ([]model.File, error) {
 	return m.files, nil
 }
 
-func (m *mockFileRepo) GetByUserID(userID string) ([]model.File, error) {
+func (m *mockFileRepo) GetByUserID(ctx context.Context, userID string) ([]model.File, error) {
 	return m.files, nil
 }
 
-func (m *mockFileRepo) GetByID(id string) (*model.File, error) {
+func (m *mockFileRepo) GetByID(ctx context.Context, id string) (*model.File, error) {
 	return m.getByIDResult, nil
 }
 
-func (m *mockFileRepo) DeleteByID(id string) error {
+func (m *mockFileRepo) Verify(ctx context.Context, id string, verifiedBy string) error {
+	m.verifiedBy = verifiedBy
+	return nil
+}
+
+func (m *mockFileRepo) DeleteByID(ctx context.Context, id string) error {
 	return m.deleteErr
 }
 
+func (m *mockFileRepo) Page(ctx context.Context, userID string, req repository.PageRequest) (repository.Page[model.File], error) {
+	m.pageOwner, m.pageReq = userID, req
+	if req.Cursor == "rusak" {
+		return repository.Page[model.File]{}, repository.ErrInvalidCursor
+	}
+	return repository.Page[model.File]{Items: m.files, Next: "berikutnya"}, nil
+}
+
+// testUploadConfig - aturan upload default dengan folder sementara
+func testUploadConfig(t *testing.T) config.UploadConfig {
+	t.Helper()
+	upload := config.Defaults().Upload
+	upload.Foto.Dir = filepath.Join(t.TempDir(), "foto")
+	upload.Sertifikat.Dir = filepath.Join(t.TempDir(), "sertifikat")
+	return upload
+}
+
 // --- helpers for multipart ---
 func makeMultipart(bodyFieldName, filename, contentType string, content []byte) (string, *bytes.Buffer, error) {
 	var buf bytes.Buffer
@@ -80,10 +109,10 @@ func makeMultipart(bodyFieldName, filename, contentType string, content []byte)
 func TestUploadFile_SuccessFoto(t *testing.T) {
 	// prepare mock repo
 	mock := &mockFileRepo{}
-	svc := NewFileService(mock)
+	svc := NewFileService(mock, testUploadConfig(t))
 
 	// setup Fiber app with middleware to set locals
-	app := fiber.New()
+	app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
 	app.Use(func(c *fiber.Ctx) error {
 		c.Locals("user_id", primitive.NewObjectID().Hex())
 		c.Locals("role", "user")
@@ -138,9 +167,9 @@ func TestGetAllFiles_Admin(t *testing.T) {
 			},
 		},
 	}
-	svc := NewFileService(mock)
+	svc := NewFileService(mock, testUploadConfig(t))
 
-	app := fiber.New()
+	app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
 	app.Use(func(c *fiber.Ctx) error {
 		c.Locals("role", "admin")
 		c.Locals("user_id", primitive.NewObjectID().Hex())
@@ -170,6 +199,51 @@ func TestGetAllFiles_Admin(t *testing.T) {
 	}
 }
 
+func TestGetAllFiles_Cursor(t *testing.T) {
+	uid := primitive.NewObjectID().Hex()
+	mock := &mockFileRepo{files: []model.File{{ID: primitive.NewObjectID(), OriginalName: "a.txt"}}}
+	svc := NewFileService(mock, testUploadConfig(t))
+
+	app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("role", "user")
+		c.Locals("user_id", uid)
+		return c.Next()
+	})
+	app.Get("/file", svc.GetAllFiles)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/file?cursor=&limit=500&sortBy=password", nil), -1)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if resp.StatusCode != 200 {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	var payload model.FileCursorResponse
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if mock.pageOwner != uid {
+		t.Errorf("user biasa harus dibatasi ke file miliknya, owner=%q", mock.pageOwner)
+	}
+	if mock.pageReq.Limit != maxPageLimit || mock.pageReq.SortBy != "uploaded_at" || mock.pageReq.Order != "desc" {
+		t.Errorf("page request tidak sesuai: %+v", mock.pageReq)
+	}
+	if len(payload.Data) != 1 || payload.Meta.Next != "berikutnya" || payload.Meta.Total != nil {
+		t.Errorf("response tidak sesuai: %+v", payload)
+	}
+
+	for _, target := range []string{"/file?cursor=rusak", "/file?cursor=&limit=0", "/file?cursor=&count=mungkin"} {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, target, nil), -1)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		if resp.StatusCode != 400 {
+			t.Errorf("%s: expected 400, got %d", target, resp.StatusCode)
+		}
+	}
+}
+
 func TestGetFileByID_Unauthorized(t *testing.T) {
 	otherUID := primitive.NewObjectID()
 	mock := &mockFileRepo{
@@ -178,9 +252,9 @@ func TestGetFileByID_Unauthorized(t *testing.T) {
 			UserID: otherUID,
 		},
 	}
-	svc := NewFileService(mock)
+	svc := NewFileService(mock, testUploadConfig(t))
 
-	app := fiber.New()
+	app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
 	app.Use(func(c *fiber.Ctx) error {
 		// set current user different from file owner
 		c.Locals("role", "user")
@@ -217,9 +291,9 @@ func TestDeleteFile_Success(t *testing.T) {
 			FilePath: tmpfile,
 		},
 	}
-	svc := NewFileService(mock)
+	svc := NewFileService(mock, testUploadConfig(t))
 
-	app := fiber.New()
+	app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
 	app.Use(func(c *fiber.Ctx) error {
 		c.Locals("role", "user")
 		c.Locals("user_id", uid.Hex()) // same owner
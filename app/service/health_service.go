@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"crud_alumni/app/model"
+	"crud_alumni/health"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type HealthService struct {
+	Checks []health.Check
+}
+
+func NewHealthService(checks ...health.Check) *HealthService {
+	return &HealthService{Checks: checks}
+}
+
+// Liveness - GET /healthz, menandakan proses masih hidup (tanpa cek dependency).
+// Tidak masuk swagger karena berada di luar BasePath /api.
+func (s *HealthService) Liveness(c *fiber.Ctx) error {
+	return c.JSON(model.HealthResponse{Status: "ok"})
+}
+
+// Readiness - GET /readyz, mengecek MongoDB, folder upload (bisa ditulis &
+// sisa disk), migrasi, dan status shutdown. Mengembalikan 503 jika ada yang gagal.
+func (s *HealthService) Readiness(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), 5*time.Second)
+	defer cancel()
+
+	checks := append([]health.Check{health.DrainingCheck()}, s.Checks...)
+	results, ok := health.RunChecks(ctx, checks)
+
+	resp := model.HealthResponse{Status: "ok", Checks: results}
+	if !ok {
+		resp.Status = "fail"
+		return c.Status(fiber.StatusServiceUnavailable).JSON(resp)
+	}
+	return c.JSON(resp)
+}
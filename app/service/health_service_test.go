@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"crud_alumni/app/model"
+	"crud_alumni/health"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func newHealthApp(checks ...health.Check) *fiber.App {
+	svc := NewHealthService(checks...)
+	app := fiber.New()
+	app.Get("/healthz", svc.Liveness)
+	app.Get("/readyz", svc.Readiness)
+	return app
+}
+
+func TestReadiness_AllOK(t *testing.T) {
+	app := newHealthApp(health.Check{Name: "dummy", Run: func(context.Context) error { return nil }})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/readyz", nil))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if resp.StatusCode != 200 {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+
+	var body model.HealthResponse
+	json.NewDecoder(resp.Body).Decode(&body)
+	if body.Status != "ok" || len(body.Checks) != 2 {
+		t.Errorf("expected status ok dengan 2 check, got %+v", body)
+	}
+}
+
+func TestReadiness_CheckFails(t *testing.T) {
+	app := newHealthApp(health.Check{Name: "mongo", Run: func(context.Context) error { return errors.New("down") }})
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/readyz", nil))
+	if resp.StatusCode != 503 {
+		t.Fatalf("expected 503, got %d", resp.StatusCode)
+	}
+
+	var body model.HealthResponse
+	json.NewDecoder(resp.Body).Decode(&body)
+	if body.Checks[1].Name != "mongo" || body.Checks[1].Error != "down" {
+		t.Errorf("expected detail error mongo, got %+v", body.Checks[1])
+	}
+}
+
+func TestReadiness_Draining(t *testing.T) {
+	health.SetDraining(true)
+	defer health.SetDraining(false)
+
+	app := newHealthApp()
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/readyz", nil))
+	if resp.StatusCode != 503 {
+		t.Errorf("expected 503 saat draining, got %d", resp.StatusCode)
+	}
+
+	resp, _ = app.Test(httptest.NewRequest("GET", "/healthz", nil))
+	if resp.StatusCode != 200 {
+		t.Errorf("expected liveness tetap 200, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"crud_alumni/app/model"
+	"crud_alumni/app/repository"
+	"crud_alumni/apperror"
+	"crud_alumni/config"
+	"crud_alumni/i18n"
+	"crud_alumni/tracing"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// historyIgnored - field yang tidak dicatat sebagai perubahan karena selalu
+// ikut berubah atau bukan data record
+var historyIgnored = []string{"_id", "version", "updated_at", "score"}
+
+// historyActor - pelaku perubahan
+type historyActor struct {
+	ID        string
+	Name      string
+	RequestID string
+}
+
+// actorOf mengambil pelaku dari token JWT dan request id dari middleware.
+// Request id bisa berasal dari header yang buffer-nya dipakai ulang fiber,
+// jadi disalin karena import memakainya setelah request selesai.
+func actorOf(c *fiber.Ctx) historyActor {
+	var a historyActor
+	a.ID, _ = c.Locals("user_id").(string)
+	a.Name, _ = c.Locals("username").(string)
+	requestID, _ := c.Locals("request_id").(string)
+	a.RequestID = strings.Clone(requestID)
+	return a
+}
+
+// newHistoryEntry melengkapi e dengan pelaku, daftar field yang berubah dari
+// before ke after, dan isi after sebagai snapshot. before nil untuk create,
+// after nil untuk hapus permanen.
+func newHistoryEntry(actor historyActor, e model.HistoryEntry, before, after any) (model.HistoryEntry, error) {
+	e.ActorID, e.ActorName, e.RequestID = actor.ID, actor.Name, actor.RequestID
+	old, err := marshalRecord(before)
+	if err != nil {
+		return e, err
+	}
+	cur, err := marshalRecord(after)
+	if err != nil {
+		return e, err
+	}
+	e.Changes = diffRecords(old, cur)
+	e.Snapshot = cur
+	return e, nil
+}
+
+func marshalRecord(v any) (bson.Raw, error) {
+	if v == nil {
+		return nil, nil
+	}
+	return bson.Marshal(v)
+}
+
+// diffRecords - field yang nilainya berbeda, urut sesuai dokumen after lalu
+// field yang hanya ada di before
+func diffRecords(before, after bson.Raw) []model.FieldChange {
+	changes := []model.FieldChange{}
+	seen := map[string]bool{}
+	add := func(key string) {
+		if seen[key] || slices.Contains(historyIgnored, key) {
+			return
+		}
+		seen[key] = true
+		old, cur := lookupRaw(before, key), lookupRaw(after, key)
+		if old.Equal(cur) {
+			return
+		}
+		changes = append(changes, model.FieldChange{Field: key, Before: rawInterface(old), After: rawInterface(cur)})
+	}
+	for _, doc := range []bson.Raw{after, before} {
+		elems, _ := doc.Elements()
+		for _, el := range elems {
+			add(el.Key())
+		}
+	}
+	return changes
+}
+
+func lookupRaw(doc bson.Raw, key string) bson.RawValue {
+	if doc == nil {
+		return bson.RawValue{}
+	}
+	return doc.Lookup(key)
+}
+
+// rawInterface - nilai Go dari field BSON; nil jika field tidak ada atau null
+func rawInterface(v bson.RawValue) any {
+	if v.Type == 0 {
+		return nil
+	}
+	var out any
+	if err := v.Unmarshal(&out); err != nil {
+		return v.String()
+	}
+	return out
+}
+
+// recordHistory mencatat perubahan yang dilakukan request c. Kegagalan
+// hanya di-log: perubahan data sudah tersimpan dan tetap dijawab sukses.
+func recordHistory(c *fiber.Ctx, e model.HistoryEntry, before, after any) {
+	writeHistory(c.UserContext(), actorOf(c), e, before, after)
+}
+
+// writeHistory - recordHistory untuk perubahan di luar request, mis. purge
+// terjadwal yang dijalankan atas nama server
+func writeHistory(ctx context.Context, actor historyActor, e model.HistoryEntry, before, after any) {
+	entry, err := newHistoryEntry(actor, e, before, after)
+	if err == nil {
+		err = repository.InsertHistory(ctx, &entry)
+	}
+	if err != nil {
+		config.Logger.Error().Err(err).
+			Str("resource", e.Resource).Str("record_id", e.RecordID.Hex()).Str("action", e.Action).
+			Msg("gagal mencatat riwayat perubahan")
+	}
+}
+
+// GetAlumniHistory godoc
+// @Summary Riwayat perubahan alumni
+// @Description Semua perubahan pada satu alumni (create, update, delete, restore, purge, revert), yang terbaru lebih dulu, beserta pelaku, request id, dan nilai field sebelum/sesudah. Riwayat tetap ada walau alumni sudah di-purge (admin saja).
+// @Tags Alumni
+// @Produce json
+// @Param id path string true "ID Alumni"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Security BearerAuth
+// @Router /alumni/{id}/history [get]
+func GetAlumniHistory(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "AlumniService.GetAlumniHistory")
+	defer span.End()
+
+	list, err := repository.ListHistory(ctx, model.ResourceAlumni, c.Params("id"))
+	if err != nil {
+		return repoError(err, apperror.CodeAlumniNotFound)
+	}
+	return c.JSON(fiber.Map{"success": true, "data": list})
+}
+
+// RevertAlumni godoc
+// @Summary Kembalikan alumni ke versi sebelumnya
+// @Description Admin mengganti isi alumni dengan isi pada versi tertentu dari riwayat. Hasilnya versi baru (aksi revert); status trash tidak berubah.
+// @Tags Alumni
+// @Produce json
+// @Param id path string true "ID Alumni"
+// @Param version path int true "Versi tujuan (lihat /alumni/{id}/history)"
+// @Param If-Match header string false "ETag dari GET; 412 jika data sudah berubah"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} model.Problem
+// @Failure 404 {object} model.Problem
+// @Failure 409 {object} model.Problem
+// @Failure 412 {object} model.Problem
+// @Failure 422 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Security BearerAuth
+// @Router /alumni/{id}/history/{version}/revert [post]
+func RevertAlumni(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "AlumniService.RevertAlumni")
+	defer span.End()
+
+	id := c.Params("id")
+	version, err := strconv.ParseInt(c.Params("version"), 10, 64)
+	if err != nil || version < 1 {
+		return apperror.BadRequest(apperror.CodeInvalidParam).WithArgs("version")
+	}
+
+	existing, err := repository.GetAlumniByID(ctx, id)
+	if err != nil {
+		return repoError(err, apperror.CodeAlumniNotFound)
+	}
+	if err := checkIfMatch(c, existing.Version); err != nil {
+		return err
+	}
+	entry, err := repository.GetHistoryVersion(ctx, model.ResourceAlumni, id, version)
+	if err != nil {
+		return repoError(err, apperror.CodeHistoryVersionNotFound)
+	}
+
+	var a model.Alumni
+	if err := bson.Unmarshal(entry.Snapshot, &a); err != nil {
+		return apperror.Internal(err)
+	}
+	keepAlumniMeta(&a, existing)
+	// aturan validasi bisa sudah berubah sejak versi tersebut disimpan
+	if err := validateAlumni(ctx, &a); err != nil {
+		return err
+	}
+	if err := repository.ReplaceAlumni(ctx, &a); err != nil {
+		return alumniSaveError(err, a.NIM)
+	}
+	recordHistory(c, model.HistoryEntry{Resource: model.ResourceAlumni, RecordID: a.ID, Version: a.Version, Action: model.HistoryRevert, RevertedTo: version}, existing, a)
+
+	setETag(c, a.Version)
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "alumni.reverted", version), "data": a})
+}
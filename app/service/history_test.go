@@ -0,0 +1,87 @@
+package service
+
+import (
+	"crud_alumni/app/model"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestNewHistoryEntry_Update(t *testing.T) {
+	id := primitive.NewObjectID()
+	before := model.Alumni{ID: id, NIM: "NIM00001", Nama: "Budi", Jurusan: "TI", Version: 2, UpdatedAt: "2026-01-01 00:00:00"}
+	after := before
+	after.Nama, after.Version, after.UpdatedAt = "Budi Santoso", 3, "2026-02-01 00:00:00"
+
+	actor := historyActor{ID: "u1", Name: "admin", RequestID: "req-1"}
+	e, err := newHistoryEntry(actor, model.HistoryEntry{Resource: model.ResourceAlumni, RecordID: id, Version: 3, Action: model.HistoryUpdate}, before, after)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e.ActorID != "u1" || e.ActorName != "admin" || e.RequestID != "req-1" {
+		t.Errorf("actor not recorded: %+v", e)
+	}
+	// version dan updated_at selalu berubah sehingga tidak dicatat
+	if len(e.Changes) != 1 || e.Changes[0].Field != "nama" || e.Changes[0].Before != "Budi" || e.Changes[0].After != "Budi Santoso" {
+		t.Errorf("unexpected changes: %+v", e.Changes)
+	}
+
+	var snap model.Alumni
+	if err := bson.Unmarshal(e.Snapshot, &snap); err != nil || snap.Nama != "Budi Santoso" {
+		t.Errorf("snapshot must hold the new state, got %+v (%v)", snap, err)
+	}
+}
+
+func TestNewHistoryEntry_CreateAndPurge(t *testing.T) {
+	a := model.Alumni{ID: primitive.NewObjectID(), NIM: "NIM00001", Nama: "Budi"}
+
+	created, err := newHistoryEntry(historyActor{}, model.HistoryEntry{Action: model.HistoryCreate}, nil, a)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !hasChange(created.Changes, "nim", nil, "NIM00001") || created.Snapshot == nil {
+		t.Errorf("create must list new fields with null before, got %+v", created.Changes)
+	}
+
+	purged, err := newHistoryEntry(historyActor{}, model.HistoryEntry{Action: model.HistoryPurge}, a, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !hasChange(purged.Changes, "nama", "Budi", nil) || purged.Snapshot != nil {
+		t.Errorf("purge must list removed fields without snapshot, got %+v", purged)
+	}
+}
+
+func TestDiffRecords_NoChange(t *testing.T) {
+	doc, _ := bson.Marshal(bson.M{"nama": "Budi", "angkatan": 2018})
+	if changes := diffRecords(doc, doc); len(changes) != 0 {
+		t.Errorf("expected no changes, got %+v", changes)
+	}
+}
+
+func hasChange(changes []model.FieldChange, field string, before, after any) bool {
+	for _, c := range changes {
+		if c.Field == field {
+			return c.Before == before && c.After == after
+		}
+	}
+	return false
+}
+
+func TestHistoryUser_PasswordNotStored(t *testing.T) {
+	before := model.User{ID: primitive.NewObjectID(), Username: "budi", Email: "budi@kampus.ac.id", Role: "user", PasswordHash: "$2a$old", Version: 1}
+	after := before
+	after.PasswordHash, after.Version = "$2a$new", 2
+
+	e, err := newHistoryEntry(historyActor{}, model.HistoryEntry{Action: model.HistoryUpdate}, historyUser(before, false), historyUser(after, true))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(e.Changes) != 1 || !hasChange(e.Changes, "password", nil, "changed") {
+		t.Errorf("password change must be recorded without the hash, got %+v", e.Changes)
+	}
+	if _, err := e.Snapshot.LookupErr("password_hash"); err == nil {
+		t.Errorf("snapshot must not contain password_hash")
+	}
+}
@@ -0,0 +1,535 @@
+package service
+
+import (
+	"context"
+	"crud_alumni/app/model"
+	"crud_alumni/app/repository"
+	"crud_alumni/apperror"
+	"crud_alumni/config"
+	"crud_alumni/i18n"
+	"crud_alumni/middleware"
+	"crud_alumni/tabular"
+	"crud_alumni/tracing"
+	"crud_alumni/validation"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// importMaxErrors - batas error per baris yang disimpan di laporan
+	importMaxErrors = 1000
+	// importProgressEvery - progress job disimpan setiap sekian baris
+	importProgressEvery = 100
+	// importLookupBatch - jumlah NIM per query pencarian alumni yang sudah ada
+	importLookupBatch = 500
+)
+
+// alumniColumns - field alumni yang bisa di-import/export, urut seperti di model
+var alumniColumns = []string{"nim", "nama", "jurusan", "angkatan", "tahun_lulus", "email", "no_telepon", "alamat"}
+
+// importRequired - kolom yang wajib ada di file
+var importRequired = []string{"nim", "nama", "jurusan", "angkatan", "tahun_lulus", "email"}
+
+// alumniStore - akses data alumni yang dipakai import (diganti mock di test)
+type alumniStore interface {
+	FindByNIMs(ctx context.Context, nims []string) (map[string]model.Alumni, error)
+	Create(ctx context.Context, a model.Alumni) (primitive.ObjectID, error)
+	Replace(ctx context.Context, a *model.Alumni) error
+	RecordHistory(ctx context.Context, e *model.HistoryEntry) error
+	CustomFields(ctx context.Context) ([]model.CustomField, error)
+}
+
+type repoAlumniStore struct{}
+
+func (repoAlumniStore) FindByNIMs(ctx context.Context, nims []string) (map[string]model.Alumni, error) {
+	return repository.FindAlumniByNIMs(ctx, nims)
+}
+
+func (repoAlumniStore) Create(ctx context.Context, a model.Alumni) (primitive.ObjectID, error) {
+	return repository.CreateAlumni(ctx, a)
+}
+
+func (repoAlumniStore) Replace(ctx context.Context, a *model.Alumni) error {
+	return repository.ReplaceAlumni(ctx, a)
+}
+
+func (repoAlumniStore) RecordHistory(ctx context.Context, e *model.HistoryEntry) error {
+	return repository.InsertHistory(ctx, e)
+}
+
+func (repoAlumniStore) CustomFields(ctx context.Context) ([]model.CustomField, error) {
+	return repository.ListCustomFields(ctx)
+}
+
+// ImportInterrupted - pesan error job yang tidak selesai karena server berhenti
+const ImportInterrupted = "server berhenti sebelum import selesai"
+
+type ImportService struct {
+	Jobs    repository.ImportJobRepo
+	Import  config.ImportConfig
+	store   alumniStore
+	queue   chan struct{} // satu job background berjalan dalam satu waktu
+	running sync.WaitGroup
+	base    context.Context // dibatalkan Close setelah batas waktu
+	cancel  context.CancelFunc
+}
+
+func NewImportService(jobs repository.ImportJobRepo, cfg config.ImportConfig) *ImportService {
+	base, cancel := context.WithCancel(context.Background())
+	return &ImportService{Jobs: jobs, Import: cfg, store: repoAlumniStore{}, queue: make(chan struct{}, 1), base: base, cancel: cancel}
+}
+
+// Close menunggu job background (yang berjalan maupun antre) selesai. Setelah
+// timeout job dibatalkan dan ditandai failed dengan ImportInterrupted.
+func (s *ImportService) Close(timeout time.Duration) {
+	done := make(chan struct{})
+	go func() {
+		s.running.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		s.cancel()
+		<-done
+	}
+}
+
+// importRow - satu baris data yang sudah dipetakan ke Alumni
+type importRow struct {
+	line   int
+	alumni model.Alumni
+}
+
+// ImportAlumni godoc
+// @Summary Import alumni dari CSV/XLSX
+// @Description Admin meng-import alumni dari file CSV (pemisah koma atau titik koma) atau XLSX (sheet pertama). Baris pertama adalah header; nama kolom default sama dengan field JSON alumni (tag dipisah koma di kolom tags; custom field: custom.<name>), atau dipetakan lewat `mapping`. Kolom yang tidak ada di file (mis. no_telepon, alamat, tags, custom field) tidak berubah pada alumni yang diperbarui. Data di-upsert berdasarkan NIM; baris dengan NIM milik alumni di trash ditolak. Mode dry_run (default) hanya memvalidasi dan melaporkan apa yang akan dibuat/diperbarui. File dengan baris lebih banyak dari IMPORT_SYNC_ROWS diproses di background (202) dan progress-nya dipantau lewat /alumni/import/{job_id}.
+// @Tags Alumni
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "File .csv atau .xlsx"
+// @Param mode formData string false "dry_run (default) atau commit"
+// @Param mapping formData string false "JSON field -> nama kolom, mis. {\"nim\":\"NIM Mahasiswa\",\"nama\":\"Nama Lengkap\"}"
+// @Success 200 {object} model.ImportReport
+// @Success 202 {object} model.ImportJob
+// @Failure 400 {object} model.Problem
+// @Failure 403 {object} model.Problem
+// @Failure 413 {object} model.Problem
+// @Failure 415 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Security BearerAuth
+// @Router /alumni/import [post]
+func (s *ImportService) ImportAlumni(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "ImportService.ImportAlumni")
+	defer span.End()
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return apperror.BadRequest(apperror.CodeUploadMissingFile)
+	}
+	if fileHeader.Size > int64(s.Import.MaxSize) {
+		return apperror.New(fiber.StatusRequestEntityTooLarge, apperror.CodeUploadTooLarge).
+			WithArgs(s.Import.MaxSize.String())
+	}
+	format, err := tabular.Format(fileHeader.Filename)
+	if err != nil {
+		return apperror.New(fiber.StatusUnsupportedMediaType, apperror.CodeImportUnsupportedFormat)
+	}
+
+	mode := c.FormValue("mode", model.ImportModeDryRun)
+	if mode != model.ImportModeDryRun && mode != model.ImportModeCommit {
+		return apperror.BadRequest(apperror.CodeInvalidParam).WithArgs("mode")
+	}
+	mapping := map[string]string{}
+	if raw := c.FormValue("mapping"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &mapping); err != nil {
+			return apperror.BadRequest(apperror.CodeInvalidParam).WithArgs("mapping")
+		}
+	}
+
+	f, err := fileHeader.Open()
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	data, err := io.ReadAll(f)
+	f.Close()
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	rows, err := tabular.Read(data, format)
+	if err != nil || len(rows) == 0 {
+		return apperror.BadRequest(apperror.CodeImportUnreadableFile)
+	}
+
+	defs, err := s.store.CustomFields(ctx)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	columns, err := importColumnIndex(rows[0], mapping, defs)
+	if err != nil {
+		return err
+	}
+	dataRows := rows[1:]
+	lang := i18n.Lang(c)
+	middleware.AuditDetail(c, "file", fileHeader.Filename)
+	middleware.AuditDetail(c, "mode", mode)
+	middleware.AuditDetail(c, "rows", strconv.Itoa(len(dataRows)))
+
+	if len(dataRows) <= s.Import.SyncRows {
+		report, err := s.process(ctx, actorOf(c), mode, lang, defs, columns, dataRows, nil)
+		if err != nil {
+			return apperror.Internal(err)
+		}
+		middleware.AuditDetail(c, "created", strconv.Itoa(report.Created))
+		middleware.AuditDetail(c, "updated", strconv.Itoa(report.Updated))
+		return c.JSON(report)
+	}
+
+	userID, _ := c.Locals("user_id").(string)
+	job := &model.ImportJob{
+		Status:    model.ImportStatusQueued,
+		Mode:      mode,
+		FileName:  fileHeader.Filename,
+		Total:     len(dataRows),
+		CreatedBy: userID,
+	}
+	if err := s.Jobs.Create(ctx, job); err != nil {
+		return apperror.Internal(err)
+	}
+	s.running.Add(1)
+	go s.runJob(job.ID, actorOf(c), mode, lang, defs, columns, dataRows)
+	middleware.AuditDetail(c, "job_id", job.ID.Hex())
+
+	c.Set(fiber.HeaderLocation, c.BaseURL()+"/api/alumni/import/"+job.ID.Hex())
+	return c.Status(http.StatusAccepted).JSON(job)
+}
+
+// GetImportJob godoc
+// @Summary Status job import alumni
+// @Description Progress (processed/total) dan laporan job import yang berjalan di background
+// @Tags Alumni
+// @Produce json
+// @Param job_id path string true "ID job import"
+// @Success 200 {object} model.ImportJob
+// @Failure 400 {object} model.Problem
+// @Failure 404 {object} model.Problem
+// @Security BearerAuth
+// @Router /alumni/import/{job_id} [get]
+func (s *ImportService) GetImportJob(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "ImportService.GetImportJob")
+	defer span.End()
+
+	job, err := s.Jobs.GetByID(ctx, c.Params("job_id"))
+	if err != nil {
+		return repoError(err, apperror.CodeImportJobNotFound)
+	}
+	return c.JSON(job)
+}
+
+// runJob memproses import di background setelah job sebelumnya selesai;
+// job yang masih antre atau berjalan saat Close habis waktunya ditandai failed
+func (s *ImportService) runJob(id primitive.ObjectID, actor historyActor, mode, lang string, defs []model.CustomField, columns map[string]int, rows [][]string) {
+	defer s.running.Done()
+	ctx, span := tracing.Start(s.base, "ImportService.runJob")
+	defer span.End()
+	// hasil job tetap disimpan walaupun ctx sudah dibatalkan Close
+	finish := func(report *model.ImportReport, errMsg string) {
+		if err := s.Jobs.Finish(context.WithoutCancel(ctx), id, report, errMsg); err != nil {
+			config.Logger.Error().Err(err).Str("job_id", id.Hex()).Msg("gagal menyimpan hasil import")
+		}
+	}
+
+	select {
+	case s.queue <- struct{}{}:
+		defer func() { <-s.queue }()
+	case <-ctx.Done():
+		finish(nil, ImportInterrupted)
+		return
+	}
+
+	progress := func(processed int) {
+		if err := s.Jobs.UpdateProgress(ctx, id, model.ImportStatusRunning, processed); err != nil {
+			config.Logger.Warn().Err(err).Str("job_id", id.Hex()).Msg("gagal menyimpan progress import")
+		}
+	}
+	progress(0)
+
+	report, err := s.process(ctx, actor, mode, lang, defs, columns, rows, progress)
+	errMsg := ""
+	if err != nil {
+		errMsg = err.Error()
+		if ctx.Err() != nil {
+			errMsg = ImportInterrupted
+		}
+		config.Logger.Error().Err(err).Str("job_id", id.Hex()).Msg("import alumni gagal")
+	}
+	finish(&report, errMsg)
+}
+
+// process memvalidasi semua baris lalu (pada mode commit) meng-upsert alumni
+// yang valid berdasarkan NIM. Error database menghentikan proses; baris yang
+// sudah tersimpan tetap tersimpan. Setiap perubahan dicatat di riwayat atas
+// nama actor (admin yang meng-upload).
+func (s *ImportService) process(ctx context.Context, actor historyActor, mode, lang string, defs []model.CustomField, columns map[string]int, rows [][]string, progress func(int)) (model.ImportReport, error) {
+	report := model.ImportReport{Mode: mode, TotalRows: len(rows), Errors: []model.ImportRowError{}}
+	addError := func(rowErr model.ImportRowError) {
+		report.Invalid++
+		if len(report.Errors) >= importMaxErrors {
+			report.ErrorsTruncated = true
+			return
+		}
+		for i, f := range rowErr.Fields {
+			rowErr.Fields[i].Message = i18n.Message(lang, "field."+f.Code, f.Args...)
+			rowErr.Fields[i].Args = nil
+		}
+		report.Errors = append(report.Errors, rowErr)
+	}
+
+	// validasi per baris + NIM ganda di dalam file
+	var valid []importRow
+	seen := map[string]int{}
+	for i, cells := range rows {
+		line := i + 2
+		a, fields := parseImportRow(cells, columns, defs)
+		if len(fields) == 0 {
+			if first, dup := seen[a.NIM]; dup {
+				fields = append(fields, apperror.FieldError{Field: "nim", Code: "duplicate", Args: []any{first}})
+			} else {
+				seen[a.NIM] = line
+			}
+		}
+		if len(fields) > 0 {
+			addError(model.ImportRowError{Row: line, NIM: a.NIM, Fields: fields})
+			continue
+		}
+		valid = append(valid, importRow{line: line, alumni: a})
+	}
+	report.Valid = len(valid)
+
+	// reject - baris valid yang ditolak karena NIM-nya bentrok di database
+	reject := func(r importRow, code string) {
+		report.Valid--
+		addError(model.ImportRowError{Row: r.line, NIM: r.alumni.NIM, Fields: []apperror.FieldError{{Field: "nim", Code: code}}})
+	}
+
+	processed := len(rows) - len(valid)
+	for start := 0; start < len(valid); start += importLookupBatch {
+		batch := valid[start:min(start+importLookupBatch, len(valid))]
+		nims := make([]string, len(batch))
+		for i, r := range batch {
+			nims[i] = r.alumni.NIM
+		}
+		existing, err := s.store.FindByNIMs(ctx, nims)
+		if err != nil {
+			return report, err
+		}
+
+		for _, r := range batch {
+			old, found := existing[r.alumni.NIM]
+			switch {
+			case found && old.DeletedAt != nil:
+				// NIM alumni di trash tetap terpakai (index unik); alumni di
+				// trash tidak ditimpa diam-diam
+				reject(r, "in_trash")
+			case mode == model.ImportModeDryRun && found:
+				report.Updated++
+			case mode == model.ImportModeDryRun:
+				report.Created++
+			case found:
+				a := r.alumni
+				keepAlumniMeta(&a, old)
+				keepAbsentColumns(&a, old, defs, columns)
+				if err := s.store.Replace(ctx, &a); errors.Is(err, repository.ErrDuplicate) {
+					reject(r, "nim_taken")
+					break
+				} else if err != nil {
+					return report, err
+				}
+				s.recordHistory(ctx, actor, model.HistoryEntry{Resource: model.ResourceAlumni, RecordID: a.ID, Version: a.Version, Action: model.HistoryUpdate}, old, a)
+				report.Updated++
+			default:
+				a := r.alumni
+				id, err := s.store.Create(ctx, a)
+				if errors.Is(err, repository.ErrDuplicate) {
+					reject(r, "nim_taken")
+					break
+				} else if err != nil {
+					return report, err
+				}
+				a.ID, a.Version = id, repository.InitialVersion
+				s.recordHistory(ctx, actor, model.HistoryEntry{Resource: model.ResourceAlumni, RecordID: a.ID, Version: a.Version, Action: model.HistoryCreate}, nil, a)
+				report.Created++
+			}
+			processed++
+			if progress != nil && processed%importProgressEvery == 0 {
+				progress(processed)
+			}
+		}
+	}
+	return report, nil
+}
+
+// recordHistory - seperti recordHistory untuk request, tetapi lewat store
+// karena import bisa berjalan di background setelah request selesai
+func (s *ImportService) recordHistory(ctx context.Context, actor historyActor, e model.HistoryEntry, before, after any) {
+	entry, err := newHistoryEntry(actor, e, before, after)
+	if err == nil {
+		err = s.store.RecordHistory(ctx, &entry)
+	}
+	if err != nil {
+		config.Logger.Error().Err(err).Str("record_id", e.RecordID.Hex()).Msg("gagal mencatat riwayat import")
+	}
+}
+
+// keepAbsentColumns mempertahankan nilai old untuk setiap kolom yang tidak
+// ada di file (mis. no_telepon, alamat, tags, custom.<name>), supaya import
+// sebagian kolom tidak menghapus data yang dikelola di luar file
+func keepAbsentColumns(a *model.Alumni, old model.Alumni, defs []model.CustomField, columns map[string]int) {
+	for _, f := range mergeFields {
+		if _, inFile := columns[f.name]; !inFile {
+			f.take(a, old)
+		}
+	}
+	if _, inFile := columns["tags"]; !inFile {
+		a.Tags = old.Tags
+	}
+	for _, d := range defs {
+		v, ok := old.Custom[d.Name]
+		if _, inFile := columns["custom."+d.Name]; inFile || !ok {
+			continue
+		}
+		if a.Custom == nil {
+			a.Custom = map[string]any{}
+		}
+		a.Custom[d.Name] = v
+	}
+}
+
+// importColumnIndex mencari posisi kolom setiap field di header. mapping
+// (field -> nama kolom) menimpa nama default; pencocokan tidak peka huruf
+// besar/kecil dan spasi di tepi. Custom field bernama custom.<name>; yang
+// required wajib ada kolomnya.
+func importColumnIndex(header []string, mapping map[string]string, defs []model.CustomField) (map[string]int, error) {
+	fieldNames := append(slices.Clone(alumniColumns), "tags")
+	required := slices.Clone(importRequired)
+	for _, d := range defs {
+		fieldNames = append(fieldNames, "custom."+d.Name)
+		if d.Required {
+			required = append(required, "custom."+d.Name)
+		}
+	}
+	known := map[string]bool{}
+	for _, f := range fieldNames {
+		known[f] = true
+	}
+	for field := range mapping {
+		if !known[field] {
+			return nil, apperror.BadRequest(apperror.CodeInvalidParam).WithArgs("mapping." + field)
+		}
+	}
+
+	position := map[string]int{}
+	for i, h := range header {
+		position[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+
+	columns := map[string]int{}
+	for _, field := range fieldNames {
+		name := field
+		if mapped, ok := mapping[field]; ok {
+			name = mapped
+		}
+		if i, ok := position[strings.ToLower(strings.TrimSpace(name))]; ok {
+			columns[field] = i
+		}
+	}
+
+	var missing []string
+	for _, field := range required {
+		if _, ok := columns[field]; !ok {
+			missing = append(missing, field)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, apperror.BadRequest(apperror.CodeImportMissingColumns).WithArgs(strings.Join(missing, ", "))
+	}
+	return columns, nil
+}
+
+// parseImportRow mengubah satu baris menjadi Alumni dan mengembalikan semua
+// kesalahan field (format angka, aturan validasi model, dan custom field)
+func parseImportRow(cells []string, columns map[string]int, defs []model.CustomField) (model.Alumni, []apperror.FieldError) {
+	cell := func(field string) string {
+		if i, ok := columns[field]; ok && i < len(cells) {
+			return strings.TrimSpace(cells[i])
+		}
+		return ""
+	}
+
+	var fields []apperror.FieldError
+	bad := map[string]bool{}
+	number := func(field string) int {
+		raw := cell(field)
+		if raw == "" {
+			return 0
+		}
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			bad[field] = true
+			fields = append(fields, apperror.FieldError{Field: field, Code: "integer"})
+		}
+		return n
+	}
+
+	a := model.Alumni{
+		NIM:        cell("nim"),
+		Nama:       cell("nama"),
+		Jurusan:    cell("jurusan"),
+		Angkatan:   number("angkatan"),
+		TahunLulus: number("tahun_lulus"),
+		Email:      cell("email"),
+		NoTelepon:  cell("no_telepon"),
+		Alamat:     cell("alamat"),
+		Tags:       normalizeTags(strings.Split(cell("tags"), ",")),
+	}
+	for _, f := range validation.Fields(a) {
+		if !bad[f.Field] {
+			fields = append(fields, f)
+		}
+	}
+
+	custom := map[string]any{}
+	for _, d := range defs {
+		raw := cell("custom." + d.Name)
+		if raw == "" || d.Type != model.CustomFieldNumber {
+			custom[d.Name] = raw
+			continue
+		}
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			bad["custom."+d.Name] = true
+			fields = append(fields, apperror.FieldError{Field: "custom." + d.Name, Code: "number"})
+			continue
+		}
+		custom[d.Name] = n
+	}
+	clean, customFields := validateCustom(custom, defs)
+	for _, f := range customFields {
+		if !bad[f.Field] {
+			fields = append(fields, f)
+		}
+	}
+	a.Custom = clean
+	normalizeAlumni(&a)
+	return a, fields
+}
@@ -0,0 +1,397 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"crud_alumni/app/model"
+	"crud_alumni/config"
+	"crud_alumni/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// --- Mock store & job repo ---
+type mockAlumniStore struct {
+	mu       sync.Mutex
+	existing map[string]model.Alumni
+	created  []model.Alumni
+	replaced []model.Alumni
+	history  []model.HistoryEntry
+	fields   []model.CustomField
+}
+
+func (m *mockAlumniStore) FindByNIMs(ctx context.Context, nims []string) (map[string]model.Alumni, error) {
+	out := map[string]model.Alumni{}
+	for _, n := range nims {
+		if a, ok := m.existing[n]; ok {
+			out[n] = a
+		}
+	}
+	return out, nil
+}
+
+func (m *mockAlumniStore) Create(ctx context.Context, a model.Alumni) (primitive.ObjectID, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.created = append(m.created, a)
+	return primitive.NewObjectID(), nil
+}
+
+func (m *mockAlumniStore) Replace(ctx context.Context, a *model.Alumni) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.replaced = append(m.replaced, *a)
+	return nil
+}
+
+func (m *mockAlumniStore) RecordHistory(ctx context.Context, e *model.HistoryEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.history = append(m.history, *e)
+	return nil
+}
+
+func (m *mockAlumniStore) CustomFields(ctx context.Context) ([]model.CustomField, error) {
+	return m.fields, nil
+}
+
+type mockImportJobRepo struct {
+	mu       sync.Mutex
+	job      *model.ImportJob
+	finished chan struct{}
+}
+
+func (m *mockImportJobRepo) Create(ctx context.Context, job *model.ImportJob) error {
+	job.ID = primitive.NewObjectID()
+	m.mu.Lock()
+	copied := *job
+	m.job = &copied
+	m.mu.Unlock()
+	return nil
+}
+
+func (m *mockImportJobRepo) GetByID(ctx context.Context, id string) (*model.ImportJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.job, nil
+}
+
+func (m *mockImportJobRepo) UpdateProgress(ctx context.Context, id primitive.ObjectID, status string, processed int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.job.Status, m.job.Processed = status, processed
+	return nil
+}
+
+func (m *mockImportJobRepo) Finish(ctx context.Context, id primitive.ObjectID, report *model.ImportReport, errMsg string) error {
+	m.mu.Lock()
+	m.job.Status, m.job.Report, m.job.Error = model.ImportStatusDone, report, errMsg
+	m.mu.Unlock()
+	close(m.finished)
+	return nil
+}
+
+func (m *mockImportJobRepo) FailUnfinished(ctx context.Context, errMsg string) (int64, error) {
+	return 0, nil
+}
+
+func newTestImportService(store *mockAlumniStore, jobs *mockImportJobRepo) *fiber.App {
+	svc := NewImportService(jobs, config.Defaults().Import)
+	svc.store = store
+
+	app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+	app.Post("/alumni/import", svc.ImportAlumni)
+	return app
+}
+
+// postImport mengirim file CSV beserta field form tambahan
+func postImport(t *testing.T, app *fiber.App, csv string, form map[string]string) *http.Response {
+	t.Helper()
+	ct, body, err := makeMultipart("file", "alumni.csv", "text/csv", []byte(csv))
+	if err != nil {
+		t.Fatalf("failed to create multipart: %v", err)
+	}
+	// field form biasa ditambahkan sebelum boundary penutup
+	if len(form) > 0 {
+		boundary := strings.TrimPrefix(ct, "multipart/form-data; boundary=")
+		raw := strings.TrimSuffix(body.String(), "--"+boundary+"--\r\n")
+		var sb strings.Builder
+		sb.WriteString(raw)
+		for k, v := range form {
+			fmt.Fprintf(&sb, "--%s\r\nContent-Disposition: form-data; name=%q\r\n\r\n%s\r\n", boundary, k, v)
+		}
+		sb.WriteString("--" + boundary + "--\r\n")
+		body.Reset()
+		body.WriteString(sb.String())
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/alumni/import", body)
+	req.Header.Set("Content-Type", ct)
+	req.Header.Set("Accept-Language", "en")
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	return resp
+}
+
+const importHeader = "nim,nama,jurusan,angkatan,tahun_lulus,email\n"
+
+func TestImportAlumni_DryRunReportsRowErrors(t *testing.T) {
+	store := &mockAlumniStore{existing: map[string]model.Alumni{"NIM00002": {NIM: "NIM00002"}}}
+	app := newTestImportService(store, &mockImportJobRepo{})
+
+	csv := importHeader +
+		"NIM00001,Budi,Teknik Informatika,2018,2022,budi@example.com\n" +
+		"NIM00002,Sari,Sistem Informasi,2017,2021,sari@example.com\n" +
+		"NIM00003,Andi,Kedokteran,dua ribu,2021,andi\n" +
+		"NIM00001,Budi Lagi,Teknik Informatika,2018,2022,budi2@example.com\n"
+	resp := postImport(t, app, csv, nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+
+	var report model.ImportReport
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if report.Mode != model.ImportModeDryRun || report.TotalRows != 4 || report.Valid != 2 || report.Invalid != 2 {
+		t.Fatalf("unexpected counts: %+v", report)
+	}
+	if report.Created != 1 || report.Updated != 1 {
+		t.Errorf("expected 1 created + 1 updated, got %+v", report)
+	}
+	if len(store.created)+len(store.replaced) != 0 {
+		t.Errorf("dry_run must not write, got %d created, %d replaced", len(store.created), len(store.replaced))
+	}
+
+	if len(report.Errors) != 2 || report.Errors[0].Row != 4 || report.Errors[1].Row != 5 {
+		t.Fatalf("expected errors on rows 4 and 5, got %+v", report.Errors)
+	}
+	codes := map[string]string{}
+	for _, f := range report.Errors[0].Fields {
+		codes[f.Field] = f.Code
+	}
+	if codes["angkatan"] != "integer" || codes["jurusan"] != "jurusan" || codes["email"] != "email" {
+		t.Errorf("unexpected field errors on row 4: %+v", report.Errors[0].Fields)
+	}
+	dup := report.Errors[1].Fields[0]
+	if dup.Code != "duplicate" || dup.Message != "Same NIM as row 2" {
+		t.Errorf("expected duplicate NIM error, got %+v", dup)
+	}
+}
+
+func TestImportAlumni_CommitUpsertsByNIM(t *testing.T) {
+	existingID := primitive.NewObjectID()
+	store := &mockAlumniStore{existing: map[string]model.Alumni{
+		"NIM00002": {ID: existingID, NIM: "NIM00002", Version: 3, CreatedAt: "2020-01-01 00:00:00", NoTelepon: "+628111", Alamat: "Bandung"},
+	}}
+	app := newTestImportService(store, &mockImportJobRepo{})
+
+	// header custom lewat mapping, urutan kolom bebas
+	csv := "Email;Nama Lengkap;NIM Mahasiswa;Prodi;Masuk;Lulus;Telp\n" +
+		"budi@example.com;Budi;NIM00001;Teknik Informatika;2018;2022;0812-3456-7890\n" +
+		"sari@example.com;Sari;NIM00002;Sistem Informasi;2017;2021;\n"
+	mapping := `{"nim":"NIM Mahasiswa","nama":"Nama Lengkap","jurusan":"Prodi","angkatan":"Masuk","tahun_lulus":"Lulus","no_telepon":"Telp"}`
+	resp := postImport(t, app, csv, map[string]string{"mode": "commit", "mapping": mapping})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+
+	var report model.ImportReport
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if report.Created != 1 || report.Updated != 1 || report.Invalid != 0 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	if len(store.created) != 1 || store.created[0].NIM != "NIM00001" || store.created[0].NoTelepon != "+6281234567890" {
+		t.Errorf("expected NIM00001 created with E.164 phone, got %+v", store.created)
+	}
+	if len(store.replaced) != 1 {
+		t.Fatalf("expected 1 replace, got %d", len(store.replaced))
+	}
+	got := store.replaced[0]
+	if got.ID != existingID || got.Version != 3 || got.CreatedAt != "2020-01-01 00:00:00" || got.Nama != "Sari" {
+		t.Errorf("replace must keep metadata and use file data, got %+v", got)
+	}
+	// kolom Telp ada tetapi kosong: no_telepon dihapus; kolom alamat tidak ada: dipertahankan
+	if got.NoTelepon != "" || got.Alamat != "Bandung" {
+		t.Errorf("expected no_telepon dikosongkan dan alamat dipertahankan, got %q / %q", got.NoTelepon, got.Alamat)
+	}
+
+	// setiap baris yang ditulis tercatat di riwayat
+	if len(store.history) != 2 {
+		t.Fatalf("expected 2 history entries, got %d", len(store.history))
+	}
+	for _, e := range store.history {
+		switch e.Action {
+		case model.HistoryCreate:
+			if e.RecordID.IsZero() || e.Version != 1 || len(e.Changes) == 0 {
+				t.Errorf("unexpected create entry: %+v", e)
+			}
+		case model.HistoryUpdate:
+			if e.RecordID != existingID {
+				t.Errorf("update entry must point to existing alumni, got %+v", e)
+			}
+		default:
+			t.Errorf("unexpected action %q", e.Action)
+		}
+	}
+}
+
+func TestImportAlumni_CustomFields(t *testing.T) {
+	store := &mockAlumniStore{
+		existing: map[string]model.Alumni{
+			"NIM00002": {ID: primitive.NewObjectID(), NIM: "NIM00002", Custom: map[string]any{"kota": "Bandung"}, Tags: []string{"narasumber"}},
+		},
+		fields: []model.CustomField{
+			{Name: "ipk", Type: model.CustomFieldNumber},
+			{Name: "beasiswa", Type: model.CustomFieldEnum, Options: []string{"KIP", "LPDP"}, Required: true},
+			{Name: "kota", Type: model.CustomFieldString},
+		},
+	}
+	app := newTestImportService(store, &mockImportJobRepo{})
+
+	// kolom custom.kota dan tags tidak ada: nilai lama NIM00002 tetap dipakai
+	csv := "nim,nama,jurusan,angkatan,tahun_lulus,email,custom.ipk,custom.beasiswa\n" +
+		"NIM00001,Budi,Teknik Informatika,2018,2022,budi@example.com,3.75,KIP\n" +
+		"NIM00002,Sari,Sistem Informasi,2017,2021,sari@example.com,,LPDP\n" +
+		"NIM00003,Dewi,Sistem Informasi,2017,2021,dewi@example.com,tinggi,Mandiri\n"
+	resp := postImport(t, app, csv, map[string]string{"mode": "commit"})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+
+	var report model.ImportReport
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if report.Created != 1 || report.Updated != 1 || report.Invalid != 1 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	codes := map[string]string{}
+	for _, f := range report.Errors[0].Fields {
+		codes[f.Field] = f.Code
+	}
+	if codes["custom.ipk"] != "number" || codes["custom.beasiswa"] != "oneof" {
+		t.Errorf("unexpected custom field errors: %+v", report.Errors[0].Fields)
+	}
+	if got := store.created[0].Custom; got["ipk"] != 3.75 || got["beasiswa"] != "KIP" {
+		t.Errorf("expected custom values parsed, got %v", got)
+	}
+	if got := store.replaced[0].Custom; got["beasiswa"] != "LPDP" || got["kota"] != "Bandung" || got["ipk"] != nil {
+		t.Errorf("expected kota kept from existing data, got %v", got)
+	}
+	if got := store.replaced[0].Tags; len(got) != 1 || got[0] != "narasumber" {
+		t.Errorf("expected tags kept from existing data, got %v", got)
+	}
+}
+
+func TestImportAlumni_MissingColumns(t *testing.T) {
+	app := newTestImportService(&mockAlumniStore{}, &mockImportJobRepo{})
+
+	resp := postImport(t, app, "nim,nama\nNIM00001,Budi\n", nil)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+	var problem model.Problem
+	if err := json.NewDecoder(resp.Body).Decode(&problem); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if problem.Code != "import_missing_columns" || !strings.Contains(problem.Detail, "jurusan, angkatan, tahun_lulus, email") {
+		t.Errorf("unexpected problem: %+v", problem)
+	}
+}
+
+func TestImportAlumni_LargeFileRunsInBackground(t *testing.T) {
+	store := &mockAlumniStore{}
+	jobs := &mockImportJobRepo{finished: make(chan struct{})}
+	app := newTestImportService(store, jobs)
+
+	var sb strings.Builder
+	sb.WriteString(importHeader)
+	rows := config.Defaults().Import.SyncRows + 1
+	for i := 0; i < rows; i++ {
+		fmt.Fprintf(&sb, "NIM%05d,Alumni %d,Manajemen,2018,2022,a%d@example.com\n", i, i, i)
+	}
+	resp := postImport(t, app, sb.String(), map[string]string{"mode": "commit"})
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d", resp.StatusCode)
+	}
+	if !strings.Contains(resp.Header.Get(fiber.HeaderLocation), "/api/alumni/import/") {
+		t.Errorf("expected Location header, got %q", resp.Header.Get(fiber.HeaderLocation))
+	}
+
+	select {
+	case <-jobs.finished:
+	case <-time.After(5 * time.Second):
+		t.Fatal("job import tidak selesai")
+	}
+	job, _ := jobs.GetByID(context.Background(), "")
+	if job.Total != rows || job.Report == nil || job.Report.Created != rows {
+		t.Errorf("unexpected job: %+v", job)
+	}
+}
+
+func TestImportAlumni_TrashedNIMRejected(t *testing.T) {
+	deletedAt := time.Now()
+	store := &mockAlumniStore{existing: map[string]model.Alumni{
+		"NIM00001": {ID: primitive.NewObjectID(), NIM: "NIM00001", DeletedAt: &deletedAt},
+	}}
+	app := newTestImportService(store, &mockImportJobRepo{})
+
+	csv := importHeader +
+		"NIM00001,Budi,Teknik Informatika,2018,2022,budi@example.com\n" +
+		"NIM00002,Sari,Sistem Informasi,2017,2021,sari@example.com\n"
+	resp := postImport(t, app, csv, map[string]string{"mode": "commit"})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	var report model.ImportReport
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if report.Valid != 1 || report.Invalid != 1 || report.Created != 1 || report.Updated != 0 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	if len(report.Errors) != 1 || report.Errors[0].Row != 2 || report.Errors[0].Fields[0].Code != "in_trash" {
+		t.Errorf("expected in_trash error on row 2, got %+v", report.Errors)
+	}
+	if len(store.replaced) != 0 {
+		t.Errorf("trashed alumni must not be replaced, got %+v", store.replaced)
+	}
+}
+
+func TestImportService_CloseFailsQueuedJob(t *testing.T) {
+	jobs := &mockImportJobRepo{finished: make(chan struct{})}
+	svc := NewImportService(jobs, config.Defaults().Import)
+	svc.store = &mockAlumniStore{}
+	job := &model.ImportJob{Status: model.ImportStatusQueued}
+	jobs.Create(context.Background(), job)
+
+	// antrean ditempati job lain yang tidak pernah selesai
+	svc.queue <- struct{}{}
+	svc.running.Add(1)
+	go svc.runJob(job.ID, historyActor{}, model.ImportModeCommit, "en", nil, nil, [][]string{{"NIM00001"}})
+
+	svc.Close(10 * time.Millisecond)
+	select {
+	case <-jobs.finished:
+	default:
+		t.Fatal("Close harus menunggu job sampai ditandai selesai")
+	}
+	got, _ := jobs.GetByID(context.Background(), "")
+	if got.Error != ImportInterrupted {
+		t.Errorf("expected job interrupted, got %+v", got)
+	}
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"crud_alumni/app/model"
+	"crud_alumni/apperror"
+	"crud_alumni/config"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetLogLevel godoc
+// @Summary Lihat level log aktif
+// @Description Menampilkan level log yang sedang digunakan aplikasi (admin saja)
+// @Tags Admin
+// @Produce json
+// @Success 200 {object} model.LogLevelResponse
+// @Security BearerAuth
+// @Router /admin/log-level [get]
+func GetLogLevel(c *fiber.Ctx) error {
+	return c.JSON(model.LogLevelResponse{Level: config.LogLevel()})
+}
+
+// SetLogLevel godoc
+// @Summary Ubah level log saat runtime
+// @Description Mengubah level log (trace/debug/info/warn/error/fatal/panic/disabled) tanpa restart (admin saja)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param level body model.LogLevelRequest true "Level log baru"
+// @Success 200 {object} model.LogLevelResponse
+// @Failure 400 {object} model.Problem
+// @Security BearerAuth
+// @Router /admin/log-level [put]
+func SetLogLevel(c *fiber.Ctx) error {
+	var req model.LogLevelRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperror.BadRequest(apperror.CodeInvalidBody)
+	}
+
+	previous := config.LogLevel()
+	if err := config.SetLogLevel(req.Level); err != nil {
+		return apperror.BadRequest(apperror.CodeInvalidLogLevel)
+	}
+
+	config.Logger.Warn().
+		Str("from", previous).
+		Str("to", config.LogLevel()).
+		Interface("by", c.Locals("username")).
+		Msg("log level diubah")
+
+	return c.JSON(model.LogLevelResponse{Level: config.LogLevel()})
+}
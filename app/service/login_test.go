@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -23,7 +24,7 @@ func hashPassword(t *testing.T, plain string) string {
 
 func TestLogin_Success(t *testing.T) {
 	// Mock repository function supaya tidak mengakses MongoDB nyata
-	repository.FindUserByUsernameOrEmailFunc = func(identifier string) (*model.User, string, error) {
+	repository.FindUserByUsernameOrEmailFunc = func(ctx context.Context, identifier string) (*model.User, string, error) {
 		u := &model.User{
 			ID:        primitive.NewObjectID(),
 			Username:  "alice",
@@ -42,7 +43,7 @@ func TestLogin_Success(t *testing.T) {
 		Password: "supersecret",
 	}
 
-	resp, err := Login(req)
+	resp, err := Login(context.Background(), req)
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -58,7 +59,7 @@ func TestLogin_Success(t *testing.T) {
 }
 
 func TestLogin_WrongPassword(t *testing.T) {
-	repository.FindUserByUsernameOrEmailFunc = func(identifier string) (*model.User, string, error) {
+	repository.FindUserByUsernameOrEmailFunc = func(ctx context.Context, identifier string) (*model.User, string, error) {
 		u := &model.User{
 			ID:       primitive.NewObjectID(),
 			Username: "bob",
@@ -74,14 +75,14 @@ func TestLogin_WrongPassword(t *testing.T) {
 		Username: "bob",
 		Password: "wrongpassword",
 	}
-	_, err := Login(req)
+	_, err := Login(context.Background(), req)
 	if err == nil {
 		t.Fatalf("expected error for wrong password, got nil")
 	}
 }
 
 func TestLogin_UserNotFound(t *testing.T) {
-	repository.FindUserByUsernameOrEmailFunc = func(identifier string) (*model.User, string, error) {
+	repository.FindUserByUsernameOrEmailFunc = func(ctx context.Context, identifier string) (*model.User, string, error) {
 		return nil, "", errors.New("not found")
 	}
 	defer func() { repository.FindUserByUsernameOrEmailFunc = nil }()
@@ -90,7 +91,7 @@ func TestLogin_UserNotFound(t *testing.T) {
 		Username: "nonexistent",
 		Password: "whatever",
 	}
-	_, err := Login(req)
+	_, err := Login(context.Background(), req)
 	if err == nil {
 		t.Fatalf("expected error when user not found, got nil")
 	}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"crud_alumni/app/model"
+	"crud_alumni/app/repository"
+	"crud_alumni/apperror"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+// cursorRequested - pagination cursor dipakai jika query memuat ?cursor
+// (boleh kosong untuk halaman pertama)
+func cursorRequested(c *fiber.Ctx) bool {
+	return c.Context().QueryArgs().Has("cursor")
+}
+
+// pageRequest membaca limit, sortBy, order, cursor, dan count untuk
+// pagination cursor. sortBy di luar allowed diganti defaultSort, sama seperti
+// whitelist sortBy di /alumni/pag.
+func pageRequest(c *fiber.Ctx, allowed []string, defaultSort, defaultOrder string) (repository.PageRequest, error) {
+	req := repository.PageRequest{
+		SortBy: c.Query("sortBy", defaultSort),
+		Order:  strings.ToLower(c.Query("order", defaultOrder)),
+		Limit:  defaultPageLimit,
+		Cursor: c.Query("cursor"),
+	}
+	if !slices.Contains(allowed, req.SortBy) {
+		req.SortBy = defaultSort
+	}
+	if req.Order != "desc" {
+		req.Order = "asc"
+	}
+	if raw := c.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 {
+			return req, apperror.BadRequest(apperror.CodeInvalidParam).WithArgs("limit")
+		}
+		req.Limit = min(limit, maxPageLimit)
+	}
+	if raw := c.Query("count"); raw != "" {
+		count, err := strconv.ParseBool(raw)
+		if err != nil {
+			return req, apperror.BadRequest(apperror.CodeInvalidParam).WithArgs("count")
+		}
+		req.Count = count
+	}
+	return req, nil
+}
+
+func cursorMeta[T any](req repository.PageRequest, page repository.Page[T]) model.CursorMeta {
+	return model.CursorMeta{
+		Limit:  req.Limit,
+		SortBy: req.SortBy,
+		Order:  req.Order,
+		Next:   page.Next,
+		Prev:   page.Prev,
+		Total:  page.Total,
+	}
+}
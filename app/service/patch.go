@@ -0,0 +1,37 @@
+package service
+
+import (
+	"crud_alumni/apperror"
+	"encoding/json"
+	"mime"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// mergePatchTypes - Content-Type yang diterima endpoint PATCH
+var mergePatchTypes = []string{"application/merge-patch+json", "application/json"}
+
+// mergePatchBody mengambil body PATCH (JSON Merge Patch, RFC 7396) setelah
+// memastikan Content-Type dan isinya berupa object JSON
+func mergePatchBody(c *fiber.Ctx) ([]byte, error) {
+	mediaType, _, _ := mime.ParseMediaType(string(c.Request().Header.ContentType()))
+	accepted := false
+	for _, t := range mergePatchTypes {
+		if mediaType == t {
+			accepted = true
+			break
+		}
+	}
+	if !accepted {
+		return nil, apperror.New(http.StatusUnsupportedMediaType, apperror.CodeUnsupportedMedia).
+			WithArgs("application/merge-patch+json, application/json")
+	}
+
+	body := c.Body()
+	var obj map[string]any
+	if err := json.Unmarshal(body, &obj); err != nil || obj == nil {
+		return nil, apperror.BadRequest(apperror.CodeInvalidBody)
+	}
+	return body, nil
+}
@@ -1,31 +1,62 @@
 package service
 
 import (
+	"context"
 	"crud_alumni/app/model"
 	"crud_alumni/app/repository"
+	"crud_alumni/apperror"
+	"crud_alumni/i18n"
+	"crud_alumni/mergepatch"
+	"crud_alumni/tracing"
+	"crud_alumni/validation"
 	"strconv"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 )
 
 // GetAllPekerjaan godoc
 // @Summary Dapatkan semua data pekerjaan
-// @Description Mengambil seluruh data pekerjaan dari database
+// @Description Mengambil seluruh data pekerjaan dari database. Dengan `cursor` (kosong untuk halaman pertama) hasilnya dipaginasi per halaman keyset dan berbentuk model.PekerjaanCursorResponse.
 // @Tags Pekerjaan
 // @Accept json
 // @Produce json
+// @Param cursor query string false "Cursor meta.next/meta.prev; kosong untuk halaman pertama"
+// @Param limit query int false "Mode cursor: jumlah data per halaman (default 20, maksimal 100)"
+// @Param sortBy query string false "Mode cursor: _id (default), nama_perusahaan, tanggal_mulai_kerja, alumni_id"
+// @Param order query string false "Mode cursor: asc/desc (default desc)"
+// @Param count query bool false "Mode cursor: hitung total data"
+// @Param fields query string false "Field yang dikembalikan, dipisah koma (mis. nama_perusahaan,posisi_jabatan)"
 // @Success 200 {array} model.Pekerjaan
-// @Failure 500 {object} map[string]interface{}
+// @Failure 400 {object} model.Problem
+// @Failure 500 {object} model.Problem
 // @Security BearerAuth
 // @Router /pekerjaan [get]
 func GetAllPekerjaan(c *fiber.Ctx) error {
-	data, err := repository.GetAllPekerjaan()
+	ctx, span := tracing.Start(c.UserContext(), "PekerjaanService.GetAllPekerjaan")
+	defer span.End()
+
+	read, err := readOptions(c, pekerjaanFields)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return err
 	}
-	return c.JSON(data)
+	if cursorRequested(c) {
+		req, err := pageRequest(c, []string{"_id", "nama_perusahaan", "tanggal_mulai_kerja", "alumni_id"}, "_id", "desc")
+		if err != nil {
+			return err
+		}
+		page, err := repository.PekerjaanPage(ctx, req, read.Repo)
+		if err != nil {
+			return repoError(err, apperror.CodePekerjaanNotFound)
+		}
+		return sendSparse(c, model.PekerjaanCursorResponse{Data: page.Items, Meta: cursorMeta(req, page)}, read.Keys)
+	}
+
+	data, err := repository.GetAllPekerjaan(ctx, read.Repo)
+	if err != nil {
+		return repoError(err, apperror.CodePekerjaanNotFound)
+	}
+	return sendSparse(c, data, read.Keys)
 }
 
 // GetPekerjaanByID godoc
@@ -35,21 +66,34 @@ func GetAllPekerjaan(c *fiber.Ctx) error {
 // @Accept json
 // @Produce json
 // @Param id path string true "ID pekerjaan"
+// @Param If-None-Match header string false "ETag terakhir; 304 jika data tidak berubah"
+// @Param fields query string false "Field yang dikembalikan, dipisah koma (mis. nama_perusahaan,posisi_jabatan)"
 // @Success 200 {object} model.Pekerjaan
-// @Failure 404 {object} map[string]interface{}
+// @Success 304 "Data tidak berubah"
+// @Failure 400 {object} model.Problem
+// @Failure 404 {object} model.Problem
 // @Security BearerAuth
 // @Router /pekerjaan/{id} [get]
 func GetPekerjaanByID(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "PekerjaanService.GetPekerjaanByID")
+	defer span.End()
+
+	read, err := readOptions(c, pekerjaanFields)
+	if err != nil {
+		return err
+	}
 	id := c.Params("id")
 
-	data, err := repository.GetPekerjaanByID(id)
+	data, err := repository.GetPekerjaanByID(ctx, id)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error": "Data tidak ditemukan",
-		})
+		return repoError(err, apperror.CodePekerjaanNotFound)
+	}
+	setETag(c, data.Version)
+	if notModified(c, data.Version) {
+		return c.SendStatus(fiber.StatusNotModified)
 	}
 
-	return c.JSON(data)
+	return sendSparse(c, data, read.Keys)
 }
 
 // GetPekerjaanByAlumniID godoc
@@ -59,118 +103,229 @@ func GetPekerjaanByID(c *fiber.Ctx) error {
 // @Accept json
 // @Produce json
 // @Param alumni_id path int true "ID Alumni"
+// @Param fields query string false "Field yang dikembalikan, dipisah koma (mis. nama_perusahaan,posisi_jabatan)"
 // @Success 200 {array} model.Pekerjaan
-// @Failure 400 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Failure 400 {object} model.Problem
+// @Failure 500 {object} model.Problem
 // @Security BearerAuth
 // @Router /pekerjaan/alumni/{alumni_id} [get]
 func GetPekerjaanByAlumniID(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "PekerjaanService.GetPekerjaanByAlumniID")
+	defer span.End()
+
 	alumniID, err := strconv.Atoi(c.Params("alumni_id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "alumni_id tidak valid",
-		})
+		return apperror.BadRequest(apperror.CodeInvalidParam).WithArgs("alumni_id")
+	}
+	read, err := readOptions(c, pekerjaanFields)
+	if err != nil {
+		return err
 	}
 
-	data, err := repository.GetPekerjaanByAlumniID(alumniID)
+	data, err := repository.GetPekerjaanByAlumniID(ctx, alumniID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return repoError(err, apperror.CodePekerjaanNotFound)
 	}
 
-	return c.JSON(data)
+	return sendSparse(c, data, read.Keys)
 }
 
 // CreatePekerjaan godoc
 // @Summary Tambahkan data pekerjaan
-// @Description Menambahkan data pekerjaan baru (admin saja)
+// @Description Menambahkan data pekerjaan baru (admin saja). Tanggal mulai kerja diisi tanggal hari ini oleh server.
 // @Tags Pekerjaan
 // @Accept json
 // @Produce json
 // @Param pekerjaan body model.Pekerjaan true "Data pekerjaan baru"
 // @Success 201 {object} map[string]interface{}
-// @Failure 400 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Failure 400 {object} model.Problem
+// @Failure 422 {object} model.Problem
+// @Failure 500 {object} model.Problem
 // @Security BearerAuth
 // @Router /pekerjaan [post]
 func CreatePekerjaan(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "PekerjaanService.CreatePekerjaan")
+	defer span.End()
+
 	var pekerjaan model.Pekerjaan
 	if err := c.BodyParser(&pekerjaan); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Gagal parse body",
-		})
+		return apperror.BadRequest(apperror.CodeInvalidBody)
+	}
+	// tanggal mulai selalu diisi server; diisi sebelum validasi supaya
+	// tanggal_selesai_kerja dibandingkan dengan nilai yang disimpan
+	pekerjaan.TanggalMulaiKerja = time.Now().Format("2006-01-02")
+	if err := validation.Struct(pekerjaan); err != nil {
+		return err
 	}
 
-	id, err := repository.CreatePekerjaan(pekerjaan)
+	id, err := repository.CreatePekerjaan(ctx, pekerjaan)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return repoError(err, apperror.CodePekerjaanNotFound)
+	}
+	// dibaca ulang karena repository mengisi status, tanggal, dan versi
+	if created, err := repository.GetPekerjaanByID(ctx, id.Hex()); err == nil {
+		recordHistory(c, model.HistoryEntry{Resource: model.ResourcePekerjaan, RecordID: id, Version: created.Version, Action: model.HistoryCreate}, nil, created)
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
-		"message": "Data pekerjaan berhasil ditambahkan",
+		"message": i18n.T(c, "pekerjaan.created"),
 		"id":      id.Hex(),
 	})
 }
 
 // UpdatePekerjaan godoc
-// @Summary Perbarui data pekerjaan
-// @Description Memperbarui data pekerjaan berdasarkan ID
+// @Summary Ganti data pekerjaan
+// @Description Mengganti seluruh data pekerjaan berdasarkan ID (full replacement). Field opsional yang tidak dikirim akan dihapus.
 // @Tags Pekerjaan
 // @Accept json
 // @Produce json
 // @Param id path string true "ID pekerjaan"
+// @Param If-Match header string false "ETag dari GET; 412 jika data sudah berubah"
 // @Param pekerjaan body model.Pekerjaan true "Data pekerjaan yang diperbarui"
 // @Success 200 {object} map[string]interface{}
-// @Failure 400 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Failure 400 {object} model.Problem
+// @Failure 404 {object} model.Problem
+// @Failure 412 {object} model.Problem
+// @Failure 422 {object} model.Problem
+// @Failure 500 {object} model.Problem
 // @Security BearerAuth
 // @Router /pekerjaan/{id} [put]
 func UpdatePekerjaan(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "PekerjaanService.UpdatePekerjaan")
+	defer span.End()
+
 	id := c.Params("id")
 
 	var pekerjaan model.Pekerjaan
 	if err := c.BodyParser(&pekerjaan); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Gagal parse body",
-		})
+		return apperror.BadRequest(apperror.CodeInvalidBody)
+	}
+	if err := validation.Struct(pekerjaan); err != nil {
+		return err
+	}
+
+	existing, err := pekerjaanForWrite(ctx, c, id)
+	if err != nil {
+		return err
+	}
+	keepPekerjaanMeta(&pekerjaan, existing)
+
+	if err := repository.ReplacePekerjaan(ctx, &pekerjaan); err != nil {
+		return repoError(err, apperror.CodePekerjaanNotFound)
+	}
+	recordHistory(c, model.HistoryEntry{Resource: model.ResourcePekerjaan, RecordID: pekerjaan.ID, Version: pekerjaan.Version, Action: model.HistoryUpdate}, existing, pekerjaan)
+	setETag(c, pekerjaan.Version)
+
+	return c.JSON(fiber.Map{
+		"message": i18n.T(c, "pekerjaan.updated"),
+		"data":    pekerjaan,
+	})
+}
+
+// PatchPekerjaan godoc
+// @Summary Ubah sebagian data pekerjaan
+// @Description Mengubah field tertentu saja dengan JSON Merge Patch (RFC 7396). Field bernilai null dihapus, field yang tidak dikirim tidak berubah.
+// @Tags Pekerjaan
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param id path string true "ID pekerjaan"
+// @Param If-Match header string false "ETag dari GET; 412 jika data sudah berubah"
+// @Param pekerjaan body model.Pekerjaan true "Field yang diubah"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} model.Problem
+// @Failure 404 {object} model.Problem
+// @Failure 412 {object} model.Problem
+// @Failure 415 {object} model.Problem
+// @Failure 422 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Security BearerAuth
+// @Router /pekerjaan/{id} [patch]
+func PatchPekerjaan(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "PekerjaanService.PatchPekerjaan")
+	defer span.End()
+
+	patch, err := mergePatchBody(c)
+	if err != nil {
+		return err
 	}
 
-	err := repository.UpdatePekerjaan(id, pekerjaan)
+	existing, err := pekerjaanForWrite(ctx, c, c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return err
+	}
+	pekerjaan := *existing
+	if err := mergepatch.ApplyTo(&pekerjaan, patch); err != nil {
+		return apperror.BadRequest(apperror.CodeInvalidBody)
+	}
+	keepPekerjaanMeta(&pekerjaan, existing)
+
+	if err := validation.Struct(pekerjaan); err != nil {
+		return err
+	}
+	if err := repository.ReplacePekerjaan(ctx, &pekerjaan); err != nil {
+		return repoError(err, apperror.CodePekerjaanNotFound)
 	}
+	recordHistory(c, model.HistoryEntry{Resource: model.ResourcePekerjaan, RecordID: pekerjaan.ID, Version: pekerjaan.Version, Action: model.HistoryUpdate}, existing, pekerjaan)
+	setETag(c, pekerjaan.Version)
 
 	return c.JSON(fiber.Map{
-		"message": "Data pekerjaan berhasil diperbarui",
+		"message": i18n.T(c, "pekerjaan.updated"),
+		"data":    pekerjaan,
 	})
 }
 
+// pekerjaanForWrite mengambil pekerjaan yang akan diubah/dihapus lalu
+// memeriksa If-Match terhadap versinya
+func pekerjaanForWrite(ctx context.Context, c *fiber.Ctx, id string) (*model.Pekerjaan, error) {
+	existing, err := repository.GetPekerjaanByID(ctx, id)
+	if err != nil {
+		return nil, repoError(err, apperror.CodePekerjaanNotFound)
+	}
+	if err := checkIfMatch(c, existing.Version); err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+// keepPekerjaanMeta mempertahankan field yang tidak boleh diubah client
+// (id, id lama, status soft delete, created_at, version)
+func keepPekerjaanMeta(p *model.Pekerjaan, existing *model.Pekerjaan) {
+	p.ID = existing.ID
+	p.LegacyID = existing.LegacyID
+	p.IsDellete = existing.IsDellete
+	p.CreatedAt = existing.CreatedAt
+	p.Version = existing.Version
+	p.DeletedAt = existing.DeletedAt
+}
+
 // DeletePekerjaan godoc
 // @Summary Hapus pekerjaan secara permanen
 // @Description Menghapus data pekerjaan secara hard delete
 // @Tags Pekerjaan
 // @Param id path string true "ID pekerjaan"
+// @Param If-Match header string false "ETag dari GET; 412 jika data sudah berubah"
 // @Success 200 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Failure 412 {object} model.Problem
+// @Failure 500 {object} model.Problem
 // @Security BearerAuth
 // @Router /pekerjaan/{id} [delete]
 func DeletePekerjaan(c *fiber.Ctx) error {
-	id := c.Params("id")
+	ctx, span := tracing.Start(c.UserContext(), "PekerjaanService.DeletePekerjaan")
+	defer span.End()
+
+	existing, err := pekerjaanForWrite(ctx, c, c.Params("id"))
+	if err != nil {
+		return err
+	}
 
-	err := repository.DeletePekerjaan(id)
+	err = repository.DeletePekerjaan(ctx, existing.ID.Hex(), existing.Version)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return repoError(err, apperror.CodePekerjaanNotFound)
 	}
+	recordHistory(c, model.HistoryEntry{Resource: model.ResourcePekerjaan, RecordID: existing.ID, Version: existing.Version, Action: model.HistoryPurge}, existing, nil)
 
 	return c.JSON(fiber.Map{
-		"message": "Data pekerjaan berhasil dihapus permanen",
+		"message": i18n.T(c, "pekerjaan.deleted"),
 	})
 }
 
@@ -179,22 +334,31 @@ func DeletePekerjaan(c *fiber.Ctx) error {
 // @Description Menghapus data pekerjaan tanpa benar-benar menghapus dari database
 // @Tags Pekerjaan
 // @Param id path string true "ID pekerjaan"
+// @Param If-Match header string false "ETag dari GET; 412 jika data sudah berubah"
 // @Success 200 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Failure 412 {object} model.Problem
+// @Failure 500 {object} model.Problem
 // @Security BearerAuth
 // @Router /pekerjaan/{id}/soft-delete [put]
 func SoftDeletePekerjaan(c *fiber.Ctx) error {
-	id := c.Params("id")
+	ctx, span := tracing.Start(c.UserContext(), "PekerjaanService.SoftDeletePekerjaan")
+	defer span.End()
+
+	existing, err := pekerjaanForWrite(ctx, c, c.Params("id"))
+	if err != nil {
+		return err
+	}
 
-	err := repository.SoftDeletePekerjaan(id)
+	err = repository.SoftDeletePekerjaan(ctx, existing.ID.Hex(), existing.Version)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return repoError(err, apperror.CodePekerjaanNotFound)
 	}
+	deleted, now := *existing, time.Now()
+	deleted.IsDellete, deleted.DeletedAt, deleted.Version = "yes", &now, existing.Version+1
+	recordHistory(c, model.HistoryEntry{Resource: model.ResourcePekerjaan, RecordID: existing.ID, Version: deleted.Version, Action: model.HistoryDelete}, existing, deleted)
 
 	return c.JSON(fiber.Map{
-		"message": "Data pekerjaan berhasil dihapus (soft delete)",
+		"message": i18n.T(c, "pekerjaan.soft_deleted"),
 	})
 }
 
@@ -203,39 +367,55 @@ func SoftDeletePekerjaan(c *fiber.Ctx) error {
 // @Description Mengembalikan data pekerjaan dari status soft delete
 // @Tags Pekerjaan
 // @Param id path string true "ID pekerjaan"
+// @Param If-Match header string false "ETag dari GET; 412 jika data sudah berubah"
 // @Success 200 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Failure 412 {object} model.Problem
+// @Failure 500 {object} model.Problem
 // @Security BearerAuth
 // @Router /pekerjaan/{id}/restore [put]
 func RestorePekerjaan(c *fiber.Ctx) error {
-	id := c.Params("id")
+	ctx, span := tracing.Start(c.UserContext(), "PekerjaanService.RestorePekerjaan")
+	defer span.End()
+
+	existing, err := pekerjaanForWrite(ctx, c, c.Params("id"))
+	if err != nil {
+		return err
+	}
 
-	err := repository.RestorePekerjaan(id)
+	err = repository.RestorePekerjaan(ctx, existing.ID.Hex(), existing.Version)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return repoError(err, apperror.CodePekerjaanNotFound)
 	}
+	restored := *existing
+	restored.IsDellete, restored.DeletedAt, restored.Version = "no", nil, existing.Version+1
+	recordHistory(c, model.HistoryEntry{Resource: model.ResourcePekerjaan, RecordID: existing.ID, Version: restored.Version, Action: model.HistoryRestore}, existing, restored)
 
 	return c.JSON(fiber.Map{
-		"message": "Data pekerjaan berhasil dipulihkan",
+		"message": i18n.T(c, "pekerjaan.restored"),
 	})
 }
 
 // GetTrashAll godoc
 // @Summary Lihat semua data pekerjaan yang dihapus (soft delete)
-// @Description Menampilkan daftar data pekerjaan yang masih tersimpan di trash
+// @Description Menampilkan daftar data pekerjaan yang masih tersimpan di trash beserta purges_at (waktu paling cepat dihapus permanen otomatis)
 // @Tags Pekerjaan
 // @Success 200 {array} model.Pekerjaan
-// @Failure 500 {object} map[string]interface{}
+// @Failure 500 {object} model.Problem
 // @Security BearerAuth
 // @Router /pekerjaan/trash [get]
 func GetTrashAll(c *fiber.Ctx) error {
-	data, err := repository.TrashAll()
+	ctx, span := tracing.Start(c.UserContext(), "PekerjaanService.GetTrashAll")
+	defer span.End()
+
+	data, err := repository.TrashAll(ctx)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return repoError(err, apperror.CodePekerjaanNotFound)
+	}
+	trash := settings.Trash
+	for i := range data {
+		if data[i].DeletedAt != nil {
+			data[i].PurgesAt = trash.PurgesAt(*data[i].DeletedAt)
+		}
 	}
 	return c.JSON(data)
 }
@@ -246,23 +426,22 @@ func GetTrashAll(c *fiber.Ctx) error {
 // @Tags Pekerjaan
 // @Param tahun path int true "Tahun pekerjaan"
 // @Success 200 {object} map[string]interface{}
-// @Failure 400 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Failure 400 {object} model.Problem
+// @Failure 500 {object} model.Problem
 // @Security BearerAuth
 // @Router /pekerjaan/tahun/{tahun} [get]
 func GetPekerjaanByTahun(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "PekerjaanService.GetPekerjaanByTahun")
+	defer span.End()
+
 	tahun, err := strconv.Atoi(c.Params("tahun"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Format tahun tidak valid",
-		})
+		return apperror.BadRequest(apperror.CodeInvalidParam).WithArgs("tahun")
 	}
 
-	result, err := repository.GetPekerjaanByTahun(tahun)
+	result, err := repository.GetPekerjaanByTahun(ctx, tahun)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return repoError(err, apperror.CodePekerjaanNotFound)
 	}
 
 	return c.JSON(result)
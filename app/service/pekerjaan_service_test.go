@@ -0,0 +1,29 @@
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"crud_alumni/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestCreatePekerjaan_ValidationFailed(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+	app.Post("/pekerjaan", CreatePekerjaan)
+
+	// body invalid harus ditolak sebelum menyentuh database
+	body := `{"alumni_id":1,"nama_perusahaan":"","posisi_jabatan":"Dev","bidang_industri":"TI","lokasi_kerja":"Bandung","status_pekerjaan":"tetap","tanggal_selesai_kerja":"2001-01-01"}`
+	req := httptest.NewRequest(http.MethodPost, "/pekerjaan", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("expected 422, got %d", resp.StatusCode)
+	}
+}
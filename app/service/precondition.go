@@ -0,0 +1,55 @@
+package service
+
+import (
+	"crud_alumni/apperror"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// etag - ETag kuat dari versi dokumen
+func etag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+func setETag(c *fiber.Ctx, version int64) {
+	c.Set(fiber.HeaderETag, etag(version))
+}
+
+// notModified bernilai true jika If-None-Match cocok dengan versi saat ini,
+// sehingga handler cukup membalas 304 tanpa body
+func notModified(c *fiber.Ctx, version int64) bool {
+	header := c.Get(fiber.HeaderIfNoneMatch)
+	return header != "" && matchETag(header, etag(version), true)
+}
+
+// checkIfMatch mengembalikan 412 jika client mengirim If-Match yang tidak
+// cocok dengan versi dokumen saat ini. Tanpa If-Match tidak ada pengecekan.
+func checkIfMatch(c *fiber.Ctx, version int64) error {
+	header := c.Get(fiber.HeaderIfMatch)
+	if header == "" || matchETag(header, etag(version), false) {
+		return nil
+	}
+	return apperror.New(http.StatusPreconditionFailed, apperror.CodePreconditionFailed)
+}
+
+// matchETag mencocokkan daftar ETag di header (dipisah koma, "*" cocok
+// dengan apa pun). If-None-Match memakai perbandingan lemah (prefix W/
+// diabaikan), If-Match memakai perbandingan kuat.
+func matchETag(header, current string, weak bool) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return true
+		}
+		if weak {
+			tag = strings.TrimPrefix(tag, "W/")
+		}
+		if tag == current {
+			return true
+		}
+	}
+	return false
+}
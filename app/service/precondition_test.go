@@ -0,0 +1,82 @@
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"crud_alumni/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestMatchETag(t *testing.T) {
+	cases := []struct {
+		header string
+		weak   bool
+		want   bool
+	}{
+		{`"3"`, false, true},
+		{`"2", "3"`, false, true},
+		{`*`, false, true},
+		{`"4"`, false, false},
+		{`W/"3"`, false, false},
+		{`W/"3"`, true, true},
+	}
+	for _, tc := range cases {
+		if got := matchETag(tc.header, etag(3), tc.weak); got != tc.want {
+			t.Errorf("matchETag(%s, weak=%v) = %v, want %v", tc.header, tc.weak, got, tc.want)
+		}
+	}
+}
+
+func TestConditionalRequests(t *testing.T) {
+	const version = 5
+	app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+	app.Get("/doc", func(c *fiber.Ctx) error {
+		setETag(c, version)
+		if notModified(c, version) {
+			return c.SendStatus(fiber.StatusNotModified)
+		}
+		return c.JSON(fiber.Map{"version": version})
+	})
+	app.Put("/doc", func(c *fiber.Ctx) error {
+		if err := checkIfMatch(c, version); err != nil {
+			return err
+		}
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	cases := []struct {
+		name   string
+		method string
+		header string
+		value  string
+		want   int
+	}{
+		{"GET tanpa header", http.MethodGet, "", "", http.StatusOK},
+		{"GET If-None-Match cocok", http.MethodGet, fiber.HeaderIfNoneMatch, `"5"`, http.StatusNotModified},
+		{"GET If-None-Match lama", http.MethodGet, fiber.HeaderIfNoneMatch, `"4"`, http.StatusOK},
+		{"PUT tanpa If-Match", http.MethodPut, "", "", http.StatusOK},
+		{"PUT If-Match cocok", http.MethodPut, fiber.HeaderIfMatch, `"5"`, http.StatusOK},
+		{"PUT If-Match lama", http.MethodPut, fiber.HeaderIfMatch, `"4"`, http.StatusPreconditionFailed},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, "/doc", nil)
+			if tc.header != "" {
+				req.Header.Set(tc.header, tc.value)
+			}
+			resp, err := app.Test(req, -1)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			if resp.StatusCode != tc.want {
+				t.Errorf("expected %d, got %d", tc.want, resp.StatusCode)
+			}
+			if tc.method == http.MethodGet && resp.Header.Get(fiber.HeaderETag) != `"5"` {
+				t.Errorf("expected ETag \"5\", got %q", resp.Header.Get(fiber.HeaderETag))
+			}
+		})
+	}
+}
@@ -0,0 +1,301 @@
+package service
+
+import (
+	"context"
+	"crud_alumni/app/model"
+	"crud_alumni/app/repository"
+	"crud_alumni/apperror"
+	"crud_alumni/config"
+	"crud_alumni/metrics"
+	"crud_alumni/tracing"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// TrashTarget - satu jenis data di trash yang di-purge otomatis setelah
+// masa retensi. Expired dipakai untuk laporan dry-run, Purge untuk hapus.
+type TrashTarget struct {
+	Name string
+	// Expired - paling banyak limit data yang lewat cutoff (terlama lebih
+	// dulu) beserta jumlah seluruhnya
+	Expired func(ctx context.Context, cutoff time.Time, limit int64) ([]model.TrashItem, int64, error)
+	// Purge menghapus permanen data yang lewat cutoff beserta relasinya dan
+	// mengembalikan jumlah yang terhapus per jenis data (mis. alumni, files)
+	Purge func(ctx context.Context, cutoff time.Time) (map[string]int64, error)
+}
+
+const (
+	// purgeReportItems - maksimal item per target di laporan dry-run; count
+	// tetap berisi jumlah seluruhnya
+	purgeReportItems = 100
+	// purgeBatch - jumlah data yang dibaca sekali jalan saat purge
+	purgeBatch = 500
+)
+
+type PurgeService struct {
+	Trash   config.TrashConfig
+	Targets []TrashTarget
+	now     func() time.Time
+	audit   func(ctx context.Context, e *model.AuditEntry) error
+}
+
+func NewPurgeService(trash config.TrashConfig, targets ...TrashTarget) *PurgeService {
+	return &PurgeService{Trash: trash, Targets: targets, now: time.Now, audit: repository.AppendAudit}
+}
+
+// DefaultTrashTargets - alumni dan pekerjaan yang ada di trash. Alumni
+// di-purge beserta pekerjaannya (lihat purgeAlumniRelations); file akun user
+// dengan email yang sama tidak dihapus dan dilaporkan di unlinked.
+func DefaultTrashTargets() []TrashTarget {
+	return []TrashTarget{
+		{
+			Name: "alumni",
+			Expired: func(ctx context.Context, cutoff time.Time, limit int64) ([]model.TrashItem, int64, error) {
+				total, err := repository.CountExpiredTrashAlumni(ctx, cutoff)
+				if err != nil || total == 0 {
+					return []model.TrashItem{}, total, err
+				}
+				list, err := repository.ExpiredTrashAlumni(ctx, cutoff, limit)
+				if err != nil {
+					return nil, 0, err
+				}
+				items := make([]model.TrashItem, 0, len(list))
+				for _, a := range list {
+					pekerjaan, files, err := repository.AlumniRelationCounts(ctx, a)
+					if err != nil {
+						return nil, 0, err
+					}
+					items = append(items, model.TrashItem{
+						ID: a.ID.Hex(), Label: a.NIM + " - " + a.Nama, DeletedAt: *a.DeletedAt,
+						Related: nonZeroCounts("pekerjaan", pekerjaan), Unlinked: nonZeroCounts("files", files),
+					})
+				}
+				return items, total, nil
+			},
+			Purge: func(ctx context.Context, cutoff time.Time) (map[string]int64, error) {
+				return purgeExpired(ctx, cutoff, repository.ExpiredTrashAlumni, func(ctx context.Context, a model.Alumni) (map[string]int64, error) {
+					if err := repository.PurgeAlumni(ctx, a.ID.Hex(), a.Version); err != nil {
+						return nil, err
+					}
+					writeHistory(ctx, systemActor, model.HistoryEntry{Resource: model.ResourceAlumni, RecordID: a.ID, Version: a.Version, Action: model.HistoryPurge}, a, nil)
+					purged, err := purgeAlumniRelations(ctx, systemActor, a)
+					purged["alumni"] = 1
+					return purged, err
+				})
+			},
+		},
+		{
+			Name: "pekerjaan",
+			Expired: func(ctx context.Context, cutoff time.Time, limit int64) ([]model.TrashItem, int64, error) {
+				total, err := repository.CountExpiredTrashPekerjaan(ctx, cutoff)
+				if err != nil || total == 0 {
+					return []model.TrashItem{}, total, err
+				}
+				list, err := repository.ExpiredTrashPekerjaan(ctx, cutoff, limit)
+				if err != nil {
+					return nil, 0, err
+				}
+				items := make([]model.TrashItem, 0, len(list))
+				for _, p := range list {
+					items = append(items, model.TrashItem{ID: p.ID.Hex(), Label: p.NamaPerusahaan + " - " + p.PosisiJabatan, DeletedAt: *p.DeletedAt})
+				}
+				return items, total, nil
+			},
+			Purge: func(ctx context.Context, cutoff time.Time) (map[string]int64, error) {
+				return purgeExpired(ctx, cutoff, repository.ExpiredTrashPekerjaan, func(ctx context.Context, p model.Pekerjaan) (map[string]int64, error) {
+					if err := repository.DeletePekerjaan(ctx, p.ID.Hex(), p.Version); err != nil {
+						return nil, err
+					}
+					writeHistory(ctx, systemActor, model.HistoryEntry{Resource: model.ResourcePekerjaan, RecordID: p.ID, Version: p.Version, Action: model.HistoryPurge}, p, nil)
+					return map[string]int64{"pekerjaan": 1}, nil
+				})
+			},
+		},
+	}
+}
+
+// purgeExpired membaca data yang lewat cutoff per batch dan menghapusnya satu
+// per satu dengan purge. Data yang gagal dihapus karena sudah berubah (mis.
+// baru di-restore) dilewati; purge berhenti jika satu batch tidak ada yang
+// terhapus supaya tidak berulang tanpa akhir.
+func purgeExpired[T any](ctx context.Context, cutoff time.Time,
+	expired func(context.Context, time.Time, int64) ([]T, error),
+	purge func(context.Context, T) (map[string]int64, error),
+) (map[string]int64, error) {
+	total := map[string]int64{}
+	for {
+		list, err := expired(ctx, cutoff, purgeBatch)
+		if err != nil {
+			return total, err
+		}
+		progressed := false
+		for _, item := range list {
+			purged, err := purge(ctx, item)
+			for kind, n := range purged {
+				total[kind] += n
+				progressed = true
+			}
+			if errors.Is(err, repository.ErrVersionConflict) || errors.Is(err, repository.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return total, err
+			}
+		}
+		if len(list) < purgeBatch || !progressed {
+			return total, nil
+		}
+	}
+}
+
+// systemActor - pelaku di riwayat untuk perubahan oleh server (purge terjadwal)
+var systemActor = historyActor{ID: model.AuditSystemActor}
+
+// purgeAlumniRelations menghapus pekerjaan milik alumni a yang baru di-purge
+// dan mencatat purge setiap pekerjaan di riwayat atas nama actor. File tidak
+// ikut dihapus karena dimiliki akun user. Hasilnya jumlah per jenis data.
+func purgeAlumniRelations(ctx context.Context, actor historyActor, a model.Alumni) (map[string]int64, error) {
+	pekerjaan, err := repository.DeleteAlumniRelations(ctx, a)
+	for _, p := range pekerjaan {
+		writeHistory(ctx, actor, model.HistoryEntry{Resource: model.ResourcePekerjaan, RecordID: p.ID, Version: p.Version, Action: model.HistoryPurge}, p, nil)
+	}
+	return map[string]int64{"pekerjaan": int64(len(pekerjaan))}, err
+}
+
+// nonZeroCounts - {kind: n}, nil jika n 0
+func nonZeroCounts(kind string, n int64) map[string]int64 {
+	if n == 0 {
+		return nil
+	}
+	return map[string]int64{kind: n}
+}
+
+func (s *PurgeService) cutoff() time.Time {
+	return s.now().Add(-s.Trash.Retention)
+}
+
+// Start menjalankan purge di background setiap PurgeInterval (langsung sekali
+// saat start). Fungsi yang dikembalikan menghentikan scheduler dan menunggu
+// purge yang sedang berjalan selesai.
+func (s *PurgeService) Start() (stop func()) {
+	if s.Trash.Retention <= 0 {
+		config.Logger.Info().Msg("purge trash otomatis nonaktif (trash.retention = 0)")
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(s.Trash.PurgeInterval)
+		defer ticker.Stop()
+		for {
+			s.Run(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// Run menghapus permanen semua data di trash yang melewati masa retensi.
+// Error satu target dicatat di log dan tidak menghentikan target lain; data
+// yang sudah terhapus sebelum error tetap dihitung.
+func (s *PurgeService) Run(ctx context.Context) map[string]int64 {
+	ctx, span := tracing.Start(ctx, "PurgeService.Run")
+	defer span.End()
+
+	cutoff := s.cutoff()
+	purged := make(map[string]int64, len(s.Targets))
+	for _, t := range s.Targets {
+		counts, err := t.Purge(ctx, cutoff)
+		if err != nil {
+			config.Logger.Error().Err(err).Str("target", t.Name).Msg("purge trash gagal")
+		}
+		for kind, n := range counts {
+			purged[kind] += n
+			metrics.TrashPurged.WithLabelValues(kind).Add(float64(n))
+		}
+		if counts[t.Name] > 0 {
+			config.Logger.Info().Str("target", t.Name).Interface("purged", counts).Time("cutoff", cutoff).Msg("purge trash")
+			s.recordAudit(ctx, t.Name, counts, cutoff)
+		}
+	}
+	return purged
+}
+
+// recordAudit mencatat purge otomatis di audit log atas nama server, beserta
+// jumlah relasi yang ikut terhapus
+func (s *PurgeService) recordAudit(ctx context.Context, target string, counts map[string]int64, cutoff time.Time) {
+	e := model.AuditEntry{
+		ActorID:  model.AuditSystemActor,
+		Action:   model.AuditTrashPurge,
+		Resource: target,
+		Detail:   map[string]string{"purged": strconv.FormatInt(counts[target], 10), "cutoff": cutoff.UTC().Format(time.RFC3339)},
+	}
+	for kind, n := range counts {
+		if kind != target && n > 0 {
+			e.Detail["purged_"+kind] = strconv.FormatInt(n, 10)
+		}
+	}
+	if err := s.audit(ctx, &e); err != nil {
+		config.Logger.Error().Err(err).Str("target", target).Msg("gagal menulis audit log purge trash")
+	}
+}
+
+// Report - laporan dry-run tanpa menghapus apa pun
+func (s *PurgeService) Report(ctx context.Context) (model.PurgeReport, error) {
+	now := s.now()
+	report := model.PurgeReport{
+		Enabled:     s.Trash.Retention > 0,
+		Retention:   s.Trash.Retention.String(),
+		Cutoff:      s.cutoff(),
+		GeneratedAt: now,
+		Targets:     []model.PurgeTargetReport{},
+	}
+	if !report.Enabled {
+		return report, nil
+	}
+
+	for _, t := range s.Targets {
+		items, total, err := t.Expired(ctx, report.Cutoff, purgeReportItems)
+		if err != nil {
+			return report, err
+		}
+		for i := range items {
+			items[i].PurgesAt = *s.Trash.PurgesAt(items[i].DeletedAt)
+		}
+		report.Targets = append(report.Targets, model.PurgeTargetReport{Target: t.Name, Count: total, Items: items})
+	}
+	return report, nil
+}
+
+// GetPurgeReport godoc
+// @Summary Laporan dry-run purge trash
+// @Description Menampilkan data di trash yang sudah melewati masa retensi dan akan dihapus permanen pada jadwal purge berikutnya, paling banyak 100 item per target (count berisi jumlah seluruhnya). Item alumni menyebut jumlah pekerjaan yang ikut terhapus (related) dan file akun user dengan email yang sama yang tidak ikut terhapus tetapi tidak lagi terhubung ke alumni (unlinked). Tidak menghapus apa pun.
+// @Tags Admin
+// @Produce json
+// @Success 200 {object} model.PurgeReport
+// @Failure 403 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Security BearerAuth
+// @Router /admin/trash/purge-report [get]
+func (s *PurgeService) GetPurgeReport(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "PurgeService.GetPurgeReport")
+	defer span.End()
+
+	report, err := s.Report(ctx)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	return c.JSON(report)
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"crud_alumni/app/model"
+	"crud_alumni/app/repository"
+	"crud_alumni/config"
+)
+
+func fakeTarget(name string, deleted []time.Time, purgeErr error) (TrashTarget, *time.Time) {
+	var gotCutoff time.Time
+	expired := func(cutoff time.Time) []model.TrashItem {
+		var items []model.TrashItem
+		for i, d := range deleted {
+			if !d.After(cutoff) {
+				items = append(items, model.TrashItem{ID: string(rune('a' + i)), DeletedAt: d})
+			}
+		}
+		return items
+	}
+	return TrashTarget{
+		Name: name,
+		Expired: func(ctx context.Context, cutoff time.Time, limit int64) ([]model.TrashItem, int64, error) {
+			items := expired(cutoff)
+			total := int64(len(items))
+			if total > limit {
+				items = items[:limit]
+			}
+			return items, total, nil
+		},
+		Purge: func(ctx context.Context, cutoff time.Time) (map[string]int64, error) {
+			gotCutoff = cutoff
+			if purgeErr != nil {
+				return nil, purgeErr
+			}
+			return map[string]int64{name: int64(len(expired(cutoff)))}, nil
+		},
+	}, &gotCutoff
+}
+
+func TestPurgeService_Report(t *testing.T) {
+	now := time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+	retention := 30 * 24 * time.Hour
+	target, _ := fakeTarget("alumni", []time.Time{
+		now.Add(-40 * 24 * time.Hour), // lewat retensi
+		now.Add(-10 * 24 * time.Hour), // masih disimpan
+	}, nil)
+
+	svc := NewPurgeService(config.TrashConfig{Retention: retention, PurgeInterval: time.Hour}, target)
+	svc.now = func() time.Time { return now }
+
+	report, err := svc.Report(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !report.Enabled || !report.Cutoff.Equal(now.Add(-retention)) {
+		t.Errorf("unexpected report header: %+v", report)
+	}
+	if len(report.Targets) != 1 || report.Targets[0].Count != 1 {
+		t.Fatalf("expected 1 item kedaluwarsa, got %+v", report.Targets)
+	}
+	item := report.Targets[0].Items[0]
+	if want := item.DeletedAt.Add(retention); !item.PurgesAt.Equal(want) {
+		t.Errorf("expected purges_at %s, got %s", want, item.PurgesAt)
+	}
+}
+
+func TestPurgeService_ReportLimit(t *testing.T) {
+	now := time.Now()
+	deleted := make([]time.Time, purgeReportItems+5)
+	for i := range deleted {
+		deleted[i] = now.Add(-48 * time.Hour)
+	}
+	target, _ := fakeTarget("pekerjaan", deleted, nil)
+	svc := NewPurgeService(config.TrashConfig{Retention: time.Hour, PurgeInterval: time.Hour}, target)
+
+	report, err := svc.Report(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := report.Targets[0]; got.Count != int64(len(deleted)) || len(got.Items) != purgeReportItems {
+		t.Errorf("expected count %d dengan %d item, got %d/%d", len(deleted), purgeReportItems, got.Count, len(got.Items))
+	}
+}
+
+func TestPurgeExpired(t *testing.T) {
+	// 3 batch penuh + sisa; satu data berubah (konflik versi) dan dilewati
+	remaining := purgeBatch*3 + 7
+	expired := func(ctx context.Context, cutoff time.Time, limit int64) ([]int, error) {
+		n := min(int(limit), remaining)
+		return make([]int, n), nil
+	}
+	calls := 0
+	purge := func(ctx context.Context, _ int) (map[string]int64, error) {
+		calls++
+		if calls == 1 {
+			return nil, repository.ErrVersionConflict
+		}
+		remaining--
+		return map[string]int64{"alumni": 1, "files": 2}, nil
+	}
+
+	total, err := purgeExpired(context.Background(), time.Now(), expired, purge)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total["alumni"] != purgeBatch*3+7 || total["files"] != 2*total["alumni"] || remaining != 0 {
+		t.Errorf("unexpected total %v (sisa %d)", total, remaining)
+	}
+
+	// batch tanpa progres tidak diulang terus
+	remaining, calls = purgeBatch, 0
+	_, err = purgeExpired(context.Background(), time.Now(), expired, func(ctx context.Context, _ int) (map[string]int64, error) {
+		calls++
+		return nil, repository.ErrVersionConflict
+	})
+	if err != nil || calls != purgeBatch {
+		t.Errorf("expected berhenti setelah satu batch, got %d panggilan (%v)", calls, err)
+	}
+}
+
+func TestPurgeService_ReportDisabled(t *testing.T) {
+	target, _ := fakeTarget("alumni", []time.Time{time.Now().Add(-time.Hour)}, nil)
+	svc := NewPurgeService(config.TrashConfig{}, target)
+
+	report, err := svc.Report(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if report.Enabled || len(report.Targets) != 0 {
+		t.Errorf("expected laporan kosong saat retensi 0, got %+v", report)
+	}
+}
+
+func TestPurgeService_RunContinuesAfterError(t *testing.T) {
+	now := time.Now()
+	broken, _ := fakeTarget("alumni", nil, errors.New("mongo down"))
+	ok, cutoff := fakeTarget("pekerjaan", []time.Time{now.Add(-48 * time.Hour)}, nil)
+
+	svc := NewPurgeService(config.TrashConfig{Retention: 24 * time.Hour, PurgeInterval: time.Hour}, broken, ok)
+	svc.now = func() time.Time { return now }
+	var audited []model.AuditEntry
+	svc.audit = func(ctx context.Context, e *model.AuditEntry) error {
+		audited = append(audited, *e)
+		return nil
+	}
+
+	purged := svc.Run(context.Background())
+	if purged["alumni"] != 0 {
+		t.Errorf("target yang gagal tidak boleh tercatat, got %v", purged)
+	}
+	if purged["pekerjaan"] != 1 {
+		t.Errorf("expected 1 pekerjaan di-purge, got %v", purged)
+	}
+	if !cutoff.Equal(now.Add(-24 * time.Hour)) {
+		t.Errorf("expected cutoff %s, got %s", now.Add(-24*time.Hour), *cutoff)
+	}
+	if len(audited) != 1 || audited[0].Resource != "pekerjaan" || audited[0].ActorID != model.AuditSystemActor || audited[0].Detail["purged"] != "1" {
+		t.Errorf("expected one audit entry for pekerjaan, got %+v", audited)
+	}
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"bytes"
+	"crud_alumni/app/repository"
+	"crud_alumni/apperror"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	defaultIncludeLimit = 10
+	maxIncludeLimit     = 50
+)
+
+// fieldSet - field yang boleh dipilih lewat ?fields=, nama JSON -> nama BSON
+type fieldSet map[string]string
+
+var (
+	alumniFields = fieldSet{
+		"id": "_id", "nim": "nim", "nama": "nama", "jurusan": "jurusan",
+		"angkatan": "angkatan", "tahun_lulus": "tahun_lulus", "email": "email",
+		"no_telepon": "no_telepon", "alamat": "alamat", "custom": "custom", "tags": "tags", "created_at": "created_at",
+		"updated_at": "updated_at", "version": "version", "score": "score",
+	}
+	pekerjaanFields = fieldSet{
+		"alumni_id": "alumni_id", "nama_perusahaan": "nama_perusahaan",
+		"posisi_jabatan": "posisi_jabatan", "bidang_industri": "bidang_industri",
+		"lokasi_kerja": "lokasi_kerja", "gaji_range": "gaji_range",
+		"tanggal_mulai_kerja": "tanggal_mulai_kerja", "tanggal_selesai_kerja": "tanggal_selesai_kerja",
+		"status_pekerjaan": "status_pekerjaan", "isdellete": "isdellete",
+		"deskripsi_pekerjaan": "deskripsi_pekerjaan", "created_at": "created_at",
+		"updated_at": "updated_at", "version": "version",
+	}
+	fileFields = fieldSet{
+		"id": "_id", "user_id": "user_id", "file_name": "file_name",
+		"original_name": "original_name", "file_path": "file_path",
+		"file_size": "file_size", "file_type": "file_type",
+		"category": "category", "uploaded_at": "uploaded_at",
+	}
+)
+
+// readParams - hasil ?fields= dan ?include=: Repo untuk query, Keys berisi key
+// JSON yang dipertahankan di response (nil berarti response utuh)
+type readParams struct {
+	Repo repository.ReadOptions
+	Keys []string
+}
+
+// readOptions membaca ?fields=nim,nama dan, jika includes diisi,
+// ?include=pekerjaan,files dan ?include_limit=. Field atau relasi yang tidak
+// dikenal dijawab 400 invalid_param.
+func readOptions(c *fiber.Ctx, fields fieldSet, includes ...string) (readParams, error) {
+	var p readParams
+	for _, key := range splitList(c.Query("fields")) {
+		bsonName, ok := fields[key]
+		if !ok {
+			return p, apperror.BadRequest(apperror.CodeInvalidParam).WithArgs("fields")
+		}
+		if !slices.Contains(p.Keys, key) {
+			p.Keys = append(p.Keys, key)
+			p.Repo.Fields = append(p.Repo.Fields, bsonName)
+		}
+	}
+	if p.Keys != nil && !slices.Contains(p.Keys, "id") {
+		p.Keys = append(p.Keys, "id")
+	}
+
+	if len(includes) == 0 {
+		return p, nil
+	}
+	for _, rel := range splitList(c.Query("include")) {
+		if !slices.Contains(includes, rel) {
+			return p, apperror.BadRequest(apperror.CodeInvalidParam).WithArgs("include")
+		}
+		if !slices.Contains(p.Repo.Include, rel) {
+			p.Repo.Include = append(p.Repo.Include, rel)
+			if p.Keys != nil {
+				p.Keys = append(p.Keys, rel)
+			}
+		}
+	}
+	p.Repo.IncludeLimit = defaultIncludeLimit
+	if raw := c.Query("include_limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 {
+			return p, apperror.BadRequest(apperror.CodeInvalidParam).WithArgs("include_limit")
+		}
+		p.Repo.IncludeLimit = min(limit, maxIncludeLimit)
+	}
+	return p, nil
+}
+
+// splitList - daftar dipisah koma, tanpa spasi dan elemen kosong
+func splitList(raw string) []string {
+	var list []string
+	for _, s := range strings.Split(raw, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			list = append(list, s)
+		}
+	}
+	return list
+}
+
+// sendSparse mengirim v sebagai JSON. Jika keys diisi, hanya key tersebut
+// yang dipertahankan di setiap item "data" (atau di v sendiri jika v adalah
+// item/daftar item); meta dan pembungkus lain tidak diubah.
+func sendSparse(c *fiber.Ctx, v any, keys []string) error {
+	if keys == nil {
+		return c.JSON(v)
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber() // angka besar tidak berubah menjadi float64
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return apperror.Internal(err)
+	}
+	if m, ok := doc.(map[string]any); ok {
+		if data, ok := m["data"]; ok {
+			m["data"] = pickKeys(data, keys)
+			return c.JSON(m)
+		}
+	}
+	return c.JSON(pickKeys(doc, keys))
+}
+
+// pickKeys - salinan objek (atau setiap objek di array) yang hanya berisi keys
+func pickKeys(doc any, keys []string) any {
+	switch v := doc.(type) {
+	case []any:
+		for i := range v {
+			v[i] = pickKeys(v[i], keys)
+		}
+		return v
+	case map[string]any:
+		picked := make(map[string]any, len(keys))
+		for _, k := range keys {
+			if val, ok := v[k]; ok {
+				picked[k] = val
+			}
+		}
+		return picked
+	}
+	return doc
+}
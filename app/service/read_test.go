@@ -0,0 +1,106 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"crud_alumni/app/repository"
+	"crud_alumni/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestReadOptions(t *testing.T) {
+	tests := []struct {
+		query  string
+		want   readParams
+		status int
+	}{
+		{query: "", want: readParams{Repo: repository.ReadOptions{IncludeLimit: defaultIncludeLimit}}},
+		{query: "fields=nim,%20nama,nim", want: readParams{
+			Repo: repository.ReadOptions{Fields: []string{"nim", "nama"}, IncludeLimit: defaultIncludeLimit},
+			Keys: []string{"nim", "nama", "id"},
+		}},
+		{query: "fields=id,nama&include=files&include_limit=500", want: readParams{
+			Repo: repository.ReadOptions{Fields: []string{"_id", "nama"}, Include: []string{"files"}, IncludeLimit: maxIncludeLimit},
+			Keys: []string{"id", "nama", "files"},
+		}},
+		{query: "fields=password", status: 400},
+		{query: "include=users", status: 400},
+		{query: "include=files&include_limit=0", status: 400},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+			var got readParams
+			app.Get("/", func(c *fiber.Ctx) error {
+				p, err := readOptions(c, alumniFields, repository.IncludePekerjaan, repository.IncludeFiles)
+				got = p
+				if err != nil {
+					return err
+				}
+				return c.SendStatus(200)
+			})
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/?"+tt.query, nil), -1)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			if tt.status != 0 {
+				if resp.StatusCode != tt.status {
+					t.Errorf("status = %d, mau %d", resp.StatusCode, tt.status)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readOptions = %+v, mau %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSendSparse(t *testing.T) {
+	app := fiber.New()
+	app.Get("/list", func(c *fiber.Ctx) error {
+		return sendSparse(c, fiber.Map{
+			"data": []fiber.Map{{"id": "a", "nim": "123", "nama": "Budi", "no_telepon": 81234567890123}},
+			"meta": fiber.Map{"total": 1},
+		}, []string{"nama", "no_telepon", "id"})
+	})
+	app.Get("/item", func(c *fiber.Ctx) error {
+		return sendSparse(c, fiber.Map{"id": "a", "nim": "123"}, []string{"id"})
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/list", nil), -1)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	var list map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	item := list["data"].([]any)[0].(map[string]any)
+	if _, ok := item["nim"]; ok || item["nama"] != "Budi" || len(item) != 3 {
+		t.Errorf("item = %v", item)
+	}
+	if list["meta"] == nil {
+		t.Errorf("meta harus tetap ada: %v", list)
+	}
+	if n, _ := item["no_telepon"].(float64); n != 81234567890123 {
+		t.Errorf("angka berubah: %v", item["no_telepon"])
+	}
+
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/item", nil), -1)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	var single map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&single); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if !reflect.DeepEqual(single, map[string]any{"id": "a"}) {
+		t.Errorf("item = %v", single)
+	}
+}
@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"crud_alumni/app/model"
+	"crud_alumni/app/repository"
+	"crud_alumni/apperror"
+	"crud_alumni/i18n"
+	"crud_alumni/middleware"
+	"crud_alumni/tracing"
+	"crud_alumni/validation"
+	"errors"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// segmentParams - parameter query yang boleh disimpan di segment (selain
+// custom.<name>). Urutan dan pagination tidak termasuk definisi segment.
+var segmentParams = []string{
+	"search", "search_mode", "jurusan", "angkatan_min", "angkatan_max",
+	"tahun_lulus_min", "tahun_lulus_max", "has_email", "created_from", "created_to",
+	"updated_from", "updated_to", "has_pekerjaan", "currently_employed", "tag",
+}
+
+// alumniQueryValues - query string request untuk daftar alumni. Dengan
+// ?segment=<id>, parameter segment dipakai untuk setiap parameter yang tidak
+// dikirim di request; parameter yang dikirim menimpa parameter segment.
+func alumniQueryValues(c *fiber.Ctx) (url.Values, error) {
+	values := url.Values{}
+	c.Context().QueryArgs().VisitAll(func(key, value []byte) {
+		values.Add(string(key), string(value))
+	})
+
+	id := values.Get("segment")
+	values.Del("segment")
+	if id == "" {
+		return values, nil
+	}
+	seg, err := repository.GetSegment(c.UserContext(), id)
+	if err != nil {
+		return nil, repoError(err, apperror.CodeSegmentNotFound)
+	}
+	stored, err := url.ParseQuery(seg.Query)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	for key, list := range stored {
+		if _, ok := values[key]; !ok {
+			values[key] = list
+		}
+	}
+	return values, nil
+}
+
+// validateSegment merapikan dan memvalidasi s. Query boleh diawali "?";
+// parameter yang tidak dikenal ditolak, nilainya diperiksa dengan aturan yang
+// sama seperti /alumni/pag, lalu disimpan dalam bentuk kanonik (urut nama).
+func validateSegment(ctx context.Context, s *model.Segment, admin bool) error {
+	s.Name = strings.TrimSpace(s.Name)
+	s.Description = strings.TrimSpace(s.Description)
+	s.Query = strings.TrimPrefix(strings.TrimSpace(s.Query), "?")
+	if err := validation.Struct(s); err != nil {
+		return err
+	}
+
+	values, err := url.ParseQuery(s.Query)
+	if err != nil {
+		return apperror.BadRequest(apperror.CodeInvalidParam).WithArgs("query")
+	}
+	var fields []apperror.FieldError
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		if !slices.Contains(segmentParams, key) && !strings.HasPrefix(key, "custom.") {
+			fields = append(fields, apperror.FieldError{Field: "query", Code: "query", Args: []any{key}})
+		}
+	}
+	if len(fields) > 0 {
+		return apperror.Validation(fields...)
+	}
+	if _, err := parseAlumniQuery(ctx, values, admin); err != nil {
+		return err
+	}
+	s.Query = values.Encode()
+	return nil
+}
+
+// GetSegments godoc
+// @Summary Daftar segment alumni
+// @Description Filter alumni yang disimpan dengan nama. Segment dipakai lewat ?segment=<id> di /alumni/pag, /alumni/export, dan /alumni/tags.
+// @Tags Segment
+// @Produce json
+// @Success 200 {object} map[string]interface{} "data berisi []model.Segment"
+// @Failure 500 {object} model.Problem
+// @Security BearerAuth
+// @Router /segments [get]
+func GetSegments(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "SegmentService.GetSegments")
+	defer span.End()
+
+	list, err := repository.ListSegments(ctx)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	return c.JSON(fiber.Map{"success": true, "data": list})
+}
+
+// GetSegmentByID godoc
+// @Summary Detail segment alumni
+// @Tags Segment
+// @Produce json
+// @Param id path string true "ID segment"
+// @Success 200 {object} map[string]interface{} "data berisi model.Segment"
+// @Failure 400 {object} model.Problem
+// @Failure 404 {object} model.Problem
+// @Security BearerAuth
+// @Router /segments/{id} [get]
+func GetSegmentByID(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "SegmentService.GetSegmentByID")
+	defer span.End()
+
+	s, err := repository.GetSegment(ctx, c.Params("id"))
+	if err != nil {
+		return repoError(err, apperror.CodeSegmentNotFound)
+	}
+	setETag(c, s.Version)
+	return c.JSON(fiber.Map{"success": true, "data": s})
+}
+
+// CountSegment godoc
+// @Summary Jumlah alumni dalam segment
+// @Description Menghitung alumni aktif yang cocok dengan segment saat ini, beserta filter hasil penguraian query segment (custom field memakai definisi terbaru).
+// @Tags Segment
+// @Produce json
+// @Param id path string true "ID segment"
+// @Success 200 {object} map[string]interface{} "data berisi model.SegmentCount"
+// @Failure 400 {object} model.Problem
+// @Failure 404 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Security BearerAuth
+// @Router /segments/{id}/count [get]
+func CountSegment(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "SegmentService.CountSegment")
+	defer span.End()
+
+	s, err := repository.GetSegment(ctx, c.Params("id"))
+	if err != nil {
+		return repoError(err, apperror.CodeSegmentNotFound)
+	}
+	values, err := url.ParseQuery(s.Query)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	q, err := parseAlumniQuery(ctx, values, isAdmin(c))
+	if err != nil {
+		return err
+	}
+	total, err := repository.CountAlumni(ctx, q.Search, q.Filter)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	return c.JSON(fiber.Map{"success": true, "data": model.SegmentCount{
+		Total: total, Search: q.Search.Query, SearchMode: q.Search.Mode, Filter: &q.Filter,
+	}})
+}
+
+// CreateSegment godoc
+// @Summary Simpan segment alumni
+// @Description Admin menyimpan filter alumni dengan nama. query memakai parameter yang sama dengan /alumni/pag (search, search_mode, jurusan, angkatan_*, tahun_lulus_*, has_email, created_*, updated_*, has_pekerjaan, currently_employed, tag, custom.<name>), mis. "angkatan_min=2019&tag=penerima beasiswa".
+// @Tags Segment
+// @Accept json
+// @Produce json
+// @Param body body model.Segment true "Nama, deskripsi, dan query segment"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} model.Problem
+// @Failure 409 {object} model.Problem
+// @Failure 422 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Security BearerAuth
+// @Router /admin/segments [post]
+func CreateSegment(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "SegmentService.CreateSegment")
+	defer span.End()
+
+	var s model.Segment
+	if err := c.BodyParser(&s); err != nil {
+		return apperror.BadRequest(apperror.CodeInvalidBody)
+	}
+	if err := validateSegment(ctx, &s, isAdmin(c)); err != nil {
+		return err
+	}
+	s.CreatedBy, _ = c.Locals("user_id").(string)
+	if err := repository.CreateSegment(ctx, &s); err != nil {
+		if errors.Is(err, repository.ErrDuplicate) {
+			return apperror.New(http.StatusConflict, apperror.CodeSegmentExists).WithArgs(s.Name)
+		}
+		return apperror.Internal(err)
+	}
+	setETag(c, s.Version)
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"success": true, "data": s})
+}
+
+// UpdateSegment godoc
+// @Summary Ubah segment alumni
+// @Description Admin mengganti nama, deskripsi, dan query segment.
+// @Tags Segment
+// @Accept json
+// @Produce json
+// @Param id path string true "ID segment"
+// @Param If-Match header string false "ETag; 412 jika data sudah berubah"
+// @Param body body model.Segment true "Nama, deskripsi, dan query segment"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} model.Problem
+// @Failure 404 {object} model.Problem
+// @Failure 409 {object} model.Problem
+// @Failure 412 {object} model.Problem
+// @Failure 422 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Security BearerAuth
+// @Router /admin/segments/{id} [put]
+func UpdateSegment(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "SegmentService.UpdateSegment")
+	defer span.End()
+
+	var s model.Segment
+	if err := c.BodyParser(&s); err != nil {
+		return apperror.BadRequest(apperror.CodeInvalidBody)
+	}
+	existing, err := repository.GetSegment(ctx, c.Params("id"))
+	if err != nil {
+		return repoError(err, apperror.CodeSegmentNotFound)
+	}
+	if err := checkIfMatch(c, existing.Version); err != nil {
+		return err
+	}
+	if err := validateSegment(ctx, &s, isAdmin(c)); err != nil {
+		return err
+	}
+	s.ID, s.CreatedBy, s.CreatedAt, s.Version = existing.ID, existing.CreatedBy, existing.CreatedAt, existing.Version
+	if err := repository.UpdateSegment(ctx, &s); err != nil {
+		if errors.Is(err, repository.ErrDuplicate) {
+			return apperror.New(http.StatusConflict, apperror.CodeSegmentExists).WithArgs(s.Name)
+		}
+		return repoError(err, apperror.CodeSegmentNotFound)
+	}
+	setETag(c, s.Version)
+	return c.JSON(fiber.Map{"success": true, "data": s})
+}
+
+// DeleteSegment godoc
+// @Summary Hapus segment alumni
+// @Description Admin menghapus segment. Data alumni tidak berubah.
+// @Tags Segment
+// @Produce json
+// @Param id path string true "ID segment"
+// @Param If-Match header string false "ETag; 412 jika data sudah berubah"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} model.Problem
+// @Failure 404 {object} model.Problem
+// @Failure 412 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Security BearerAuth
+// @Router /admin/segments/{id} [delete]
+func DeleteSegment(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "SegmentService.DeleteSegment")
+	defer span.End()
+
+	existing, err := repository.GetSegment(ctx, c.Params("id"))
+	if err != nil {
+		return repoError(err, apperror.CodeSegmentNotFound)
+	}
+	if err := checkIfMatch(c, existing.Version); err != nil {
+		return err
+	}
+	middleware.AuditDetail(c, "name", existing.Name)
+	middleware.AuditDetail(c, "query", existing.Query)
+	if err := repository.DeleteSegment(ctx, existing.ID, existing.Version); err != nil {
+		return repoError(err, apperror.CodeSegmentNotFound)
+	}
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "segment.deleted")})
+}
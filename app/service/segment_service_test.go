@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"crud_alumni/app/model"
+	"crud_alumni/app/repository"
+	"crud_alumni/apperror"
+	"crud_alumni/middleware"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	got := normalizeTags([]string{" Penerima  Beasiswa ", "narasumber", "", "penerima beasiswa"})
+	if strings.Join(got, "|") != "penerima beasiswa|narasumber" {
+		t.Errorf("unexpected tags: %q", got)
+	}
+	if normalizeTags([]string{" "}) != nil {
+		t.Error("expected nil for empty tags")
+	}
+}
+
+func TestApplyTags(t *testing.T) {
+	got, gained, lost := applyTags([]string{"a", "b"}, []string{"b", "c"}, []string{"a"})
+	if strings.Join(got, "|") != "b|c" || !gained || !lost {
+		t.Errorf("unexpected result: %q gained=%v lost=%v", got, gained, lost)
+	}
+	if got, gained, lost := applyTags([]string{"a"}, []string{"a"}, nil); len(got) != 1 || gained || lost {
+		t.Errorf("existing tag must not count as gained: %q %v %v", got, gained, lost)
+	}
+}
+
+func TestValidateSegment(t *testing.T) {
+	s := model.Segment{Name: " Beasiswa 2019 ", Query: "?tag=Penerima+Beasiswa&angkatan_min=2019"}
+	if err := validateSegment(context.Background(), &s, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.Name != "Beasiswa 2019" || s.Query != "angkatan_min=2019&tag=Penerima+Beasiswa" {
+		t.Errorf("expected trimmed name and canonical query, got %+v", s)
+	}
+
+	// parameter di luar filter ditolak 422, nilai yang salah 400 seperti /alumni/pag
+	cases := map[string]int{
+		"page=2&sortBy=nim":  http.StatusUnprocessableEntity,
+		"segment=abc":        http.StatusUnprocessableEntity,
+		"angkatan_min=tahun": http.StatusBadRequest,
+		"":                   http.StatusUnprocessableEntity,
+	}
+	for query, status := range cases {
+		s := model.Segment{Name: "x", Query: query}
+		err := validateSegment(context.Background(), &s, true)
+		var appErr *apperror.Error
+		if !errors.As(err, &appErr) || appErr.Status != status {
+			t.Errorf("%q: expected %d, got %v", query, status, err)
+		}
+	}
+}
+
+func TestAlumniListQuery_Segment(t *testing.T) {
+	repository.GetSegmentFunc = func(ctx context.Context, id string) (model.Segment, error) {
+		if id != "seg1" {
+			return model.Segment{}, repository.ErrNotFound
+		}
+		return model.Segment{Query: "angkatan_min=2018&search=budi&tag=narasumber"}, nil
+	}
+	t.Cleanup(func() { repository.GetSegmentFunc = nil })
+
+	app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+	app.Get("/alumni/pag", func(c *fiber.Ctx) error {
+		q, err := alumniListQuery(c)
+		if err != nil {
+			return err
+		}
+		return c.JSON(fiber.Map{"search": q.Search.Query, "filter": q.Filter})
+	})
+
+	// parameter request menimpa parameter segment yang sama
+	req := httptest.NewRequest(http.MethodGet, "/alumni/pag?segment=seg1&angkatan_min=2020&tag=Alumni+Aktif", nil)
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	var got struct {
+		Search string             `json:"search"`
+		Filter model.AlumniFilter `json:"filter"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if got.Search != "budi" || got.Filter.AngkatanMin != 2020 || strings.Join(got.Filter.Tags, "|") != "alumni aktif" {
+		t.Errorf("unexpected query: %+v", got)
+	}
+
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/alumni/pag?segment=lain", nil), -1)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected 404 for unknown segment, got %d", resp.StatusCode)
+	}
+}
+
+func TestBulkTagAlumni_Rejected(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+	app.Post("/alumni/tags", BulkTagAlumni)
+
+	// ditolak sebelum menyentuh database
+	cases := []struct {
+		query, body string
+		want        int
+	}{
+		{"", `{"add": ["narasumber"]}`, http.StatusBadRequest},
+		{"angkatan_min=2019", `{"add": [" "]}`, http.StatusUnprocessableEntity},
+		{"angkatan_min=2019", `{}`, http.StatusUnprocessableEntity},
+		{"angkatan_min=2019", `{"add": ["a"], "remove": ["A"]}`, http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodPost, "/alumni/tags?"+tc.query, strings.NewReader(tc.body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		if resp.StatusCode != tc.want {
+			t.Errorf("%s %s: expected %d, got %d", tc.query, tc.body, tc.want, resp.StatusCode)
+		}
+	}
+}
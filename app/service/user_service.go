@@ -0,0 +1,136 @@
+package service
+
+import (
+	"crud_alumni/app/model"
+	"crud_alumni/app/repository"
+	"crud_alumni/apperror"
+	"crud_alumni/i18n"
+	"crud_alumni/middleware"
+	"crud_alumni/tracing"
+	"crud_alumni/validation"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// GetUsers godoc
+// @Summary Daftar user
+// @Description Menampilkan daftar user dengan pagination cursor (admin saja). Halaman pertama tanpa cursor; halaman berikutnya/sebelumnya memakai meta.next/meta.prev dengan sortBy dan order yang sama.
+// @Tags Admin
+// @Produce json
+// @Param cursor query string false "Cursor meta.next/meta.prev"
+// @Param limit query int false "Jumlah data per halaman (default 20, maksimal 100)"
+// @Param sortBy query string false "username (default), email, role, created_at"
+// @Param order query string false "asc/desc (default asc)"
+// @Param count query bool false "Hitung total user"
+// @Success 200 {object} model.UserCursorResponse
+// @Failure 400 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Security BearerAuth
+// @Router /admin/users [get]
+func GetUsers(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "UserService.GetUsers")
+	defer span.End()
+
+	req, err := pageRequest(c, []string{"username", "email", "role", "created_at"}, "username", "asc")
+	if err != nil {
+		return err
+	}
+	page, err := repository.UserPage(ctx, req)
+	if err != nil {
+		return repoError(err, apperror.CodeInternal) // daftar user tidak pernah ErrNotFound
+	}
+	return c.JSON(model.UserCursorResponse{Data: page.Items, Meta: cursorMeta(req, page)})
+}
+
+// UpdateUserRole godoc
+// @Summary Ubah role user
+// @Description Admin mengganti role user (admin atau user). Admin terakhir tidak bisa diturunkan menjadi user (409). Perubahan dicatat di riwayat user dan audit log. Token yang sudah terbit tetap memakai role lama sampai kedaluwarsa.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path string true "ID User"
+// @Param body body model.RoleUpdateRequest true "Role baru"
+// @Param If-Match header string false "ETag/versi user; 412 jika data sudah berubah"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} model.Problem
+// @Failure 404 {object} model.Problem
+// @Failure 409 {object} model.Problem
+// @Failure 412 {object} model.Problem
+// @Failure 422 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Security BearerAuth
+// @Router /admin/users/{id}/role [put]
+func UpdateUserRole(c *fiber.Ctx) error {
+	ctx, span := tracing.Start(c.UserContext(), "UserService.UpdateUserRole")
+	defer span.End()
+
+	var req model.RoleUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperror.BadRequest(apperror.CodeInvalidBody)
+	}
+	req.Role = strings.TrimSpace(req.Role)
+	if err := validation.Struct(req); err != nil {
+		return err
+	}
+
+	id := c.Params("id")
+	existing, err := repository.GetUserByID(ctx, id)
+	if err != nil {
+		return repoError(err, apperror.CodeUserNotFound)
+	}
+	if err := checkIfMatch(c, existing.Version); err != nil {
+		return err
+	}
+	if existing.Role == "admin" && req.Role != "admin" {
+		admins, err := repository.CountAdmins(ctx)
+		if err != nil {
+			return apperror.Internal(err)
+		}
+		if admins <= 1 {
+			return apperror.New(fiber.StatusConflict, apperror.CodeLastAdmin)
+		}
+	}
+	if err := repository.UpdateUserRole(ctx, id, req.Role, existing.Version); err != nil {
+		return repoError(err, apperror.CodeUserNotFound)
+	}
+
+	updated := *existing
+	updated.Role, updated.Version = req.Role, existing.Version+1
+	recordUserHistory(c, model.HistoryUpdate, existing, &updated)
+	middleware.RecordAudit(c, model.AuditEntry{
+		Action:     model.AuditRoleChange,
+		Resource:   model.ResourceUsers,
+		ResourceID: existing.ID.Hex(),
+		Status:     fiber.StatusOK,
+		Detail:     map[string]string{"username": existing.Username, "from": existing.Role, "to": req.Role},
+	})
+
+	setETag(c, updated.Version)
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "user.role_changed", req.Role), "data": updated})
+}
+
+// recordUserHistory mencatat perubahan user (before nil untuk create) tanpa
+// hash password. Penggantian password tetap tercatat sebagai perubahan field
+// password, hanya nilainya yang tidak disimpan.
+func recordUserHistory(c *fiber.Ctx, action string, before, after *model.User) {
+	var old any
+	passwordChanged := false
+	if before != nil {
+		old = historyUser(*before, false)
+		passwordChanged = before.PasswordHash != after.PasswordHash
+	}
+	e := model.HistoryEntry{Resource: model.ResourceUsers, RecordID: after.ID, Version: after.Version, Action: action}
+	recordHistory(c, e, old, historyUser(*after, passwordChanged))
+}
+
+// historyUser - isi user untuk riwayat; password hanya ditandai "changed"
+// pada sisi after jika hash-nya berganti
+func historyUser(u model.User, passwordChanged bool) bson.D {
+	d := bson.D{{Key: "_id", Value: u.ID}, {Key: "username", Value: u.Username}, {Key: "email", Value: u.Email}, {Key: "role", Value: u.Role}, {Key: "created_at", Value: u.CreatedAt}, {Key: "version", Value: u.Version}}
+	if passwordChanged {
+		d = append(d, bson.E{Key: "password", Value: "changed"})
+	}
+	return d
+}
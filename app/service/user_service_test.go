@@ -0,0 +1,35 @@
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"crud_alumni/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestUpdateUserRole_TrimsRole(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+	app.Put("/users/:id/role", UpdateUserRole)
+
+	// role dengan spasi lolos validasi setelah di-trim; id invalid ditolak
+	// sebelum menyentuh database
+	cases := map[string]int{
+		`{"role":" user "}`: http.StatusBadRequest,
+		`{"role":"owner"}`:  http.StatusUnprocessableEntity,
+	}
+	for body, want := range cases {
+		req := httptest.NewRequest(http.MethodPut, "/users/bukan-id/role", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		if resp.StatusCode != want {
+			t.Errorf("%s: expected %d, got %d", body, want, resp.StatusCode)
+		}
+	}
+}
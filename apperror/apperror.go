@@ -0,0 +1,107 @@
+// Package apperror - model error aplikasi dengan kode stabil yang bisa dibaca
+// mesin, status HTTP, key pesan, dan detail per field. Dirender sebagai
+// RFC 7807 problem+json oleh middleware.ErrorHandler, dengan pesan diambil
+// dari katalog i18n sesuai Accept-Language.
+package apperror
+
+import (
+	"errors"
+	"net/http"
+)
+
+// FieldError - detail kesalahan untuk satu field input. Message diisi
+// ErrorHandler dari katalog dengan key "field.<Code>" dan Args.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Args    []any  `json:"-"`
+}
+
+// Error - error aplikasi. Cause tidak pernah dikirim ke client, hanya dicatat di log.
+type Error struct {
+	Code   string
+	Status int
+	Key    string // key katalog pesan, default sama dengan Code
+	Args   []any  // argumen format pesan
+	Fields []FieldError
+	Cause  error
+}
+
+func (e *Error) Error() string {
+	if e.Cause != nil {
+		return e.Code + ": " + e.Cause.Error()
+	}
+	return e.Code
+}
+
+func (e *Error) Unwrap() error {
+	return e.Cause
+}
+
+// New membuat error dengan key pesan sama dengan kode
+func New(status int, code string) *Error {
+	return &Error{Code: code, Status: status, Key: code}
+}
+
+// WithKey memakai key pesan lain (tetap dengan kode yang sama) beserta argumennya
+func (e *Error) WithKey(key string, args ...any) *Error {
+	cp := *e
+	cp.Key = key
+	cp.Args = args
+	return &cp
+}
+
+// WithArgs mengisi argumen untuk pesan default kode ini
+func (e *Error) WithArgs(args ...any) *Error {
+	cp := *e
+	cp.Args = args
+	return &cp
+}
+
+// WithCause menyimpan error asal untuk logging
+func (e *Error) WithCause(err error) *Error {
+	cp := *e
+	cp.Cause = err
+	return &cp
+}
+
+// WithFields menambahkan detail per field
+func (e *Error) WithFields(fields ...FieldError) *Error {
+	cp := *e
+	cp.Fields = append(append([]FieldError(nil), e.Fields...), fields...)
+	return &cp
+}
+
+// Is membuat errors.Is(err, apperror.NotFound(...)) cocok berdasarkan kode
+func (e *Error) Is(target error) bool {
+	var t *Error
+	if errors.As(target, &t) {
+		return t.Code == e.Code
+	}
+	return false
+}
+
+// As mengembalikan *Error di dalam err, atau nil
+func As(err error) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	return nil
+}
+
+func BadRequest(code string) *Error   { return New(http.StatusBadRequest, code) }
+func Unauthorized(code string) *Error { return New(http.StatusUnauthorized, code) }
+func Forbidden(code string) *Error    { return New(http.StatusForbidden, code) }
+func NotFound(code string) *Error     { return New(http.StatusNotFound, code) }
+
+// Validation - 422 dengan detail semua field yang salah
+func Validation(fields ...FieldError) *Error {
+	return New(http.StatusUnprocessableEntity, CodeValidationFailed).WithFields(fields...)
+}
+
+// Internal membungkus error tak terduga tanpa membocorkan detailnya ke client
+func Internal(cause error) *Error {
+	return New(http.StatusInternalServerError, CodeInternal).WithCause(cause)
+}
@@ -0,0 +1,67 @@
+package apperror
+
+// Kode error stabil. Jangan mengganti nilai yang sudah ada karena dipakai client.
+const (
+	CodeInternal           = "internal_error"
+	CodeBadRequest         = "bad_request"
+	CodeInvalidBody        = "invalid_body"
+	CodeInvalidID          = "invalid_id"
+	CodeInvalidParam       = "invalid_param"
+	CodeValidationFailed   = "validation_failed"
+	CodeRouteNotFound      = "route_not_found"
+	CodeUnsupportedMedia   = "unsupported_media"
+	CodePreconditionFailed = "precondition_failed"
+	CodeInvalidCursor      = "invalid_cursor"
+
+	CodeTokenRequired      = "token_required"
+	CodeTokenMalformed     = "token_malformed"
+	CodeTokenInvalid       = "token_invalid"
+	CodeAdminOnly          = "admin_only"
+	CodeForbidden          = "forbidden"
+	CodeInvalidCredentials = "invalid_credentials"
+
+	CodeAlumniNotFound         = "alumni_not_found"
+	CodeAlumniNotInTrash       = "alumni_not_in_trash"
+	CodeAlumniNIMExists        = "alumni_nim_exists"
+	CodeMergeEmailConflict     = "merge_email_conflict"
+	CodePekerjaanNotFound      = "pekerjaan_not_found"
+	CodeFileNotFound           = "file_not_found"
+	CodeHistoryVersionNotFound = "history_version_not_found"
+	CodeUserNotFound           = "user_not_found"
+	CodeLastAdmin              = "last_admin"
+	CodeCustomFieldNotFound    = "custom_field_not_found"
+	CodeCustomFieldExists      = "custom_field_exists"
+	CodeSegmentNotFound        = "segment_not_found"
+	CodeSegmentExists          = "segment_exists"
+	CodeTagFilterRequired      = "tag_filter_required"
+
+	CodeUploadMissingFile     = "upload_missing_file"
+	CodeUploadUnknownCategory = "upload_unknown_category"
+	CodeUploadTypeNotAllowed  = "upload_type_not_allowed"
+	CodeUploadTooLarge        = "upload_too_large"
+	CodeFileNotCertificate    = "file_not_certificate"
+
+	CodeImportUnsupportedFormat = "import_unsupported_format"
+	CodeImportUnreadableFile    = "import_unreadable_file"
+	CodeImportMissingColumns    = "import_missing_columns"
+	CodeImportJobNotFound       = "import_job_not_found"
+	CodeExportTooManyRows       = "export_too_many_rows"
+
+	CodeInvalidLogLevel = "invalid_log_level"
+)
+
+// Codes - semua kode di atas; dipakai test katalog i18n untuk memastikan
+// setiap kode punya terjemahan
+var Codes = []string{
+	CodeInternal, CodeBadRequest, CodeInvalidBody, CodeInvalidID, CodeInvalidParam,
+	CodeValidationFailed, CodeRouteNotFound, CodeUnsupportedMedia, CodePreconditionFailed, CodeInvalidCursor,
+	CodeTokenRequired, CodeTokenMalformed, CodeTokenInvalid, CodeAdminOnly,
+	CodeForbidden, CodeInvalidCredentials,
+	CodeAlumniNotFound, CodeAlumniNotInTrash, CodeAlumniNIMExists, CodeMergeEmailConflict, CodePekerjaanNotFound, CodeFileNotFound, CodeHistoryVersionNotFound,
+	CodeUserNotFound, CodeLastAdmin, CodeCustomFieldNotFound, CodeCustomFieldExists, CodeSegmentNotFound, CodeSegmentExists,
+	CodeTagFilterRequired,
+	CodeUploadMissingFile, CodeUploadUnknownCategory, CodeUploadTypeNotAllowed, CodeUploadTooLarge, CodeFileNotCertificate,
+	CodeImportUnsupportedFormat, CodeImportUnreadableFile, CodeImportMissingColumns, CodeImportJobNotFound,
+	CodeExportTooManyRows,
+	CodeInvalidLogLevel,
+}
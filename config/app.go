@@ -18,15 +18,17 @@
 package config
 
 import (
-	"crud_alumni/route"
-
 	"github.com/gofiber/fiber/v2"
 )
 
-func App() *fiber.App {
+// App membuat instance Fiber. Route dan error handler (middleware.ErrorHandler)
+// dipasang dari main supaya package config bisa dipakai oleh service dan
+// middleware tanpa import cycle.
+func App(cfg *Config, errorHandler fiber.ErrorHandler) *fiber.App {
 	app := fiber.New(fiber.Config{
-		AppName: "CRUD Alumni (MongoDB Version)",
+		AppName:      "CRUD Alumni (MongoDB Version)",
+		ErrorHandler: errorHandler,
+		BodyLimit:    cfg.BodyLimit(),
 	})
-	route.SetupRoutes(app)
 	return app
 }
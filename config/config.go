@@ -0,0 +1,358 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.yaml.in/yaml/v3"
+)
+
+// defaultJWTSecret - secret lama yang dulu di-hardcode di utils/jwt.go.
+// Hanya boleh dipakai dengan APP_ENV=development (dengan peringatan saat
+// startup); di environment lain JWT_SECRET wajib diatur.
+const defaultJWTSecret = "secret-key-panjang-minimal-32-char"
+
+// Config - seluruh konfigurasi aplikasi. Urutan pemuatan: default di kode,
+// lalu file YAML opsional (CONFIG_FILE), lalu env / .env. Field bertag
+// secret:"true" disamarkan di tampilan admin.
+type Config struct {
+	App      AppConfig      `yaml:"app"`
+	Database DatabaseConfig `yaml:"database"`
+	JWT      JWTConfig      `yaml:"jwt"`
+	Upload   UploadConfig   `yaml:"upload"`
+	Alumni   AlumniConfig   `yaml:"alumni"`
+	Import   ImportConfig   `yaml:"import" env:"IMPORT_"`
+	Export   ExportConfig   `yaml:"export" env:"EXPORT_"`
+	Trash    TrashConfig    `yaml:"trash"`
+	Log      LogConfig      `yaml:"log"`
+	Metrics  MetricsConfig  `yaml:"metrics"`
+	Tracing  TracingConfig  `yaml:"tracing"`
+}
+
+type AppConfig struct {
+	Env            string `yaml:"env" env:"APP_ENV"` // development, staging, production
+	Port           string `yaml:"port" env:"APP_PORT"`
+	DrainSeconds   int    `yaml:"drain_seconds" env:"SHUTDOWN_DRAIN_SECONDS"`
+	MigrateOnStart bool   `yaml:"migrate_on_start" env:"MIGRATE_ON_START"`
+}
+
+type DatabaseConfig struct {
+	Timeout time.Duration `yaml:"timeout" env:"DB_TIMEOUT"`
+}
+
+type JWTConfig struct {
+	Secret string        `yaml:"secret" env:"JWT_SECRET" secret:"true"`
+	TTL    time.Duration `yaml:"ttl" env:"JWT_TTL"`
+}
+
+type UploadConfig struct {
+	Foto       UploadCategory `yaml:"foto" env:"UPLOAD_FOTO_"`
+	Sertifikat UploadCategory `yaml:"sertifikat" env:"UPLOAD_SERTIFIKAT_"`
+	MinFree    ByteSize       `yaml:"min_free" env:"UPLOAD_MIN_FREE"`
+}
+
+type UploadCategory struct {
+	Dir          string   `yaml:"dir" env:"DIR"`
+	MaxSize      ByteSize `yaml:"max_size" env:"MAX_SIZE"`
+	AllowedTypes []string `yaml:"allowed_types" env:"ALLOWED_TYPES"`
+}
+
+// AlumniConfig - aturan validasi data alumni
+type AlumniConfig struct {
+	NIMPattern string   `yaml:"nim_pattern" env:"ALUMNI_NIM_PATTERN"` // regex format NIM
+	MinYear    int      `yaml:"min_year" env:"ALUMNI_MIN_YEAR"`       // angkatan/tahun lulus paling awal yang masuk akal
+	Jurusan    []string `yaml:"jurusan" env:"ALUMNI_JURUSAN"`         // daftar jurusan yang diizinkan
+}
+
+// ImportConfig - batas import alumni dari CSV/XLSX
+type ImportConfig struct {
+	MaxSize  ByteSize `yaml:"max_size" env:"MAX_SIZE"`
+	SyncRows int      `yaml:"sync_rows" env:"SYNC_ROWS"` // file lebih besar diproses sebagai job background
+}
+
+// ExportConfig - batas export alumni ke CSV/XLSX/PDF
+type ExportConfig struct {
+	Timeout    time.Duration `yaml:"timeout" env:"TIMEOUT"`           // batas waktu satu export (query + streaming)
+	PDFMaxRows int           `yaml:"pdf_max_rows" env:"PDF_MAX_ROWS"` // PDF disusun di memori, jadi dibatasi
+}
+
+// TrashConfig - berapa lama data di trash disimpan sebelum dihapus permanen
+// oleh scheduler. Retention 0 menonaktifkan purge otomatis.
+type TrashConfig struct {
+	Retention     time.Duration `yaml:"retention" env:"TRASH_RETENTION"`
+	PurgeInterval time.Duration `yaml:"purge_interval" env:"TRASH_PURGE_INTERVAL"`
+}
+
+// PurgesAt - waktu paling cepat data yang dihapus pada deletedAt ikut di-purge,
+// nil jika purge otomatis nonaktif
+func (t TrashConfig) PurgesAt(deletedAt time.Time) *time.Time {
+	if t.Retention <= 0 {
+		return nil
+	}
+	at := deletedAt.Add(t.Retention)
+	return &at
+}
+
+type MetricsConfig struct {
+	Port  string `yaml:"port" env:"METRICS_PORT"`
+	Token string `yaml:"token" env:"METRICS_TOKEN" secret:"true"`
+}
+
+type TracingConfig struct {
+	Exporter    string `yaml:"exporter" env:"OTEL_TRACES_EXPORTER"`
+	File        string `yaml:"file" env:"OTEL_TRACES_FILE"`
+	ServiceName string `yaml:"service_name" env:"OTEL_SERVICE_NAME"`
+}
+
+// Category mengembalikan aturan upload untuk kategori "foto" / "sertifikat"
+func (u UploadConfig) Category(name string) (UploadCategory, bool) {
+	switch name {
+	case "foto":
+		return u.Foto, true
+	case "sertifikat":
+		return u.Sertifikat, true
+	}
+	return UploadCategory{}, false
+}
+
+// Allows mengecek apakah MIME type diizinkan untuk kategori ini
+func (u UploadCategory) Allows(contentType string) bool {
+	for _, t := range u.AllowedTypes {
+		if strings.EqualFold(t, contentType) {
+			return true
+		}
+	}
+	return false
+}
+
+// Defaults - nilai bawaan (sama dengan nilai yang dulu di-hardcode)
+func Defaults() Config {
+	return Config{
+		App: AppConfig{
+			Env:            "production",
+			Port:           "3000",
+			DrainSeconds:   5,
+			MigrateOnStart: true,
+		},
+		Database: DatabaseConfig{Timeout: 10 * time.Second},
+		JWT:      JWTConfig{Secret: defaultJWTSecret, TTL: 24 * time.Hour},
+		Upload: UploadConfig{
+			Foto: UploadCategory{
+				Dir:          "./uploads/foto",
+				MaxSize:      1 << 20,
+				AllowedTypes: []string{"image/jpeg", "image/png"},
+			},
+			Sertifikat: UploadCategory{
+				Dir:          "./uploads/sertifikat",
+				MaxSize:      2 << 20,
+				AllowedTypes: []string{"application/pdf"},
+			},
+			MinFree: 100 << 20,
+		},
+		Alumni: AlumniConfig{
+			NIMPattern: `^[A-Za-z0-9][A-Za-z0-9.\-]{4,19}$`,
+			MinYear:    1950,
+			Jurusan: []string{
+				"Teknik Informatika",
+				"Sistem Informasi",
+				"Teknik Elektro",
+				"Teknik Mesin",
+				"Teknik Sipil",
+				"Manajemen",
+				"Akuntansi",
+			},
+		},
+		Import: ImportConfig{
+			MaxSize:  10 << 20,
+			SyncRows: 200,
+		},
+		Export: ExportConfig{
+			Timeout:    5 * time.Minute,
+			PDFMaxRows: 5000,
+		},
+		Trash: TrashConfig{
+			Retention:     30 * 24 * time.Hour,
+			PurgeInterval: time.Hour,
+		},
+		Log: LogConfig{
+			Level:      "info",
+			Format:     "json",
+			File:       "logs/app.log",
+			Rotate:     "size",
+			MaxSizeMB:  50,
+			Interval:   24 * time.Hour,
+			MaxBackups: 10,
+			MaxAgeDays: 30,
+			Compress:   true,
+		},
+		Tracing: TracingConfig{
+			Exporter:    "none",
+			File:        "logs/traces.json",
+			ServiceName: "crud-alumni",
+		},
+	}
+}
+
+// Load memuat dan memvalidasi konfigurasi. Hasilnya diteruskan ke package
+// lain saat startup (lihat main.go), bukan dibaca dari variabel global.
+func Load() (*Config, error) {
+	cfg := Defaults()
+
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("gagal membaca CONFIG_FILE: %w", err)
+		}
+		if err := yaml.Unmarshal(raw, &cfg); err != nil {
+			return nil, fmt.Errorf("CONFIG_FILE tidak valid: %w", err)
+		}
+	}
+
+	if err := applyEnv(&cfg); err != nil {
+		return nil, err
+	}
+	cfg.normalize()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// normalize menyeragamkan nilai pilihan supaya "JSON" atau "Size" di env
+// tetap diterima
+func (c *Config) normalize() {
+	c.App.Env = strings.ToLower(c.App.Env)
+	c.Log.Level = strings.ToLower(c.Log.Level)
+	c.Log.Format = strings.ToLower(c.Log.Format)
+	c.Log.Rotate = strings.ToLower(c.Log.Rotate)
+	c.Tracing.Exporter = strings.ToLower(c.Tracing.Exporter)
+	if c.Log.File == "none" {
+		c.Log.File = ""
+	}
+}
+
+// Validate mengumpulkan semua kesalahan konfigurasi sekaligus
+func (c *Config) Validate() error {
+	var errs []error
+	add := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	switch c.App.Env {
+	case "development", "staging", "production":
+	default:
+		add("app.env (APP_ENV) harus development, staging, atau production")
+	}
+	if c.App.Port == "" {
+		add("app.port (APP_PORT) wajib diisi")
+	}
+	if c.App.DrainSeconds < 0 {
+		add("app.drain_seconds (SHUTDOWN_DRAIN_SECONDS) tidak boleh negatif")
+	}
+	if c.Database.Timeout <= 0 {
+		add("database.timeout (DB_TIMEOUT) harus > 0")
+	}
+	if len(c.JWT.Secret) < 32 {
+		add("jwt.secret (JWT_SECRET) minimal 32 karakter")
+	}
+	if c.UsesDefaultJWTSecret() && !c.IsDevelopment() {
+		add("jwt.secret (JWT_SECRET) wajib diatur jika APP_ENV bukan development")
+	}
+	if c.JWT.TTL <= 0 {
+		add("jwt.ttl (JWT_TTL) harus > 0")
+	}
+	for name, cat := range map[string]UploadCategory{"foto": c.Upload.Foto, "sertifikat": c.Upload.Sertifikat} {
+		if cat.Dir == "" {
+			add("upload.%s.dir wajib diisi", name)
+		}
+		if cat.MaxSize <= 0 {
+			add("upload.%s.max_size harus > 0", name)
+		}
+		if len(cat.AllowedTypes) == 0 {
+			add("upload.%s.allowed_types minimal satu MIME type", name)
+		}
+	}
+	if _, err := regexp.Compile(c.Alumni.NIMPattern); err != nil {
+		add("alumni.nim_pattern (ALUMNI_NIM_PATTERN) bukan regex valid: %v", err)
+	}
+	if c.Alumni.MinYear <= 0 || c.Alumni.MinYear > time.Now().Year() {
+		add("alumni.min_year (ALUMNI_MIN_YEAR) harus antara 1 dan tahun sekarang")
+	}
+	if len(c.Alumni.Jurusan) == 0 {
+		add("alumni.jurusan (ALUMNI_JURUSAN) minimal satu jurusan")
+	}
+	if c.Import.MaxSize <= 0 {
+		add("import.max_size (IMPORT_MAX_SIZE) harus > 0")
+	}
+	if c.Import.SyncRows < 0 {
+		add("import.sync_rows (IMPORT_SYNC_ROWS) tidak boleh negatif")
+	}
+	if c.Export.Timeout <= 0 {
+		add("export.timeout (EXPORT_TIMEOUT) harus > 0")
+	}
+	if c.Export.PDFMaxRows <= 0 {
+		add("export.pdf_max_rows (EXPORT_PDF_MAX_ROWS) harus > 0")
+	}
+	if c.Trash.Retention < 0 {
+		add("trash.retention (TRASH_RETENTION) tidak boleh negatif")
+	}
+	if c.Trash.Retention > 0 && c.Trash.PurgeInterval <= 0 {
+		add("trash.purge_interval (TRASH_PURGE_INTERVAL) harus > 0 jika retention aktif")
+	}
+	if _, err := parseLevel(c.Log.Level); err != nil {
+		add("log.level (LOG_LEVEL) tidak valid: %q", c.Log.Level)
+	}
+	if c.Log.Format != "json" && c.Log.Format != "console" {
+		add("log.format (LOG_FORMAT) harus json atau console")
+	}
+	switch c.Log.Rotate {
+	case "none":
+	case "size":
+		if c.Log.MaxSizeMB <= 0 {
+			add("log.max_size_mb (LOG_MAX_SIZE_MB) harus > 0 untuk rotate=size")
+		}
+	case "time":
+		if c.Log.Interval <= 0 {
+			add("log.interval (LOG_ROTATE_INTERVAL) harus > 0 untuk rotate=time")
+		}
+	default:
+		add("log.rotate (LOG_ROTATE) harus none, size, atau time")
+	}
+	switch c.Tracing.Exporter {
+	case "none", "otlp", "stdout", "file":
+	default:
+		add("tracing.exporter (OTEL_TRACES_EXPORTER) harus none, otlp, stdout, atau file")
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("konfigurasi tidak valid:\n%w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// BodyLimit - batas ukuran body request: cukup untuk upload/import terbesar
+// ditambah overhead multipart, minimal default Fiber (4MB)
+func (c *Config) BodyLimit() int {
+	limit := ByteSize(fiber.DefaultBodyLimit)
+	for _, size := range []ByteSize{c.Upload.Foto.MaxSize + 1<<20, c.Upload.Sertifikat.MaxSize + 1<<20, c.Import.MaxSize + 1<<20} {
+		if size > limit {
+			limit = size
+		}
+	}
+	return int(limit)
+}
+
+// IsDevelopment bernilai true untuk APP_ENV=development
+func (c *Config) IsDevelopment() bool {
+	return c.App.Env == "development"
+}
+
+// UsesDefaultJWTSecret bernilai true jika JWT_SECRET tidak diatur
+func (c *Config) UsesDefaultJWTSecret() bool {
+	return c.JWT.Secret == defaultJWTSecret
+}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoad_YAMLThenEnv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	yamlContent := `
+app:
+  port: "8080"
+upload:
+  foto:
+    max_size: 3MB
+jwt:
+  ttl: 2h
+`
+	if err := os.WriteFile(path, []byte(yamlContent), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CONFIG_FILE", path)
+	t.Setenv("APP_PORT", "9090")
+	t.Setenv("UPLOAD_SERTIFIKAT_ALLOWED_TYPES", "application/pdf, image/png")
+	t.Setenv("DB_TIMEOUT", "3s")
+	t.Setenv("JWT_SECRET", strings.Repeat("s", 32))
+	t.Setenv("LOG_FORMAT", "Console")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if cfg.App.Port != "9090" {
+		t.Errorf("env harus menimpa YAML, got port %s", cfg.App.Port)
+	}
+	if cfg.Upload.Foto.MaxSize != 3<<20 {
+		t.Errorf("expected max_size foto 3MB, got %s", cfg.Upload.Foto.MaxSize)
+	}
+	if cfg.JWT.TTL != 2*time.Hour {
+		t.Errorf("expected ttl 2h, got %s", cfg.JWT.TTL)
+	}
+	if cfg.Database.Timeout != 3*time.Second {
+		t.Errorf("expected timeout 3s, got %s", cfg.Database.Timeout)
+	}
+	if !cfg.Upload.Sertifikat.Allows("image/png") {
+		t.Errorf("expected image/png diizinkan untuk sertifikat, got %v", cfg.Upload.Sertifikat.AllowedTypes)
+	}
+	if cfg.Log.Format != "console" {
+		t.Errorf("expected LOG_FORMAT dinormalisasi, got %s", cfg.Log.Format)
+	}
+}
+
+func TestValidate_DefaultJWTSecret(t *testing.T) {
+	cfg := Defaults()
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "JWT_SECRET") {
+		t.Errorf("expected secret default ditolak di production, got %v", err)
+	}
+	cfg.App.Env = "development"
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("expected secret default boleh di development, got %v", err)
+	}
+}
+
+func TestValidate_ReportsAllErrors(t *testing.T) {
+	cfg := Defaults()
+	cfg.JWT.Secret = "pendek"
+	cfg.Database.Timeout = 0
+	cfg.Log.Format = "xml"
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+	for _, want := range []string{"JWT_SECRET", "DB_TIMEOUT", "LOG_FORMAT"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error menyebut %s, got %v", want, err)
+		}
+	}
+}
+
+func TestRedacted_HidesSecrets(t *testing.T) {
+	cfg := Defaults()
+	cfg.Metrics.Token = "token-rahasia"
+
+	view, err := cfg.Redacted()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	jwt := view["jwt"].(map[string]any)
+	if jwt["secret"] != "***" {
+		t.Errorf("expected jwt.secret disamarkan, got %v", jwt["secret"])
+	}
+	metrics := view["metrics"].(map[string]any)
+	if metrics["token"] != "***" {
+		t.Errorf("expected metrics.token disamarkan, got %v", metrics["token"])
+	}
+	if cfg.Metrics.Token != "token-rahasia" {
+		t.Errorf("Redacted tidak boleh mengubah config asli")
+	}
+}
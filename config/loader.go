@@ -0,0 +1,178 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// ByteSize - ukuran dalam byte, bisa ditulis "1MB", "512KB", atau angka biasa
+type ByteSize int64
+
+var byteUnits = []struct {
+	suffix string
+	size   int64
+}{
+	{"GB", 1 << 30},
+	{"MB", 1 << 20},
+	{"KB", 1 << 10},
+	{"B", 1},
+}
+
+func ParseByteSize(s string) (ByteSize, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	for _, u := range byteUnits {
+		if strings.HasSuffix(s, u.suffix) {
+			n, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(s, u.suffix)), 64)
+			if err != nil {
+				return 0, fmt.Errorf("ukuran tidak valid: %q", s)
+			}
+			return ByteSize(n * float64(u.size)), nil
+		}
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("ukuran tidak valid: %q", s)
+	}
+	return ByteSize(n), nil
+}
+
+func (b ByteSize) String() string {
+	for _, u := range byteUnits {
+		if int64(b) >= u.size && int64(b)%u.size == 0 {
+			return strconv.FormatInt(int64(b)/u.size, 10) + u.suffix
+		}
+	}
+	return strconv.FormatInt(int64(b), 10) + "B"
+}
+
+func (b ByteSize) MarshalYAML() (any, error) {
+	return b.String(), nil
+}
+
+func (b *ByteSize) UnmarshalYAML(node *yaml.Node) error {
+	v, err := ParseByteSize(node.Value)
+	if err != nil {
+		return err
+	}
+	*b = v
+	return nil
+}
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	byteSizeType = reflect.TypeOf(ByteSize(0))
+)
+
+// applyEnv menimpa field bertag env dengan nilai environment variable.
+// Untuk field struct, tag env dipakai sebagai prefix nama variabel.
+func applyEnv(cfg *Config) error {
+	return applyEnvStruct(reflect.ValueOf(cfg).Elem(), "")
+}
+
+func applyEnvStruct(v reflect.Value, prefix string) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := field.Tag.Get("env")
+		fv := v.Field(i)
+
+		if field.Type.Kind() == reflect.Struct {
+			if err := applyEnvStruct(fv, prefix+name); err != nil {
+				return err
+			}
+			continue
+		}
+		if name == "" {
+			continue
+		}
+
+		key := prefix + name
+		raw, ok := os.LookupEnv(key)
+		if !ok || raw == "" {
+			continue
+		}
+		if err := setField(fv, raw); err != nil {
+			return fmt.Errorf("env %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+func setField(fv reflect.Value, raw string) error {
+	switch {
+	case fv.Type() == durationType:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(int64(d))
+	case fv.Type() == byteSizeType:
+		b, err := ParseByteSize(raw)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(int64(b))
+	case fv.Kind() == reflect.String:
+		fv.SetString(strings.TrimSpace(raw))
+	case fv.Kind() == reflect.Int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(int64(n))
+	case fv.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	case fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() == reflect.String:
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		fv.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("tipe %s tidak didukung", fv.Type())
+	}
+	return nil
+}
+
+// Redacted mengembalikan konfigurasi dalam bentuk map (key sama dengan file
+// YAML) dengan field rahasia disamarkan, untuk endpoint debug admin
+func (c *Config) Redacted() (map[string]any, error) {
+	copied := *c
+	redactStruct(reflect.ValueOf(&copied).Elem())
+
+	raw, err := yaml.Marshal(copied)
+	if err != nil {
+		return nil, err
+	}
+	var out map[string]any
+	if err := yaml.Unmarshal(raw, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func redactStruct(v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		fv := v.Field(i)
+		if fv.Kind() == reflect.Struct {
+			redactStruct(fv)
+			continue
+		}
+		if t.Field(i).Tag.Get("secret") == "true" && fv.Kind() == reflect.String && fv.String() != "" {
+			fv.SetString("***")
+		}
+	}
+}
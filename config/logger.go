@@ -1,7 +1,12 @@
 package config
 
 import (
+	"errors"
+	"io"
+	"log"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -9,8 +14,92 @@ import (
 
 var Logger zerolog.Logger
 
-func InitLogger() {
-	file, _ := os.OpenFile("logs/app.log", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
-	Logger = zerolog.New(file).With().Timestamp().Logger()
+var errInvalidLogLevel = errors.New("level log tidak valid")
+
+// logFile menyimpan writer file aktif supaya bisa ditutup saat shutdown
+var logFile io.WriteCloser
+
+// LogConfig - pengaturan logger (level, format, tujuan output, rotasi)
+type LogConfig struct {
+	Level      string        `yaml:"level" env:"LOG_LEVEL"`               // trace/debug/info/warn/error
+	Format     string        `yaml:"format" env:"LOG_FORMAT"`             // json atau console
+	Stdout     bool          `yaml:"stdout" env:"LOG_STDOUT"`             // tulis ke stdout
+	File       string        `yaml:"file" env:"LOG_FILE"`                 // path file log, "none" = tanpa file
+	Rotate     string        `yaml:"rotate" env:"LOG_ROTATE"`             // none, size, atau time
+	MaxSizeMB  int           `yaml:"max_size_mb" env:"LOG_MAX_SIZE_MB"`   // batas ukuran segmen (rotate=size)
+	Interval   time.Duration `yaml:"interval" env:"LOG_ROTATE_INTERVAL"`  // interval rotasi (rotate=time)
+	MaxBackups int           `yaml:"max_backups" env:"LOG_MAX_BACKUPS"`   // jumlah segmen lama yang disimpan, 0 = tanpa batas
+	MaxAgeDays int           `yaml:"max_age_days" env:"LOG_MAX_AGE_DAYS"` // umur maksimal segmen lama, 0 = tanpa batas
+	Compress   bool          `yaml:"compress" env:"LOG_COMPRESS"`         // gzip segmen lama
+}
+
+func InitLogger(cfg LogConfig) {
 	zerolog.TimeFieldFormat = time.RFC3339
+
+	var writers []io.Writer
+	if cfg.Stdout {
+		writers = append(writers, formatWriter(os.Stdout, cfg.Format))
+	}
+	if cfg.File != "" {
+		rw, err := NewRotatingWriter(cfg)
+		if err != nil {
+			log.Println("⚠️  Gagal membuka file log:", err)
+		} else {
+			logFile = rw
+			writers = append(writers, formatWriter(rw, cfg.Format))
+		}
+	}
+	if len(writers) == 0 {
+		writers = append(writers, formatWriter(os.Stdout, cfg.Format))
+	}
+
+	if err := SetLogLevel(cfg.Level); err != nil {
+		log.Println("⚠️  LOG_LEVEL tidak valid, gunakan info:", cfg.Level)
+		zerolog.SetGlobalLevel(zerolog.InfoLevel)
+	}
+
+	Logger = zerolog.New(zerolog.MultiLevelWriter(writers...)).With().Timestamp().Logger()
+}
+
+// CloseLogger menutup file log aktif (dipanggil saat aplikasi berhenti)
+func CloseLogger() error {
+	if logFile == nil {
+		return nil
+	}
+	return logFile.Close()
+}
+
+// SetLogLevel mengubah level log global saat runtime
+func SetLogLevel(level string) error {
+	lvl, err := parseLevel(level)
+	if err != nil {
+		return err
+	}
+	zerolog.SetGlobalLevel(lvl)
+	return nil
+}
+
+func parseLevel(level string) (zerolog.Level, error) {
+	lvl, err := zerolog.ParseLevel(strings.ToLower(level))
+	if err != nil || lvl == zerolog.NoLevel {
+		return zerolog.NoLevel, errInvalidLogLevel
+	}
+	return lvl, nil
+}
+
+func formatWriter(w io.Writer, format string) io.Writer {
+	if format == "console" {
+		return zerolog.ConsoleWriter{Out: w, TimeFormat: time.RFC3339, NoColor: w != os.Stdout}
+	}
+	return w
+}
+
+// ensureLogDir membuat folder log dengan permission yang tidak world-writable
+func ensureLogDir(path string) error {
+	return os.MkdirAll(filepath.Dir(path), 0o750)
+}
+
+// LogLevel mengembalikan level log global yang sedang aktif
+func LogLevel() string {
+	return zerolog.GlobalLevel().String()
 }
@@ -0,0 +1,227 @@
+package config
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// backupTimeFormat - timestamp nama segmen sampai nanodetik agar dua rotasi
+// dalam detik yang sama tidak saling menimpa. time.Parse dengan layout tanpa
+// pecahan detik tetap menerima nama segmen lama maupun baru.
+const (
+	backupTimeFormat  = "20060102T150405"
+	backupStampFormat = backupTimeFormat + ".000000000"
+)
+
+// RotatingWriter - writer file log dengan rotasi berdasarkan ukuran atau waktu.
+// Segmen lama diberi nama <nama>-<timestamp><ext>, opsional di-gzip, dan
+// dibersihkan sesuai MaxBackups / MaxAgeDays.
+type RotatingWriter struct {
+	mu       sync.Mutex
+	cfg      LogConfig
+	file     *os.File
+	size     int64
+	openedAt time.Time
+	now      func() time.Time
+	// pending - goroutine postRotate yang belum selesai; ditunggu oleh Close
+	pending sync.WaitGroup
+}
+
+func NewRotatingWriter(cfg LogConfig) (*RotatingWriter, error) {
+	w := &RotatingWriter{cfg: cfg, now: time.Now}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *RotatingWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.shouldRotate(int64(len(p))) {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Close menutup file aktif lalu menunggu kompresi dan pembersihan segmen
+// yang masih berjalan.
+func (w *RotatingWriter) Close() error {
+	w.mu.Lock()
+	var err error
+	if w.file != nil {
+		err = w.file.Close()
+		w.file = nil
+	}
+	w.mu.Unlock()
+
+	w.pending.Wait()
+	return err
+}
+
+func (w *RotatingWriter) shouldRotate(next int64) bool {
+	switch w.cfg.Rotate {
+	case "size":
+		limit := int64(w.cfg.MaxSizeMB) * 1024 * 1024
+		return limit > 0 && w.size > 0 && w.size+next > limit
+	case "time":
+		return w.cfg.Interval > 0 && w.now().Sub(w.openedAt) >= w.cfg.Interval
+	}
+	return false
+}
+
+func (w *RotatingWriter) open() error {
+	if err := ensureLogDir(w.cfg.File); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(w.cfg.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	w.file = f
+	w.size = info.Size()
+	w.openedAt = w.now()
+	return nil
+}
+
+func (w *RotatingWriter) rotate() error {
+	if w.file != nil {
+		if err := w.file.Close(); err != nil {
+			return err
+		}
+		w.file = nil
+	}
+
+	backup := w.freeBackupName(w.now())
+	if err := os.Rename(w.cfg.File, backup); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := w.open(); err != nil {
+		return err
+	}
+
+	w.pending.Add(1)
+	go func() {
+		defer w.pending.Done()
+		w.postRotate(backup)
+	}()
+	return nil
+}
+
+// postRotate menjalankan kompresi dan pembersihan segmen lama di background
+func (w *RotatingWriter) postRotate(backup string) {
+	if w.cfg.Compress {
+		if err := gzipFile(backup); err == nil {
+			os.Remove(backup)
+		}
+	}
+	w.prune()
+}
+
+func (w *RotatingWriter) backupName(t time.Time) string {
+	ext := filepath.Ext(w.cfg.File)
+	base := strings.TrimSuffix(w.cfg.File, ext)
+	return base + "-" + t.Format(backupStampFormat) + ext
+}
+
+// freeBackupName - nama segmen untuk t yang belum dipakai (termasuk versi
+// .gz-nya). Jika sudah ada, t dimajukan per nanodetik.
+func (w *RotatingWriter) freeBackupName(t time.Time) string {
+	for {
+		name := w.backupName(t)
+		if !fileExists(name) && !fileExists(name+".gz") {
+			return name
+		}
+		t = t.Add(time.Nanosecond)
+	}
+}
+
+func fileExists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
+
+// prune menghapus segmen lama yang melebihi MaxBackups atau MaxAgeDays
+func (w *RotatingWriter) prune() {
+	ext := filepath.Ext(w.cfg.File)
+	base := strings.TrimSuffix(filepath.Base(w.cfg.File), ext)
+	dir := filepath.Dir(w.cfg.File)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+
+	type segment struct {
+		path string
+		at   time.Time
+	}
+	var segments []segment
+	for _, e := range entries {
+		name := strings.TrimSuffix(e.Name(), ".gz")
+		if e.IsDir() || !strings.HasPrefix(name, base+"-") || !strings.HasSuffix(name, ext) {
+			continue
+		}
+		stamp := strings.TrimSuffix(strings.TrimPrefix(name, base+"-"), ext)
+		at, err := time.Parse(backupTimeFormat, stamp)
+		if err != nil {
+			continue
+		}
+		segments = append(segments, segment{path: filepath.Join(dir, e.Name()), at: at})
+	}
+
+	// terbaru di depan
+	sort.Slice(segments, func(i, j int) bool { return segments[i].at.After(segments[j].at) })
+
+	cutoff := w.now().AddDate(0, 0, -w.cfg.MaxAgeDays)
+	for i, s := range segments {
+		tooMany := w.cfg.MaxBackups > 0 && i >= w.cfg.MaxBackups
+		tooOld := w.cfg.MaxAgeDays > 0 && s.at.Before(cutoff)
+		if tooMany || tooOld {
+			os.Remove(s.path)
+		}
+	}
+}
+
+func gzipFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o640)
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(dst)
+	if _, err := io.Copy(gz, src); err != nil {
+		gz.Close()
+		dst.Close()
+		os.Remove(path + ".gz")
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
+}
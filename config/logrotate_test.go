@@ -0,0 +1,114 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRotatingWriter_RotateBySize(t *testing.T) {
+	dir := t.TempDir()
+	w, err := NewRotatingWriter(LogConfig{
+		File:      filepath.Join(dir, "app.log"),
+		Rotate:    "size",
+		MaxSizeMB: 1,
+	})
+	if err != nil {
+		t.Fatalf("gagal membuat writer: %v", err)
+	}
+	defer w.Close()
+
+	chunk := make([]byte, 700*1024)
+	if _, err := w.Write(chunk); err != nil {
+		t.Fatalf("write pertama gagal: %v", err)
+	}
+	if _, err := w.Write(chunk); err != nil {
+		t.Fatalf("write kedua gagal: %v", err)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 file (aktif + backup), got %d", len(entries))
+	}
+	info, _ := os.Stat(filepath.Join(dir, "app.log"))
+	if info.Size() != int64(len(chunk)) {
+		t.Errorf("expected file aktif berisi 1 chunk, got %d byte", info.Size())
+	}
+	if perm := info.Mode().Perm(); perm&0o006 != 0 {
+		t.Errorf("file log tidak boleh world-accessible, got %v", perm)
+	}
+}
+
+func TestRotatingWriter_PruneMaxBackups(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	w := &RotatingWriter{
+		cfg: LogConfig{File: filepath.Join(dir, "app.log"), MaxBackups: 2, MaxAgeDays: 5},
+		now: func() time.Time { return now },
+	}
+
+	for _, day := range []int{9, 8, 7, 1} {
+		name := w.backupName(time.Date(2025, 1, day, 0, 0, 0, 0, time.UTC)) + ".gz"
+		if err := os.WriteFile(name, []byte("x"), 0o640); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	w.prune()
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 backup tersisa, got %d", len(entries))
+	}
+	for _, e := range entries {
+		if e.Name() != "app-20250109T000000.000000000.log.gz" && e.Name() != "app-20250108T000000.000000000.log.gz" {
+			t.Errorf("backup tak terduga tersisa: %s", e.Name())
+		}
+	}
+}
+
+func TestRotatingWriter_SameInstant(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	w := &RotatingWriter{
+		cfg: LogConfig{File: filepath.Join(dir, "app.log"), Rotate: "size", MaxSizeMB: 1, Compress: true},
+		now: func() time.Time { return now },
+	}
+	if err := w.open(); err != nil {
+		t.Fatal(err)
+	}
+
+	// tiga rotasi pada waktu yang sama tidak boleh saling menimpa
+	chunk := make([]byte, 700*1024)
+	for i := 0; i < 4; i++ {
+		if _, err := w.Write(chunk); err != nil {
+			t.Fatalf("write %d gagal: %v", i, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 4 {
+		t.Fatalf("expected file aktif + 3 backup, got %d", len(entries))
+	}
+	for _, e := range entries {
+		if e.Name() != "app.log" && filepath.Ext(e.Name()) != ".gz" {
+			t.Errorf("expected kompresi selesai sebelum Close kembali, got %s", e.Name())
+		}
+	}
+}
+
+func TestSetLogLevel_Invalid(t *testing.T) {
+	if err := SetLogLevel("berisik"); err == nil {
+		t.Error("expected error untuk level tidak valid")
+	}
+	if err := SetLogLevel("debug"); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if LogLevel() != "debug" {
+		t.Errorf("expected level debug, got %s", LogLevel())
+	}
+}
@@ -15,48 +15,65 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/alumni": {
+        "/admin/alumni/duplicates": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengambil seluruh data alumni dari database",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Pasangan alumni aktif yang kemungkinan orang yang sama, urut skor tertinggi. Skor dari NIM (50), email (40), nomor telepon (30), dan kemiripan nama (sampai 30), maksimal 100. Pasangan yang sudah ditandai bukan duplikat tidak ditampilkan (admin saja).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Alumni"
+                    "Admin"
+                ],
+                "summary": "Daftar kandidat alumni duplikat",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Skor minimal 1-100 (default 40)",
+                        "name": "min_score",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah pasangan (default 50, maksimal 200)",
+                        "name": "limit",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Dapatkan semua data alumni",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.DuplicateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admin/alumni/duplicates/dismiss": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menambahkan data alumni baru (hanya admin)",
+                "description": "Pasangan yang sudah diperiksa dan ternyata orang berbeda tidak lagi muncul di daftar duplikat (admin saja).",
                 "consumes": [
                     "application/json"
                 ],
@@ -64,23 +81,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Alumni"
+                    "Admin"
                 ],
-                "summary": "Tambah alumni baru",
+                "summary": "Tandai pasangan bukan duplikat",
                 "parameters": [
                     {
-                        "description": "Data Alumni",
-                        "name": "alumni",
+                        "description": "ID kedua alumni",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.Alumni"
+                            "$ref": "#/definitions/model.DismissDuplicateRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -89,61 +106,92 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
             }
         },
-        "/alumni/pag": {
+        "/admin/audit": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menampilkan daftar alumni berdasarkan halaman, urutan, dan kata kunci pencarian",
+                "description": "Kejadian penting di seluruh sistem (login, export, import, download file, perubahan role, hapus permanen, merge alumni), yang terbaru lebih dulu, dengan pagination cursor (admin saja).",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Alumni"
+                    "Admin"
                 ],
-                "summary": "Dapatkan daftar alumni dengan pagination dan pencarian",
+                "summary": "Audit log",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Nomor halaman (default 1)",
-                        "name": "page",
+                        "type": "string",
+                        "description": "ID atau username pelaku",
+                        "name": "actor",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Jumlah data per halaman (default 10)",
-                        "name": "limit",
+                        "type": "string",
+                        "description": "login, login_failed, export, import, file_download, role_change, hard_delete, trash_purge, merge",
+                        "name": "action",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Kolom pengurutan (nama/nim/angkatan/tahun_lulus/email)",
-                        "name": "sortBy",
+                        "description": "alumni, pekerjaan, users, files, audit_log",
+                        "name": "resource",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Arah pengurutan (asc/desc)",
-                        "name": "order",
+                        "description": "Sejak tanggal (YYYY-MM-DD, UTC)",
+                        "name": "from",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Kata kunci pencarian",
-                        "name": "search",
+                        "description": "Sampai tanggal (YYYY-MM-DD, UTC, inklusif)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor meta.next/meta.prev",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah data per halaman (default 20, maksimal 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc/desc (default desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Hitung total entri",
+                        "name": "count",
                         "in": "query"
                     }
                 ],
@@ -151,250 +199,228 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.AlumniResponse"
+                            "$ref": "#/definitions/model.AuditCursorResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
             }
         },
-        "/alumni/{id}": {
+        "/admin/audit/export": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengambil data detail 1 alumni berdasarkan ID",
+                "description": "Mengunduh audit log dengan filter yang sama seperti /admin/audit, urut seq naik, termasuk prev_hash dan hash untuk diperiksa di luar sistem. Export ini sendiri juga dicatat di audit log.",
+                "produces": [
+                    "text/csv"
+                ],
                 "tags": [
-                    "Alumni"
+                    "Admin"
                 ],
-                "summary": "Dapatkan detail alumni berdasarkan ID",
+                "summary": "Export audit log ke CSV",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID Alumni",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/model.Alumni"
-                        }
+                        "description": "ID atau username pelaku",
+                        "name": "actor",
+                        "in": "query"
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    }
-                }
-            },
-            "put": {
-                "security": [
                     {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Admin dapat memperbarui data alumni berdasarkan ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Alumni"
-                ],
-                "summary": "Perbarui data alumni",
-                "parameters": [
+                        "type": "string",
+                        "description": "Aksi",
+                        "name": "action",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "ID Alumni",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Resource",
+                        "name": "resource",
+                        "in": "query"
                     },
                     {
-                        "description": "Data Alumni",
-                        "name": "alumni",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.Alumni"
-                        }
+                        "type": "string",
+                        "description": "Sejak tanggal (YYYY-MM-DD, UTC)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sampai tanggal (YYYY-MM-DD, UTC, inklusif)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "file"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/audit/verify": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Admin dapat menghapus alumni berdasarkan ID",
-                "tags": [
-                    "Alumni"
+                "description": "Menghitung ulang hash setiap entri dari awal. valid=false beserta broken_seq jika ada entri yang diubah, dihapus di tengah, atau disisipkan. Simpan last_hash di luar sistem untuk mendeteksi penghapusan entri terakhir.",
+                "produces": [
+                    "application/json"
                 ],
-                "summary": "Hapus data alumni",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "ID Alumni",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                "tags": [
+                    "Admin"
                 ],
+                "summary": "Periksa rantai hash audit log",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.AuditVerifyResult"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
             }
         },
-        "/file": {
+        "/admin/config": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Admin dapat melihat semua file, user hanya file miliknya sendiri",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Menampilkan konfigurasi yang sedang dipakai (secret disamarkan) untuk debugging (admin saja)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "File"
+                    "Admin"
                 ],
-                "summary": "Dapatkan semua file",
+                "summary": "Lihat konfigurasi aktif",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.File"
-                            }
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
             }
         },
-        "/file/{category}": {
+        "/admin/custom-fields": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Upload file foto (jpeg/png) atau sertifikat (pdf)",
+                "description": "Admin menambah atribut alumni. name (huruf kecil, angka, _) menjadi key di alumni.custom dan tidak bisa diubah, begitu juga type. visibility default public.",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "File"
+                    "Admin"
                 ],
-                "summary": "Upload file (foto/sertifikat)",
+                "summary": "Tambah custom field alumni",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "File to upload",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Jenis file (foto/sertifikat)",
-                        "name": "category",
-                        "in": "path",
-                        "required": true
+                        "description": "Definisi custom field",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CustomField"
+                        }
                     }
                 ],
                 "responses": {
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/model.File"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
             }
         },
-        "/file/{id}": {
-            "get": {
+        "/admin/custom-fields/{id}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Admin dapat melihat semua file, user hanya file miliknya sendiri",
+                "description": "Admin mengubah label, options, required, dan visibility. name dan type boleh dikirim tetapi harus sama dengan yang tersimpan. Aturan baru (mis. required) berlaku saat alumni disimpan berikutnya.",
                 "consumes": [
                     "application/json"
                 ],
@@ -402,32 +428,71 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "File"
+                    "Admin"
                 ],
-                "summary": "Dapatkan file berdasarkan ID",
+                "summary": "Ubah custom field alumni",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "File ID",
+                        "description": "ID custom field",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag; 412 jika data sudah berubah",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Definisi custom field",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CustomField"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/model.File"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
-                    }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
                 }
             },
             "delete": {
@@ -436,24 +501,27 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Admin dapat menghapus semua file, user hanya miliknya sendiri",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Admin menghapus definisi custom field beserta nilainya di semua alumni, termasuk yang di trash (versi alumni tersebut naik dan perubahannya dicatat di riwayat alumni). Dicatat di audit log.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "File"
+                    "Admin"
                 ],
-                "summary": "Hapus file",
+                "summary": "Hapus custom field alumni",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "File ID",
+                        "description": "ID custom field",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag; 412 jika data sudah berubah",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -464,79 +532,64 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
             }
         },
-        "/login": {
-            "post": {
-                "description": "Login dan mendapatkan token JWT",
-                "consumes": [
-                    "application/json"
+        "/admin/log-level": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Menampilkan level log yang sedang digunakan aplikasi (admin saja)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
-                ],
-                "summary": "Login user",
-                "parameters": [
-                    {
-                        "description": "Login credentials",
-                        "name": "login",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.LoginRequest"
-                        }
-                    }
+                    "Admin"
                 ],
+                "summary": "Lihat level log aktif",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.LoginResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.LogLevelResponse"
                         }
                     }
                 }
-            }
-        },
-        "/pekerjaan": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengambil seluruh data pekerjaan dari database",
+                "description": "Mengubah level log (trace/debug/info/warn/error/fatal/panic/disabled) tanpa restart (admin saja)",
                 "consumes": [
                     "application/json"
                 ],
@@ -544,35 +597,44 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Pekerjaan"
+                    "Admin"
+                ],
+                "summary": "Ubah level log saat runtime",
+                "parameters": [
+                    {
+                        "description": "Level log baru",
+                        "name": "level",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.LogLevelRequest"
+                        }
+                    }
                 ],
-                "summary": "Dapatkan semua data pekerjaan",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Pekerjaan"
-                            }
+                            "$ref": "#/definitions/model.LogLevelResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admin/segments": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menambahkan data pekerjaan baru (admin saja)",
+                "description": "Admin menyimpan filter alumni dengan nama. query memakai parameter yang sama dengan /alumni/pag (search, search_mode, jurusan, angkatan_*, tahun_lulus_*, has_email, created_*, updated_*, has_pekerjaan, currently_employed, tag, custom.\u003cname\u003e), mis. \"angkatan_min=2019\u0026tag=penerima beasiswa\".",
                 "consumes": [
                     "application/json"
                 ],
@@ -580,17 +642,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Pekerjaan"
+                    "Segment"
                 ],
-                "summary": "Tambahkan data pekerjaan",
+                "summary": "Simpan segment alumni",
                 "parameters": [
                     {
-                        "description": "Data pekerjaan baru",
-                        "name": "pekerjaan",
+                        "description": "Nama, deskripsi, dan query segment",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.Pekerjaan"
+                            "$ref": "#/definitions/model.Segment"
                         }
                     }
                 ],
@@ -605,28 +667,38 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
             }
         },
-        "/pekerjaan/alumni/{alumni_id}": {
-            "get": {
+        "/admin/segments/{id}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengambil semua data pekerjaan berdasarkan alumni_id",
+                "description": "Admin mengganti nama, deskripsi, dan query segment.",
                 "consumes": [
                     "application/json"
                 ],
@@ -634,64 +706,106 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Pekerjaan"
+                    "Segment"
                 ],
-                "summary": "Dapatkan pekerjaan berdasarkan ID alumni",
+                "summary": "Ubah segment alumni",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID Alumni",
-                        "name": "alumni_id",
+                        "type": "string",
+                        "description": "ID segment",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag; 412 jika data sudah berubah",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Nama, deskripsi, dan query segment",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Segment"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Pekerjaan"
-                            }
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/pekerjaan/tahun/{tahun}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengambil jumlah pekerjaan berdasarkan tahun",
+                "description": "Admin menghapus segment. Data alumni tidak berubah.",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Pekerjaan"
+                    "Segment"
                 ],
-                "summary": "Statistik pekerjaan berdasarkan tahun",
+                "summary": "Hapus segment alumni",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Tahun pekerjaan",
-                        "name": "tahun",
+                        "type": "string",
+                        "description": "ID segment",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag; 412 jika data sudah berubah",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -705,102 +819,144 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
             }
         },
-        "/pekerjaan/trash": {
+        "/admin/trash/purge-report": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menampilkan daftar data pekerjaan yang masih tersimpan di trash",
+                "description": "Menampilkan data di trash yang sudah melewati masa retensi dan akan dihapus permanen pada jadwal purge berikutnya, paling banyak 100 item per target (count berisi jumlah seluruhnya). Item alumni menyebut jumlah pekerjaan dan file yang ikut terhapus. Tidak menghapus apa pun.",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Pekerjaan"
+                    "Admin"
                 ],
-                "summary": "Lihat semua data pekerjaan yang dihapus (soft delete)",
+                "summary": "Laporan dry-run purge trash",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Pekerjaan"
-                            }
+                            "$ref": "#/definitions/model.PurgeReport"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
             }
         },
-        "/pekerjaan/{id}": {
+        "/admin/users": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengambil data pekerjaan berdasarkan ID (ObjectID MongoDB)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Menampilkan daftar user dengan pagination cursor (admin saja). Halaman pertama tanpa cursor; halaman berikutnya/sebelumnya memakai meta.next/meta.prev dengan sortBy dan order yang sama.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Pekerjaan"
+                    "Admin"
                 ],
-                "summary": "Dapatkan pekerjaan berdasarkan ID",
+                "summary": "Daftar user",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID pekerjaan",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Cursor meta.next/meta.prev",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah data per halaman (default 20, maksimal 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "username (default), email, role, created_at",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc/desc (default asc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Hitung total user",
+                        "name": "count",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Pekerjaan"
+                            "$ref": "#/definitions/model.UserCursorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admin/users/{id}/role": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Memperbarui data pekerjaan berdasarkan ID",
+                "description": "Admin mengganti role user (admin atau user). Admin terakhir tidak bisa diturunkan menjadi user (409). Perubahan dicatat di riwayat user dan audit log. Token yang sudah terbit tetap memakai role lama sampai kedaluwarsa.",
                 "consumes": [
                     "application/json"
                 ],
@@ -808,25 +964,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Pekerjaan"
+                    "Admin"
                 ],
-                "summary": "Perbarui data pekerjaan",
+                "summary": "Ubah role user",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID pekerjaan",
+                        "description": "ID User",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Data pekerjaan yang diperbarui",
-                        "name": "pekerjaan",
+                        "description": "Role baru",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.Pekerjaan"
+                            "$ref": "#/definitions/model.RoleUpdateRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag/versi user; 412 jika data sudah berubah",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -840,37 +1002,78 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/alumni": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menghapus data pekerjaan secara hard delete",
+                "description": "Mengambil seluruh data alumni dari database\nUntuk non-admin email disamarkan, sedangkan no_telepon, alamat, dan gaji_range (include=pekerjaan) tidak ditampilkan.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Pekerjaan"
+                    "Alumni"
                 ],
-                "summary": "Hapus pekerjaan secara permanen",
+                "summary": "Dapatkan semua data alumni",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID pekerjaan",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Field yang dikembalikan, dipisah koma (mis. nim,nama); id selalu ikut",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Relasi yang disertakan: pekerjaan, files (dipisah koma); files hanya untuk admin",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maksimal data per relasi (default 10, maksimal 50)",
+                        "name": "include_limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -881,114 +1084,3017 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/pekerjaan/{id}/restore": {
-            "put": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengembalikan data pekerjaan dari status soft delete",
+                "description": "Menambahkan data alumni baru (hanya admin)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Pekerjaan"
+                    "Alumni"
                 ],
-                "summary": "Pulihkan data pekerjaan yang dihapus",
+                "summary": "Tambah alumni baru",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ID pekerjaan",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Data Alumni",
+                        "name": "alumni",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Alumni"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
             }
         },
-        "/pekerjaan/{id}/soft-delete": {
-            "put": {
+        "/alumni/export": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menghapus data pekerjaan tanpa benar-benar menghapus dari database",
+                "description": "Mengunduh daftar alumni dengan pencarian, filter, dan urutan yang sama seperti /alumni/pag (tanpa pagination). Data dikirim bertahap (streaming) dari database. Kolom bisa dipilih lewat ` + "`" + `columns` + "`" + `; header kolom sama dengan nama field sehingga file CSV/XLSX bisa di-import ulang. PDF memuat judul dan filter yang dipakai, dan dibatasi EXPORT_PDF_MAX_ROWS baris.",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/pdf"
+                ],
                 "tags": [
-                    "Pekerjaan"
+                    "Alumni"
                 ],
-                "summary": "Soft delete pekerjaan",
+                "summary": "Export alumni ke CSV/XLSX/PDF",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID pekerjaan",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "csv (default), xlsx, atau pdf",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kolom dipisah koma: nim,nama,jurusan,angkatan,tahun_lulus,email,no_telepon,alamat,tags,custom.\u003cname\u003e (default semua termasuk seluruh custom field)",
+                        "name": "columns",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kolom pengurutan (nama/nim/angkatan/tahun_lulus/email/relevance)",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Arah pengurutan (asc/desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kata kunci pencarian",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "contains (default) atau text",
+                        "name": "search_mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Jurusan (boleh diulang atau dipisah koma)",
+                        "name": "jurusan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Angkatan minimal",
+                        "name": "angkatan_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Angkatan maksimal",
+                        "name": "angkatan_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tahun lulus minimal",
+                        "name": "tahun_lulus_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tahun lulus maksimal",
+                        "name": "tahun_lulus_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "true = punya email, false = email kosong",
+                        "name": "has_email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Dibuat sejak (YYYY-MM-DD)",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Dibuat sampai (YYYY-MM-DD, inklusif)",
+                        "name": "created_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Diubah sejak (YYYY-MM-DD)",
+                        "name": "updated_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Diubah sampai (YYYY-MM-DD, inklusif)",
+                        "name": "updated_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Punya data pekerjaan",
+                        "name": "has_pekerjaan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Sedang bekerja (pekerjaan tanpa tanggal selesai atau belum selesai)",
+                        "name": "currently_employed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Hanya alumni yang punya semua tag ini (boleh diulang atau dipisah koma)",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter custom field: nilai dipisah koma, atau min..max untuk number/date",
+                        "name": "custom.{name}",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID segment; parameternya dipakai untuk filter yang tidak dikirim",
+                        "name": "segment",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "model.Alumni": {
-            "type": "object",
-            "properties": {
-                "alamat": {
-                    "type": "string"
-                },
-                "angkatan": {
-                    "type": "integer"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "email": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
+        },
+        "/alumni/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin meng-import alumni dari file CSV (pemisah koma atau titik koma) atau XLSX (sheet pertama). Baris pertama adalah header; nama kolom default sama dengan field JSON alumni (tag dipisah koma di kolom tags; custom field: custom.\u003cname\u003e), atau dipetakan lewat ` + "`" + `mapping` + "`" + `. Kolom yang tidak ada di file (mis. no_telepon, alamat, tags, custom field) tidak berubah pada alumni yang diperbarui. Data di-upsert berdasarkan NIM; baris dengan NIM milik alumni di trash ditolak. Mode dry_run (default) hanya memvalidasi dan melaporkan apa yang akan dibuat/diperbarui. File dengan baris lebih banyak dari IMPORT_SYNC_ROWS diproses di background (202) dan progress-nya dipantau lewat /alumni/import/{job_id}.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Import alumni dari CSV/XLSX",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "File .csv atau .xlsx",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "dry_run (default) atau commit",
+                        "name": "mode",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "JSON field -\u003e nama kolom, mis. {\\",
+                        "name": "mapping",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ImportReport"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/model.ImportJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/alumni/import/{job_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Progress (processed/total) dan laporan job import yang berjalan di background",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Status job import alumni",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID job import",
+                        "name": "job_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ImportJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/alumni/pag": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menampilkan daftar alumni berdasarkan halaman, urutan, kata kunci pencarian, dan filter terstruktur. Semua filter digabung (AND) dengan pencarian; meta.filter berisi filter yang diterapkan.\nUntuk non-admin email disamarkan, sedangkan no_telepon, alamat, dan gaji_range (include=pekerjaan) tidak ditampilkan.\nDengan ` + "`" + `cursor` + "`" + ` (kosong untuk halaman pertama) dipakai pagination cursor: response berbentuk model.AlumniCursorResponse dengan meta.next/meta.prev, ` + "`" + `page` + "`" + ` diabaikan, dan total hanya dihitung jika ` + "`" + `count=true` + "`" + `.",
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Dapatkan daftar alumni dengan pagination dan pencarian",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Nomor halaman (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah data per halaman (default 10, mode cursor default 20 maksimal 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor meta.next/meta.prev; kosong untuk halaman pertama mode cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Mode cursor: hitung total data",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kolom pengurutan (nama/nim/angkatan/tahun_lulus/email/relevance)",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Arah pengurutan (asc/desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kata kunci pencarian",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "contains (default): potongan teks di nama/jurusan/email atau awalan NIM; text: text index per kata dengan skor relevansi",
+                        "name": "search_mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Jurusan (boleh diulang atau dipisah koma)",
+                        "name": "jurusan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Angkatan minimal",
+                        "name": "angkatan_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Angkatan maksimal",
+                        "name": "angkatan_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tahun lulus minimal",
+                        "name": "tahun_lulus_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tahun lulus maksimal",
+                        "name": "tahun_lulus_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "true = punya email, false = email kosong",
+                        "name": "has_email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Dibuat sejak (YYYY-MM-DD)",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Dibuat sampai (YYYY-MM-DD, inklusif)",
+                        "name": "created_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Diubah sejak (YYYY-MM-DD)",
+                        "name": "updated_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Diubah sampai (YYYY-MM-DD, inklusif)",
+                        "name": "updated_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Punya data pekerjaan",
+                        "name": "has_pekerjaan",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Sedang bekerja (pekerjaan tanpa tanggal selesai atau belum selesai)",
+                        "name": "currently_employed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Hanya alumni yang punya semua tag ini (boleh diulang atau dipisah koma)",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter custom field: nilai dipisah koma, atau min..max untuk number/date",
+                        "name": "custom.{name}",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID segment; parameternya dipakai untuk filter yang tidak dikirim",
+                        "name": "segment",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field yang dikembalikan, dipisah koma (mis. nim,nama); id selalu ikut",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Relasi yang disertakan: pekerjaan, files (dipisah koma); files hanya untuk admin",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maksimal data per relasi (default 10, maksimal 50)",
+                        "name": "include_limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AlumniResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/alumni/suggest": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Saran alumni untuk kotak pencarian: NIM yang diawali q, atau nama yang salah satu katanya diawali q (tanpa peduli huruf besar/kecil dan diakritik). q kurang dari 2 karakter menghasilkan daftar kosong.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Autocomplete alumni",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Awalan nama atau NIM",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah saran (default 10, maksimal 20)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.AlumniSuggestion"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/alumni/tags": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Semua tag yang dipakai alumni di luar trash beserta jumlah alumninya, urut dari yang paling banyak.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Daftar tag alumni",
+                "responses": {
+                    "200": {
+                        "description": "data berisi []model.TagCount",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin menambah tag ` + "`" + `add` + "`" + ` dan menghapus tag ` + "`" + `remove` + "`" + ` pada semua alumni di luar trash yang cocok dengan pencarian, filter, atau segment di query string (parameter sama dengan /alumni/pag). Minimal satu filter wajib diisi. Versi alumni yang berubah naik dan setiap perubahan dicatat di riwayat alumni; ringkasannya dicatat di audit log. Alumni yang akan punya lebih dari 20 tag dilewati (tag_limit), begitu juga alumni yang diubah request lain selama proses (conflicts).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Tambah/hapus tag pada banyak alumni",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID segment",
+                        "name": "segment",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kata kunci pencarian",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Hanya alumni yang punya semua tag ini",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Tag yang ditambah dan dihapus",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TagUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "data berisi model.TagUpdateResult",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/alumni/trash": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menampilkan alumni yang sudah dihapus (soft delete), yang terakhir dihapus lebih dulu, beserta purges_at (waktu paling cepat dihapus permanen otomatis)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Lihat alumni di trash",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/alumni/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil data detail 1 alumni berdasarkan ID\nUntuk non-admin email disamarkan, sedangkan no_telepon, alamat, dan gaji_range (include=pekerjaan) tidak ditampilkan.",
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Dapatkan detail alumni berdasarkan ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Alumni",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag terakhir; 304 jika data tidak berubah (tidak berlaku dengan include)",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field yang dikembalikan, dipisah koma (mis. nim,nama); id selalu ikut",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Relasi yang disertakan: pekerjaan, files (dipisah koma); files hanya untuk admin atau akun dengan email alumni ini",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maksimal data per relasi (default 10, maksimal 50)",
+                        "name": "include_limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AlumniDetail"
+                        }
+                    },
+                    "304": {
+                        "description": "Data tidak berubah"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin mengganti seluruh data alumni berdasarkan ID (full replacement, termasuk nim). Field opsional yang tidak dikirim akan dihapus.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Ganti data alumni",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Alumni",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari GET; 412 jika data sudah berubah",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Data Alumni",
+                        "name": "alumni",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Alumni"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin menghapus alumni (soft delete). Data masih bisa dipulihkan lewat /alumni/{id}/restore sampai di-purge.",
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Pindahkan alumni ke trash",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Alumni",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari GET; 412 jika data sudah berubah",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin mengubah field tertentu saja dengan JSON Merge Patch (RFC 7396). Field bernilai null dihapus, field yang tidak dikirim tidak berubah.",
+                "consumes": [
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Ubah sebagian data alumni",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Alumni",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari GET; 412 jika data sudah berubah",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Field yang diubah",
+                        "name": "alumni",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Alumni"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/alumni/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Semua perubahan pada satu alumni (create, update, delete, restore, purge, revert), yang terbaru lebih dulu, beserta pelaku, request id, dan nilai field sebelum/sesudah. Riwayat tetap ada walau alumni sudah di-purge (admin saja).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Riwayat perubahan alumni",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Alumni",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/alumni/{id}/history/{version}/revert": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin mengganti isi alumni dengan isi pada versi tertentu dari riwayat. Hasilnya versi baru (aksi revert); status trash tidak berubah.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Kembalikan alumni ke versi sebelumnya",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Alumni",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Versi tujuan (lihat /alumni/{id}/history)",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari GET; 412 jika data sudah berubah",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/alumni/{id}/merge": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Alumni {id} (survivor) menerima nilai field yang dipilih dari duplikat, lalu pekerjaan duplikat dipindah ke survivor, akun user dengan email survivor lama/duplikat diganti ke email hasil merge (file miliknya ikut, dicatat di riwayat user), dan duplikat dipindah ke trash dengan merged_into. NIM duplikat yang berbeda dari NIM survivor tidak bisa dipilih (409, NIM masih dipakai duplikat); jadikan duplikat sebagai survivor. Merge juga ditolak (409) jika lebih dari satu akun user akan memakai email hasil merge. Merge dicatat di riwayat dan audit log (admin saja).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Gabungkan alumni duplikat",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Alumni survivor",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Duplikat dan sumber nilai per field",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.MergeRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag survivor; 412 jika data sudah berubah",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "data berisi model.MergeResult",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/alumni/{id}/profile": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Data alumni, riwayat pekerjaan (urut kronologis), pekerjaan saat ini, URL foto terbaru, sertifikat yang sudah diverifikasi, dan skor kelengkapan data dalam satu response.\nUntuk non-admin email disamarkan, sedangkan no_telepon, alamat, dan gaji_range tidak ditampilkan.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Profil lengkap alumni",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Alumni",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AlumniProfile"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/alumni/{id}/purge": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin menghapus permanen alumni. Alumni harus sudah ada di trash.",
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Hapus permanen alumni dari trash",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Alumni",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari GET; 412 jika data sudah berubah",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/alumni/{id}/restore": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin mengembalikan alumni yang sudah di-soft delete",
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Pulihkan alumni dari trash",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Alumni",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari GET; 412 jika data sudah berubah",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/custom-fields": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Definisi atribut tambahan alumni (nama, tipe, pilihan enum, wajib/tidak). Non-admin hanya melihat field dengan visibility public.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alumni"
+                ],
+                "summary": "Daftar custom field alumni",
+                "responses": {
+                    "200": {
+                        "description": "data berisi []model.CustomField",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/file": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin dapat melihat semua file, user hanya file miliknya sendiri. Dengan ` + "`" + `cursor` + "`" + ` (kosong untuk halaman pertama) hasilnya dipaginasi per halaman keyset dan berbentuk model.FileCursorResponse.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "File"
+                ],
+                "summary": "Dapatkan semua file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cursor meta.next/meta.prev; kosong untuk halaman pertama",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Mode cursor: jumlah data per halaman (default 20, maksimal 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Mode cursor: uploaded_at (default), original_name, file_size",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Mode cursor: asc/desc (default desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Mode cursor: hitung total data",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field yang dikembalikan, dipisah koma (mis. original_name,file_path); id selalu ikut",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.File"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/file/{category}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload file foto (jpeg/png) atau sertifikat (pdf)",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "File"
+                ],
+                "summary": "Upload file (foto/sertifikat)",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "File to upload",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Jenis file (foto/sertifikat)",
+                        "name": "category",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.File"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/file/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin dapat melihat semua file, user hanya file miliknya sendiri",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "File"
+                ],
+                "summary": "Dapatkan file berdasarkan ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field yang dikembalikan, dipisah koma (mis. original_name,file_path); id selalu ikut",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.File"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin dapat menghapus semua file, user hanya miliknya sendiri",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "File"
+                ],
+                "summary": "Hapus file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/file/{id}/download": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengunduh isi file dengan nama asli. Admin dapat mengunduh semua file, user hanya miliknya sendiri. Setiap download dicatat di audit log.",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "File"
+                ],
+                "summary": "Download file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/file/{id}/verify": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admin menandai sertifikat sudah diperiksa. Hanya sertifikat terverifikasi yang tampil di profil alumni.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "File"
+                ],
+                "summary": "Verifikasi sertifikat",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "description": "Login dan mendapatkan token JWT",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Login user",
+                "parameters": [
+                    {
+                        "description": "Login credentials",
+                        "name": "login",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/pekerjaan": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil seluruh data pekerjaan dari database. Dengan ` + "`" + `cursor` + "`" + ` (kosong untuk halaman pertama) hasilnya dipaginasi per halaman keyset dan berbentuk model.PekerjaanCursorResponse.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pekerjaan"
+                ],
+                "summary": "Dapatkan semua data pekerjaan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cursor meta.next/meta.prev; kosong untuk halaman pertama",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Mode cursor: jumlah data per halaman (default 20, maksimal 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Mode cursor: _id (default), nama_perusahaan, tanggal_mulai_kerja, alumni_id",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Mode cursor: asc/desc (default desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Mode cursor: hitung total data",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field yang dikembalikan, dipisah koma (mis. nama_perusahaan,posisi_jabatan)",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Pekerjaan"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menambahkan data pekerjaan baru (admin saja). Tanggal mulai kerja diisi tanggal hari ini oleh server.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pekerjaan"
+                ],
+                "summary": "Tambahkan data pekerjaan",
+                "parameters": [
+                    {
+                        "description": "Data pekerjaan baru",
+                        "name": "pekerjaan",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Pekerjaan"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/pekerjaan/alumni/{alumni_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil semua data pekerjaan berdasarkan alumni_id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pekerjaan"
+                ],
+                "summary": "Dapatkan pekerjaan berdasarkan ID alumni",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID Alumni",
+                        "name": "alumni_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field yang dikembalikan, dipisah koma (mis. nama_perusahaan,posisi_jabatan)",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Pekerjaan"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/pekerjaan/tahun/{tahun}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil jumlah pekerjaan berdasarkan tahun",
+                "tags": [
+                    "Pekerjaan"
+                ],
+                "summary": "Statistik pekerjaan berdasarkan tahun",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tahun pekerjaan",
+                        "name": "tahun",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/pekerjaan/trash": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menampilkan daftar data pekerjaan yang masih tersimpan di trash beserta purges_at (waktu paling cepat dihapus permanen otomatis)",
+                "tags": [
+                    "Pekerjaan"
+                ],
+                "summary": "Lihat semua data pekerjaan yang dihapus (soft delete)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Pekerjaan"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/pekerjaan/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil data pekerjaan berdasarkan ID (ObjectID MongoDB)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pekerjaan"
+                ],
+                "summary": "Dapatkan pekerjaan berdasarkan ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID pekerjaan",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag terakhir; 304 jika data tidak berubah",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field yang dikembalikan, dipisah koma (mis. nama_perusahaan,posisi_jabatan)",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Pekerjaan"
+                        }
+                    },
+                    "304": {
+                        "description": "Data tidak berubah"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengganti seluruh data pekerjaan berdasarkan ID (full replacement). Field opsional yang tidak dikirim akan dihapus.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pekerjaan"
+                ],
+                "summary": "Ganti data pekerjaan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID pekerjaan",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari GET; 412 jika data sudah berubah",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Data pekerjaan yang diperbarui",
+                        "name": "pekerjaan",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Pekerjaan"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus data pekerjaan secara hard delete",
+                "tags": [
+                    "Pekerjaan"
+                ],
+                "summary": "Hapus pekerjaan secara permanen",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID pekerjaan",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari GET; 412 jika data sudah berubah",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengubah field tertentu saja dengan JSON Merge Patch (RFC 7396). Field bernilai null dihapus, field yang tidak dikirim tidak berubah.",
+                "consumes": [
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pekerjaan"
+                ],
+                "summary": "Ubah sebagian data pekerjaan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID pekerjaan",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari GET; 412 jika data sudah berubah",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Field yang diubah",
+                        "name": "pekerjaan",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Pekerjaan"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/pekerjaan/{id}/restore": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengembalikan data pekerjaan dari status soft delete",
+                "tags": [
+                    "Pekerjaan"
+                ],
+                "summary": "Pulihkan data pekerjaan yang dihapus",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID pekerjaan",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari GET; 412 jika data sudah berubah",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/pekerjaan/{id}/soft-delete": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus data pekerjaan tanpa benar-benar menghapus dari database",
+                "tags": [
+                    "Pekerjaan"
+                ],
+                "summary": "Soft delete pekerjaan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID pekerjaan",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari GET; 412 jika data sudah berubah",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/segments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Filter alumni yang disimpan dengan nama. Segment dipakai lewat ?segment=\u003cid\u003e di /alumni/pag, /alumni/export, dan /alumni/tags.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Segment"
+                ],
+                "summary": "Daftar segment alumni",
+                "responses": {
+                    "200": {
+                        "description": "data berisi []model.Segment",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/segments/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Segment"
+                ],
+                "summary": "Detail segment alumni",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID segment",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "data berisi model.Segment",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/segments/{id}/count": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghitung alumni aktif yang cocok dengan segment saat ini, beserta filter hasil penguraian query segment (custom field memakai definisi terbaru).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Segment"
+                ],
+                "summary": "Jumlah alumni dalam segment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID segment",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "data berisi model.SegmentCount",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "apperror.FieldError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Alumni": {
+            "type": "object",
+            "required": [
+                "angkatan",
+                "email",
+                "jurusan",
+                "nama",
+                "nim",
+                "tahun_lulus"
+            ],
+            "properties": {
+                "alamat": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "angkatan": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "custom": {
+                    "description": "nilai custom field, key = CustomField.Name",
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "deleted_at": {
+                    "description": "terisi jika ada di trash",
+                    "type": "string"
+                },
+                "deleted_by": {
+                    "description": "user_id yang menghapus",
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "jurusan": {
+                    "type": "string"
+                },
+                "merged_into": {
+                    "description": "alumni tujuan jika dihapus karena merge",
+                    "type": "string"
+                },
+                "nama": {
+                    "type": "string",
+                    "maxLength": 150
+                },
+                "nim": {
+                    "type": "string"
+                },
+                "no_telepon": {
+                    "description": "E.164, mis. +6281234567890",
+                    "type": "string"
+                },
+                "purges_at": {
+                    "description": "hanya di listing trash",
+                    "type": "string"
+                },
+                "score": {
+                    "description": "relevansi, hanya di hasil search_mode=text",
+                    "type": "number"
+                },
+                "tags": {
+                    "description": "huruf kecil, tanpa duplikat",
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tahun_lulus": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "naik setiap perubahan, dipakai sebagai ETag",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AlumniDetail": {
+            "type": "object",
+            "required": [
+                "angkatan",
+                "email",
+                "jurusan",
+                "nama",
+                "nim",
+                "tahun_lulus"
+            ],
+            "properties": {
+                "alamat": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "angkatan": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "custom": {
+                    "description": "nilai custom field, key = CustomField.Name",
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "deleted_at": {
+                    "description": "terisi jika ada di trash",
+                    "type": "string"
+                },
+                "deleted_by": {
+                    "description": "user_id yang menghapus",
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "files": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.File"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "jurusan": {
+                    "type": "string"
+                },
+                "merged_into": {
+                    "description": "alumni tujuan jika dihapus karena merge",
+                    "type": "string"
+                },
+                "nama": {
+                    "type": "string",
+                    "maxLength": 150
+                },
+                "nim": {
+                    "type": "string"
+                },
+                "no_telepon": {
+                    "description": "E.164, mis. +6281234567890",
+                    "type": "string"
+                },
+                "pekerjaan": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Pekerjaan"
+                    }
+                },
+                "purges_at": {
+                    "description": "hanya di listing trash",
+                    "type": "string"
+                },
+                "score": {
+                    "description": "relevansi, hanya di hasil search_mode=text",
+                    "type": "number"
+                },
+                "tags": {
+                    "description": "huruf kecil, tanpa duplikat",
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tahun_lulus": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "naik setiap perubahan, dipakai sebagai ETag",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AlumniFilter": {
+            "type": "object",
+            "properties": {
+                "angkatan_max": {
+                    "type": "integer"
+                },
+                "angkatan_min": {
+                    "type": "integer"
+                },
+                "created_from": {
+                    "description": "YYYY-MM-DD, inklusif",
+                    "type": "string"
+                },
+                "created_to": {
+                    "type": "string"
+                },
+                "currently_employed": {
+                    "description": "punya pekerjaan tanpa tanggal selesai / belum selesai",
+                    "type": "boolean"
+                },
+                "custom": {
+                    "description": "?custom.\u003cname\u003e=, urut nama field",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CustomFilter"
+                    }
+                },
+                "has_email": {
+                    "type": "boolean"
+                },
+                "has_pekerjaan": {
+                    "type": "boolean"
+                },
+                "jurusan": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tag": {
+                    "description": "?tag=, alumni harus punya semua tag",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tahun_lulus_max": {
+                    "type": "integer"
+                },
+                "tahun_lulus_min": {
+                    "type": "integer"
+                },
+                "updated_from": {
+                    "type": "string"
+                },
+                "updated_to": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AlumniProfile": {
+            "type": "object",
+            "properties": {
+                "alumni": {
+                    "$ref": "#/definitions/model.Alumni"
+                },
+                "foto_url": {
+                    "description": "foto terbaru",
+                    "type": "string"
+                },
+                "kelengkapan": {
+                    "$ref": "#/definitions/model.ProfileCompleteness"
+                },
+                "pekerjaan_saat_ini": {
+                    "description": "belum selesai, mulai paling akhir",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.Pekerjaan"
+                        }
+                    ]
+                },
+                "redacted": {
+                    "description": "data kontak/gaji disembunyikan (bukan admin)",
+                    "type": "boolean"
+                },
+                "riwayat_pekerjaan": {
+                    "description": "urut dari yang paling lama",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Pekerjaan"
+                    }
+                },
+                "sertifikat": {
+                    "description": "hanya yang sudah diverifikasi",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ProfileFile"
+                    }
+                }
+            }
+        },
+        "model.AlumniResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AlumniDetail"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/model.MetaInfo"
+                }
+            }
+        },
+        "model.AlumniSuggestion": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
                 "jurusan": {
                     "type": "string"
                 },
@@ -997,29 +4103,294 @@ const docTemplate = `{
                 },
                 "nim": {
                     "type": "string"
+                }
+            }
+        },
+        "model.AuditCursorResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AuditEntry"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/model.CursorMeta"
+                }
+            }
+        },
+        "model.AuditEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor_id": {
+                    "type": "string"
+                },
+                "actor_name": {
+                    "type": "string"
+                },
+                "actor_role": {
+                    "type": "string"
+                },
+                "at": {
+                    "type": "string"
+                },
+                "detail": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "hash": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "prev_hash": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "resource": {
+                    "type": "string"
+                },
+                "resource_id": {
+                    "type": "string"
+                },
+                "seq": {
+                    "description": "urutan di rantai, mulai dari 1",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "status HTTP; kosong untuk aksi di luar request",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AuditVerifyResult": {
+            "type": "object",
+            "properties": {
+                "broken_seq": {
+                    "description": "entri pertama yang hash atau urutannya tidak cocok",
+                    "type": "integer"
+                },
+                "checked": {
+                    "description": "jumlah entri yang diperiksa",
+                    "type": "integer"
+                },
+                "last_hash": {
+                    "description": "simpan di luar sistem untuk mendeteksi entri terakhir yang dihapus",
+                    "type": "string"
+                },
+                "last_seq": {
+                    "type": "integer"
+                },
+                "valid": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.CursorMeta": {
+            "type": "object",
+            "properties": {
+                "filter": {
+                    "description": "daftar alumni",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.AlumniFilter"
+                        }
+                    ]
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next": {
+                    "type": "string"
+                },
+                "order": {
+                    "type": "string"
+                },
+                "prev": {
+                    "type": "string"
+                },
+                "search": {
+                    "description": "daftar alumni",
+                    "type": "string"
+                },
+                "search_mode": {
+                    "description": "daftar alumni",
+                    "type": "string"
+                },
+                "sortBy": {
+                    "type": "string"
+                },
+                "total": {
+                    "description": "hanya jika ?count=true",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CustomField": {
+            "type": "object",
+            "required": [
+                "name",
+                "type",
+                "visibility"
+            ],
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 40
+                },
+                "options": {
+                    "description": "pilihan untuk type enum",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "string",
+                        "number",
+                        "date",
+                        "enum",
+                        "url"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "visibility": {
+                    "type": "string",
+                    "enum": [
+                        "public",
+                        "admin"
+                    ]
+                }
+            }
+        },
+        "model.CustomFilter": {
+            "type": "object",
+            "properties": {
+                "max": {
+                    "type": "string"
+                },
+                "min": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.DismissDuplicateRequest": {
+            "type": "object",
+            "required": [
+                "a",
+                "b"
+            ],
+            "properties": {
+                "a": {
+                    "type": "string"
+                },
+                "b": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.DuplicateMeta": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "min_score": {
+                    "type": "integer"
+                },
+                "total": {
+                    "description": "semua pasangan di atas min_score",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.DuplicatePair": {
+            "type": "object",
+            "properties": {
+                "a": {
+                    "$ref": "#/definitions/model.Alumni"
+                },
+                "b": {
+                    "$ref": "#/definitions/model.Alumni"
+                },
+                "name_similarity": {
+                    "description": "0-1 (Jaro-Winkler nama yang dinormalisasi)",
+                    "type": "number"
                 },
-                "no_telepon": {
-                    "type": "integer"
+                "reasons": {
+                    "description": "field yang cocok",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "tahun_lulus": {
+                "score": {
+                    "description": "0-100, makin besar makin mirip",
                     "type": "integer"
-                },
-                "updated_at": {
-                    "type": "string"
                 }
             }
         },
-        "model.AlumniResponse": {
+        "model.DuplicateResponse": {
             "type": "object",
             "properties": {
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.Alumni"
+                        "$ref": "#/definitions/model.DuplicatePair"
                     }
                 },
                 "meta": {
-                    "$ref": "#/definitions/model.MetaInfo"
+                    "$ref": "#/definitions/model.DuplicateMeta"
                 }
             }
         },
@@ -1053,6 +4424,120 @@ const docTemplate = `{
                 },
                 "user_id": {
                     "type": "string"
+                },
+                "verified_at": {
+                    "description": "sertifikat yang sudah diperiksa admin",
+                    "type": "string"
+                },
+                "verified_by": {
+                    "description": "user_id admin yang memverifikasi",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ImportJob": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "mode": {
+                    "type": "string"
+                },
+                "processed": {
+                    "type": "integer"
+                },
+                "report": {
+                    "$ref": "#/definitions/model.ImportReport"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ImportReport": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ImportRowError"
+                    }
+                },
+                "errors_truncated": {
+                    "type": "boolean"
+                },
+                "invalid": {
+                    "type": "integer"
+                },
+                "mode": {
+                    "type": "string"
+                },
+                "total_rows": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                },
+                "valid": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ImportRowError": {
+            "type": "object",
+            "properties": {
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/apperror.FieldError"
+                    }
+                },
+                "nim": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.LogLevelRequest": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.LogLevelResponse": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "type": "string"
                 }
             }
         },
@@ -1078,9 +4563,34 @@ const docTemplate = `{
                 }
             }
         },
+        "model.MergeRequest": {
+            "type": "object",
+            "required": [
+                "duplicate_id"
+            ],
+            "properties": {
+                "duplicate_id": {
+                    "type": "string"
+                },
+                "fields": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "model.MetaInfo": {
             "type": "object",
             "properties": {
+                "filter": {
+                    "description": "filter yang diterapkan (daftar alumni)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.AlumniFilter"
+                        }
+                    ]
+                },
                 "limit": {
                     "type": "integer"
                 },
@@ -1096,6 +4606,10 @@ const docTemplate = `{
                 "search": {
                     "type": "string"
                 },
+                "search_mode": {
+                    "description": "contains / text (daftar alumni)",
+                    "type": "string"
+                },
                 "sortBy": {
                     "type": "string"
                 },
@@ -1106,19 +4620,36 @@ const docTemplate = `{
         },
         "model.Pekerjaan": {
             "type": "object",
+            "required": [
+                "alumni_id",
+                "bidang_industri",
+                "lokasi_kerja",
+                "nama_perusahaan",
+                "posisi_jabatan",
+                "status_pekerjaan",
+                "tanggal_mulai_kerja"
+            ],
             "properties": {
                 "alumni_id": {
-                    "type": "integer"
+                    "type": "integer",
+                    "minimum": 1
                 },
                 "bidang_industri": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 100
                 },
                 "created_at": {},
-                "deskripsi_pekerjaan": {
+                "deleted_at": {
+                    "description": "waktu soft delete",
                     "type": "string"
                 },
+                "deskripsi_pekerjaan": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
                 "gaji_range": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 50
                 },
                 "id": {
                     "type": "integer"
@@ -1127,16 +4658,24 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "lokasi_kerja": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 100
                 },
                 "nama_perusahaan": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 150
                 },
                 "posisi_jabatan": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "purges_at": {
+                    "description": "hanya di listing trash",
                     "type": "string"
                 },
                 "status_pekerjaan": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 50
                 },
                 "tanggal_mulai_kerja": {
                     "type": "string"
@@ -1144,7 +4683,209 @@ const docTemplate = `{
                 "tanggal_selesai_kerja": {
                     "type": "string"
                 },
-                "updated_at": {}
+                "updated_at": {},
+                "version": {
+                    "description": "naik setiap perubahan, dipakai sebagai ETag",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Problem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/apperror.FieldError"
+                    }
+                },
+                "instance": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProfileCompleteness": {
+            "type": "object",
+            "properties": {
+                "belum_diisi": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "skor": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ProfileFile": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "original_name": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "verified_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PurgeReport": {
+            "type": "object",
+            "properties": {
+                "cutoff": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "generated_at": {
+                    "type": "string"
+                },
+                "retention": {
+                    "type": "string"
+                },
+                "targets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PurgeTargetReport"
+                    }
+                }
+            }
+        },
+        "model.PurgeTargetReport": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "jumlah seluruhnya",
+                    "type": "integer"
+                },
+                "items": {
+                    "description": "paling banyak 100 item, terlama lebih dulu",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TrashItem"
+                    }
+                },
+                "target": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.RoleUpdateRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "user"
+                    ]
+                }
+            }
+        },
+        "model.Segment": {
+            "type": "object",
+            "required": [
+                "name",
+                "query"
+            ],
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "description": "user_id pembuat",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "query": {
+                    "description": "mis. \"angkatan_min=2019\u0026tag=penerima+beasiswa\"",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.TagUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "add": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "remove": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.TrashItem": {
+            "type": "object",
+            "properties": {
+                "deleted_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "purges_at": {
+                    "type": "string"
+                },
+                "related": {
+                    "description": "relasi yang ikut terhapus, mis. {\"pekerjaan\": 2, \"files\": 1}",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                }
             }
         },
         "model.User": {
@@ -1164,6 +4905,24 @@ const docTemplate = `{
                 },
                 "username": {
                     "type": "string"
+                },
+                "version": {
+                    "description": "naik setiap perubahan, dipakai sebagai ETag",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.UserCursorResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.User"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/model.CursorMeta"
                 }
             }
         }
@@ -0,0 +1,10 @@
+//go:build windows
+
+package health
+
+import "math"
+
+// freeBytes belum didukung di Windows; pengecekan sisa disk dilewati
+func freeBytes(string) (uint64, error) {
+	return math.MaxUint64, nil
+}
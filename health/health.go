@@ -0,0 +1,134 @@
+// Package health berisi pengecekan dependency untuk endpoint /healthz dan
+// /readyz, serta status draining saat graceful shutdown.
+package health
+
+import (
+	"context"
+	"crud_alumni/app/model"
+	"crud_alumni/migration"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var draining atomic.Bool
+
+// SetDraining menandai aplikasi sedang shutdown sehingga readiness gagal
+func SetDraining(v bool) {
+	draining.Store(v)
+}
+
+func Draining() bool {
+	return draining.Load()
+}
+
+// Check - satu pengecekan readiness
+type Check struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+// RunChecks menjalankan semua check secara paralel dan mengembalikan hasil
+// sesuai urutan input. ok bernilai false jika ada check yang gagal.
+func RunChecks(ctx context.Context, checks []Check) ([]model.HealthCheck, bool) {
+	results := make([]model.HealthCheck, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			start := time.Now()
+			err := check.Run(ctx)
+			results[i] = model.HealthCheck{
+				Name:      check.Name,
+				Status:    "ok",
+				LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+			}
+			if err != nil {
+				results[i].Status = "fail"
+				results[i].Error = err.Error()
+			}
+		}(i, check)
+	}
+	wg.Wait()
+
+	ok := true
+	for _, r := range results {
+		if r.Status != "ok" {
+			ok = false
+		}
+	}
+	return results, ok
+}
+
+// DrainingCheck gagal saat graceful shutdown sedang berjalan
+func DrainingCheck() Check {
+	return Check{Name: "shutdown", Run: func(context.Context) error {
+		if Draining() {
+			return errors.New("sedang draining")
+		}
+		return nil
+	}}
+}
+
+// MongoCheck melakukan ping ke MongoDB
+func MongoCheck(db *mongo.Database) Check {
+	return Check{Name: "mongo", Run: func(ctx context.Context) error {
+		if db == nil {
+			return errors.New("database belum terhubung")
+		}
+		return db.Client().Ping(ctx, nil)
+	}}
+}
+
+// MigrationCheck gagal jika masih ada migrasi yang belum diterapkan, kecuali
+// migrasi Deferrable yang menunggu data dirapikan
+func MigrationCheck(db *mongo.Database) Check {
+	return Check{Name: "migrations", Run: func(ctx context.Context) error {
+		if db == nil {
+			return errors.New("database belum terhubung")
+		}
+		pending, err := migration.Pending(ctx, db)
+		if err != nil {
+			return err
+		}
+		pending = slices.DeleteFunc(pending, migration.Deferrable)
+		if len(pending) > 0 {
+			return fmt.Errorf("migrasi belum diterapkan: %s", strings.Join(pending, ", "))
+		}
+		return nil
+	}}
+}
+
+// UploadDirCheck memastikan folder upload bisa ditulis dan sisa disk
+// minimal minFreeBytes
+func UploadDirCheck(dir string, minFreeBytes uint64) Check {
+	return Check{Name: "upload:" + filepath.Base(dir), Run: func(context.Context) error {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+		probe, err := os.CreateTemp(dir, ".healthcheck-*")
+		if err != nil {
+			return fmt.Errorf("folder tidak bisa ditulis: %w", err)
+		}
+		probe.Close()
+		os.Remove(probe.Name())
+
+		free, err := freeBytes(dir)
+		if err != nil {
+			return err
+		}
+		if free < minFreeBytes {
+			return fmt.Errorf("sisa disk %d MB, minimal %d MB", free>>20, minFreeBytes>>20)
+		}
+		return nil
+	}}
+}
@@ -0,0 +1,83 @@
+// Package i18n - katalog pesan API dalam Bahasa Indonesia dan Inggris.
+// Bahasa dipilih dari header Accept-Language; default Indonesia.
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	ID = "id"
+	EN = "en"
+
+	Default = ID
+)
+
+// Supported - bahasa yang punya katalog lengkap
+var Supported = []string{ID, EN}
+
+var catalogs = map[string]map[string]string{
+	ID: messagesID,
+	EN: messagesEN,
+}
+
+// Message mengambil pesan untuk key dalam bahasa lang. Jika key tidak ada di
+// bahasa tersebut dipakai bahasa default, lalu key itu sendiri.
+func Message(lang, key string, args ...any) string {
+	msg, ok := catalogs[lang][key]
+	if !ok {
+		msg, ok = catalogs[Default][key]
+	}
+	if !ok {
+		return key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+// T - Message dengan bahasa dari request
+func T(c *fiber.Ctx, key string, args ...any) string {
+	return Message(Lang(c), key, args...)
+}
+
+// Lang menentukan bahasa dari Accept-Language request
+func Lang(c *fiber.Ctx) string {
+	return FromAcceptLanguage(c.Get(fiber.HeaderAcceptLanguage))
+}
+
+// FromAcceptLanguage memilih bahasa yang didukung dengan q-value tertinggi,
+// mis. "en-US,en;q=0.9,id;q=0.8" -> "en". Tanpa kecocokan -> Default.
+func FromAcceptLanguage(header string) string {
+	best, bestQ := Default, 0.0
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+		primary, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		if _, ok := catalogs[primary]; ok && q > bestQ {
+			best, bestQ = primary, q
+		}
+	}
+	return best
+}
+
+// Keys mengembalikan semua key katalog dalam bahasa lang (urut)
+func Keys(lang string) []string {
+	keys := make([]string, 0, len(catalogs[lang]))
+	for k := range catalogs[lang] {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
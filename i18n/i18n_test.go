@@ -0,0 +1,59 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+
+	"crud_alumni/apperror"
+)
+
+func TestCatalog_AllKeysTranslated(t *testing.T) {
+	for _, lang := range Supported {
+		for _, other := range Supported {
+			for _, key := range Keys(lang) {
+				msg, ok := catalogs[other][key]
+				if !ok || strings.TrimSpace(msg) == "" {
+					t.Errorf("key %q ada di %s tapi tidak diterjemahkan di %s", key, lang, other)
+				}
+			}
+		}
+	}
+}
+
+func TestCatalog_PlaceholdersMatch(t *testing.T) {
+	for _, key := range Keys(Default) {
+		want := strings.Count(catalogs[Default][key], "%")
+		for _, lang := range Supported {
+			if got := strings.Count(catalogs[lang][key], "%"); got != want {
+				t.Errorf("key %q: jumlah placeholder %s (%d) beda dengan %s (%d)", key, lang, got, Default, want)
+			}
+		}
+	}
+}
+
+func TestCatalog_EveryErrorCodeHasMessage(t *testing.T) {
+	for _, code := range apperror.Codes {
+		for _, lang := range Supported {
+			if _, ok := catalogs[lang][code]; !ok {
+				t.Errorf("kode error %q belum punya pesan %s", code, lang)
+			}
+		}
+	}
+}
+
+func TestFromAcceptLanguage(t *testing.T) {
+	cases := map[string]string{
+		"":                        ID,
+		"en":                      EN,
+		"en-US,en;q=0.9":          EN,
+		"id-ID,id;q=0.9,en;q=0.8": ID,
+		"fr-FR,en;q=0.5,id;q=0.7": ID,
+		"fr-FR,de;q=0.5":          ID,
+		"EN-gb;q=0.8, fr;q=0.9":   EN,
+	}
+	for header, want := range cases {
+		if got := FromAcceptLanguage(header); got != want {
+			t.Errorf("FromAcceptLanguage(%q) = %s, want %s", header, got, want)
+		}
+	}
+}
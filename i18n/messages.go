@@ -0,0 +1,225 @@
+package i18n
+
+var messagesID = map[string]string{
+	// error umum
+	"internal_error":      "Terjadi kesalahan pada server",
+	"bad_request":         "Request tidak valid",
+	"invalid_body":        "Body tidak valid",
+	"invalid_id":          "Format ID tidak valid",
+	"invalid_param":       "Parameter %s tidak valid",
+	"validation_failed":   "Validasi data gagal",
+	"route_not_found":     "Endpoint tidak ditemukan",
+	"unsupported_media":   "Content-Type harus salah satu dari: %s",
+	"precondition_failed": "Data sudah diubah oleh pengguna lain, muat ulang lalu coba lagi",
+	"invalid_cursor":      "Cursor tidak valid atau tidak cocok dengan sortBy/order",
+
+	// auth
+	"token_required":      "Token diperlukan",
+	"token_malformed":     "Format token salah",
+	"token_invalid":       "Token invalid",
+	"admin_only":          "Hanya admin",
+	"forbidden":           "Tidak punya akses ke data ini",
+	"invalid_credentials": "Username atau password salah",
+
+	// data tidak ditemukan
+	"alumni_not_found":          "Alumni tidak ditemukan",
+	"alumni_not_in_trash":       "Alumni tidak ditemukan di trash",
+	"alumni_nim_exists":         "NIM %s sudah dipakai alumni lain (termasuk yang di trash)",
+	"merge_email_conflict":      "Merge akan membuat beberapa akun user memakai email %s (%s); rapikan akun tersebut lebih dulu",
+	"pekerjaan_not_found":       "Data pekerjaan tidak ditemukan",
+	"file_not_found":            "File tidak ditemukan",
+	"history_version_not_found": "Versi tersebut tidak ada di riwayat",
+	"user_not_found":            "User tidak ditemukan",
+	"last_admin":                "Tidak bisa mengubah role admin terakhir",
+	"custom_field_not_found":    "Custom field tidak ditemukan",
+	"custom_field_exists":       "Custom field %s sudah ada",
+	"segment_not_found":         "Segment tidak ditemukan",
+	"segment_exists":            "Segment %s sudah ada",
+	"tag_filter_required":       "Isi minimal satu filter, pencarian, atau segment supaya tag tidak diubah di semua alumni",
+
+	// upload
+	"upload_missing_file":        "File belum di-upload",
+	"upload_unknown_category":    "Kategori file tidak dikenal",
+	"upload_type_not_allowed":    "Tipe file harus salah satu dari: %s",
+	"upload_too_large":           "Ukuran file maksimal %s",
+	"file_not_certificate":       "Hanya file sertifikat yang bisa diverifikasi",
+	"upload_for_other_forbidden": "User tidak boleh upload file untuk orang lain",
+	"include_files_forbidden":    "include=files hanya untuk admin atau pemilik data alumni",
+
+	// import
+	"import_unsupported_format": "Format file harus .csv atau .xlsx",
+	"import_unreadable_file":    "File tidak bisa dibaca atau kosong",
+	"import_missing_columns":    "Kolom wajib tidak ditemukan: %s",
+	"import_job_not_found":      "Job import tidak ditemukan",
+
+	// export
+	"export_too_many_rows": "Hasil filter berisi %d baris, export PDF maksimal %d baris; gunakan format csv atau xlsx",
+	"export.title":         "Data Alumni",
+	"export.generated_at":  "Dibuat: %s",
+	"export.search":        "Pencarian: %s",
+	"export.filter":        "Filter: %s",
+	"export.sort":          "Urutan: %s (%s)",
+	"export.total":         "Jumlah data: %d",
+
+	// admin
+	"invalid_log_level": "Level log tidak valid",
+
+	// validasi per field (apperror.FieldError.Code)
+	"field.required":  "Wajib diisi",
+	"field.min_len":   "Minimal %d karakter",
+	"field.max_len":   "Maksimal %d karakter",
+	"field.min":       "Minimal %d",
+	"field.max":       "Maksimal %d",
+	"field.email":     "Format email tidak valid",
+	"field.nim":       "Format NIM tidak valid",
+	"field.jurusan":   "Jurusan harus salah satu dari: %s",
+	"field.year":      "Tahun harus antara %d dan %d",
+	"field.date":      "Format tanggal harus YYYY-MM-DD",
+	"field.oneof":     "Harus salah satu dari: %s",
+	"field.gtefield":  "Tidak boleh lebih kecil dari %s",
+	"field.integer":   "Harus berupa angka bulat",
+	"field.phone":     "Nomor telepon tidak valid, mis. 0812-3456-7890 atau +62 812 3456 7890",
+	"field.slug":      "Hanya huruf kecil, angka, dan _, diawali huruf",
+	"field.number":    "Harus berupa angka",
+	"field.url":       "Harus berupa URL http/https",
+	"field.string":    "Harus berupa teks",
+	"field.unknown":   "Custom field tidak dikenal",
+	"field.max_items": "Maksimal %d item",
+	"field.tag":       "Setiap tag harus 1-%d karakter",
+	"field.query":     "Parameter %s tidak bisa dipakai di segment",
+	"field.duplicate": "NIM sama dengan baris %d",
+	"field.in_trash":  "NIM dipakai alumni di trash; pulihkan atau purge dulu",
+	"field.nim_taken": "NIM sudah dipakai alumni lain",
+
+	// pesan sukses
+	"alumni.soft_deleted":        "Alumni dipindahkan ke trash",
+	"alumni.restored":            "Alumni berhasil dipulihkan",
+	"alumni.purged":              "Alumni berhasil dihapus permanen",
+	"alumni.reverted":            "Alumni dikembalikan ke isi versi %d",
+	"alumni.merged":              "Alumni duplikat berhasil digabung",
+	"alumni.duplicate_dismissed": "Pasangan ditandai bukan duplikat",
+	"pekerjaan.created":          "Data pekerjaan berhasil ditambahkan",
+	"pekerjaan.updated":          "Data pekerjaan berhasil diperbarui",
+	"pekerjaan.deleted":          "Data pekerjaan berhasil dihapus permanen",
+	"pekerjaan.soft_deleted":     "Data pekerjaan berhasil dihapus (soft delete)",
+	"pekerjaan.restored":         "Data pekerjaan berhasil dipulihkan",
+	"file.uploaded":              "File berhasil di-upload",
+	"file.deleted":               "File berhasil dihapus",
+	"file.verified":              "Sertifikat berhasil diverifikasi",
+	"user.role_changed":          "Role user diubah menjadi %s",
+	"custom_field.deleted":       "Custom field dihapus, nilainya dihapus dari %d alumni",
+	"segment.deleted":            "Segment berhasil dihapus",
+	"alumni.tags_updated":        "Tag diperbarui untuk %d alumni yang cocok dengan filter",
+}
+
+var messagesEN = map[string]string{
+	// general errors
+	"internal_error":      "An internal server error occurred",
+	"bad_request":         "Invalid request",
+	"invalid_body":        "Invalid request body",
+	"invalid_id":          "Invalid ID format",
+	"invalid_param":       "Invalid parameter %s",
+	"validation_failed":   "Validation failed",
+	"route_not_found":     "Endpoint not found",
+	"unsupported_media":   "Content-Type must be one of: %s",
+	"precondition_failed": "The record was modified by someone else, reload it and try again",
+	"invalid_cursor":      "The cursor is invalid or does not match sortBy/order",
+
+	// auth
+	"token_required":      "Token is required",
+	"token_malformed":     "Malformed token",
+	"token_invalid":       "Invalid token",
+	"admin_only":          "Admin only",
+	"forbidden":           "You do not have access to this resource",
+	"invalid_credentials": "Invalid username or password",
+
+	// not found
+	"alumni_not_found":          "Alumni not found",
+	"alumni_not_in_trash":       "Alumni not found in trash",
+	"alumni_nim_exists":         "NIM %s is already used by another alumni (including trashed ones)",
+	"pekerjaan_not_found":       "Employment record not found",
+	"merge_email_conflict":      "Merging would leave several user accounts with email %s (%s); resolve those accounts first",
+	"file_not_found":            "File not found",
+	"history_version_not_found": "Version not found in history",
+	"user_not_found":            "User not found",
+	"last_admin":                "Cannot change the role of the last admin",
+	"custom_field_not_found":    "Custom field not found",
+	"custom_field_exists":       "Custom field %s already exists",
+	"segment_not_found":         "Segment not found",
+	"segment_exists":            "Segment %s already exists",
+	"tag_filter_required":       "Provide at least one filter, search, or segment so tags are not changed on every alumni",
+
+	// upload
+	"upload_missing_file":        "No file uploaded",
+	"upload_unknown_category":    "Unknown file category",
+	"upload_type_not_allowed":    "File type must be one of: %s",
+	"upload_too_large":           "Maximum file size is %s",
+	"file_not_certificate":       "Only certificate files can be verified",
+	"upload_for_other_forbidden": "Users may not upload files for other users",
+	"include_files_forbidden":    "include=files is only allowed for admins or the alumni's own account",
+
+	// import
+	"import_unsupported_format": "File must be .csv or .xlsx",
+	"import_unreadable_file":    "The file is empty or cannot be read",
+	"import_missing_columns":    "Required columns not found: %s",
+	"import_job_not_found":      "Import job not found",
+
+	// export
+	"export_too_many_rows": "The filter matches %d rows but PDF export is limited to %d rows; use csv or xlsx instead",
+	"export.title":         "Alumni Data",
+	"export.generated_at":  "Generated: %s",
+	"export.search":        "Search: %s",
+	"export.filter":        "Filters: %s",
+	"export.sort":          "Sorted by: %s (%s)",
+	"export.total":         "Total rows: %d",
+
+	// admin
+	"invalid_log_level": "Invalid log level",
+
+	// per-field validation (apperror.FieldError.Code)
+	"field.required":  "This field is required",
+	"field.oneof":     "Must be one of: %s",
+	"field.min_len":   "Must be at least %d characters",
+	"field.max_len":   "Must be at most %d characters",
+	"field.min":       "Must be at least %d",
+	"field.max":       "Must be at most %d",
+	"field.email":     "Invalid email address",
+	"field.nim":       "Invalid NIM format",
+	"field.jurusan":   "Jurusan must be one of: %s",
+	"field.year":      "Year must be between %d and %d",
+	"field.date":      "Date must use the YYYY-MM-DD format",
+	"field.gtefield":  "Must not be less than %s",
+	"field.integer":   "Must be a whole number",
+	"field.phone":     "Invalid phone number, e.g. 0812-3456-7890 or +62 812 3456 7890",
+	"field.slug":      "Only lowercase letters, digits and _, starting with a letter",
+	"field.number":    "Must be a number",
+	"field.url":       "Must be an http/https URL",
+	"field.string":    "Must be text",
+	"field.unknown":   "Unknown custom field",
+	"field.max_items": "Must have at most %d items",
+	"field.tag":       "Each tag must be 1-%d characters",
+	"field.query":     "Parameter %s cannot be used in a segment",
+	"field.duplicate": "Same NIM as row %d",
+	"field.in_trash":  "NIM belongs to a trashed alumni; restore or purge it first",
+	"field.nim_taken": "NIM is already used by another alumni",
+
+	// success messages
+	"alumni.soft_deleted":        "Alumni moved to trash",
+	"alumni.restored":            "Alumni restored",
+	"alumni.purged":              "Alumni permanently deleted",
+	"alumni.reverted":            "Alumni reverted to the contents of version %d",
+	"alumni.merged":              "Duplicate alumni merged",
+	"alumni.duplicate_dismissed": "Pair marked as not duplicate",
+	"pekerjaan.created":          "Employment record created",
+	"pekerjaan.updated":          "Employment record updated",
+	"pekerjaan.deleted":          "Employment record permanently deleted",
+	"pekerjaan.soft_deleted":     "Employment record moved to trash",
+	"pekerjaan.restored":         "Employment record restored",
+	"file.uploaded":              "File uploaded successfully",
+	"file.deleted":               "File deleted successfully",
+	"file.verified":              "Certificate verified",
+	"user.role_changed":          "User role changed to %s",
+	"custom_field.deleted":       "Custom field deleted and removed from %d alumni",
+	"segment.deleted":            "Segment deleted",
+	"alumni.tags_updated":        "Tags updated for %d matching alumni",
+}
@@ -1,13 +1,30 @@
 package main
 
 import (
+	"context"
+	"crud_alumni/app/repository"
+	"crud_alumni/app/service"
 	"crud_alumni/config"
 	"crud_alumni/database"
+	"crud_alumni/health"
+	"crud_alumni/metrics"
+	"crud_alumni/middleware"
+	"crud_alumni/migration"
 	"crud_alumni/route"
+	"crud_alumni/tracing"
+	"crud_alumni/utils"
+	"crud_alumni/validation"
+	"errors"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	_ "crud_alumni/docs"
 
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
 	fiberSwagger "github.com/swaggo/fiber-swagger"
 )
 
@@ -22,13 +39,59 @@ import (
 // @name Authorization
 func main() {
 	config.LoadEnv()
-	config.InitLogger()
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal("❌ ", err)
+	}
+	if cfg.UsesDefaultJWTSecret() {
+		log.Println("⚠️  JWT_SECRET tidak diatur, memakai secret default (hanya untuk APP_ENV=development)")
+	}
+	repository.Configure(cfg.Database)
+	validation.Configure(cfg.Alumni)
+	utils.ConfigureJWT(cfg.JWT)
+
+	config.InitLogger(cfg.Log)
+	defer config.CloseLogger()
 	database.ConnectDB()
 
-	app := config.App()
+	if cfg.App.MigrateOnStart {
+		if err := migration.Run(context.Background(), database.DB); errors.Is(err, migration.ErrDeferred) {
+			log.Println("⚠️  Migrasi ditunda, server tetap berjalan: ", err)
+		} else if err != nil {
+			log.Fatal("❌ Migrasi gagal: ", err)
+		}
+	}
+
+	shutdownTracing, err := tracing.Init(context.Background(), cfg.Tracing)
+	if err != nil {
+		log.Fatal("❌ Gagal inisialisasi tracing: ", err)
+	}
+	defer shutdownTracing(context.Background())
+
+	app := config.App(cfg, middleware.ErrorHandler)
+	// header X-Request-ID dari client dipakai jika ada; dicatat di riwayat perubahan
+	app.Use(requestid.New(requestid.Config{ContextKey: "request_id"}))
+	app.Use(middleware.Tracing())
+	app.Use(middleware.Metrics())
+
+	// purge trash otomatis; dihentikan (menunggu purge yang berjalan) setelah
+	// server berhenti menerima request
+	purgeService := service.NewPurgeService(cfg.Trash, service.DefaultTrashTargets()...)
+	stopPurge := purgeService.Start()
+	defer stopPurge()
+
+	// import alumni di background; job yang tertinggal dari proses sebelumnya
+	// ditandai failed, job yang berjalan ditunggu saat server berhenti
+	importService := service.NewImportService(repository.NewImportJobRepository(database.DB), cfg.Import)
+	if n, err := importService.Jobs.FailUnfinished(context.Background(), service.ImportInterrupted); err != nil {
+		log.Println("⚠️  Gagal menandai job import yang tertinggal:", err)
+	} else if n > 0 {
+		log.Println("⚠️  Job import yang tertinggal ditandai failed:", n)
+	}
+	defer importService.Close(30 * time.Second)
 
 	// route setup
-	route.SetupRoutes(app)
+	route.SetupRoutes(app, cfg, purgeService, importService)
 
 	// Swagger route
 	app.Get("/swagger/*", fiberSwagger.WrapHandler)
@@ -36,6 +99,37 @@ func main() {
 	// Static uploads
 	app.Static("/uploads", "./uploads")
 
-	port := config.GetEnv("APP_PORT", "3000")
-	log.Fatal(app.Listen(":" + port))
+	// Metrics: port internal (METRICS_PORT) atau /metrics dengan METRICS_TOKEN
+	if cfg.Metrics.Port != "" {
+		go func() {
+			log.Println("❌ Metrics server berhenti:", metrics.Serve(":"+cfg.Metrics.Port))
+		}()
+	} else if cfg.Metrics.Token != "" {
+		app.Get("/metrics", middleware.MetricsToken(cfg.Metrics.Token), metrics.Handler())
+	} else {
+		log.Println("⚠️  METRICS_PORT/METRICS_TOKEN kosong, endpoint /metrics dinonaktifkan")
+	}
+
+	go gracefulShutdown(app, time.Duration(cfg.App.DrainSeconds)*time.Second)
+
+	if err := app.Listen(":" + cfg.App.Port); err != nil {
+		log.Println("❌ Server berhenti:", err)
+	}
+}
+
+// gracefulShutdown menunggu SIGINT/SIGTERM, menandai /readyz gagal selama
+// SHUTDOWN_DRAIN_SECONDS supaya load balancer berhenti mengirim traffic,
+// lalu menutup server dengan menunggu request yang masih berjalan.
+func gracefulShutdown(app *fiber.App, drain time.Duration) {
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	health.SetDraining(true)
+	log.Println("⏳ Shutdown: draining", drain)
+	time.Sleep(drain)
+
+	if err := app.ShutdownWithTimeout(30 * time.Second); err != nil {
+		log.Println("❌ Gagal shutdown:", err)
+	}
 }
@@ -0,0 +1,71 @@
+// Package mergepatch - JSON Merge Patch (RFC 7396). Key bernilai null
+// menghapus field, object digabung rekursif, nilai lain (termasuk array)
+// menggantikan nilai lama.
+package mergepatch
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+)
+
+// ErrNotObject - patch untuk resource harus berupa object JSON
+var ErrNotObject = errors.New("merge patch harus berupa object JSON")
+
+// Apply menerapkan patch ke dokumen JSON doc dan mengembalikan hasilnya
+func Apply(doc, patch []byte) ([]byte, error) {
+	var target, p any
+	if len(doc) > 0 {
+		if err := json.Unmarshal(doc, &target); err != nil {
+			return nil, err
+		}
+	}
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, err
+	}
+	return json.Marshal(merge(target, p))
+}
+
+// ApplyTo menerapkan patch ke struct dst (pointer) lewat representasi
+// JSON-nya. Field yang dihapus patch kembali ke zero value.
+func ApplyTo(dst any, patch []byte) error {
+	var p any
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return err
+	}
+	if _, ok := p.(map[string]any); !ok {
+		return ErrNotObject
+	}
+
+	doc, err := json.Marshal(dst)
+	if err != nil {
+		return err
+	}
+	merged, err := Apply(doc, patch)
+	if err != nil {
+		return err
+	}
+
+	v := reflect.ValueOf(dst).Elem()
+	v.Set(reflect.Zero(v.Type()))
+	return json.Unmarshal(merged, dst)
+}
+
+func merge(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = map[string]any{}
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+			continue
+		}
+		t[k] = merge(t[k], v)
+	}
+	return t
+}
@@ -0,0 +1,61 @@
+package mergepatch
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// contoh dari RFC 7396 Appendix A
+func TestApply_RFCExamples(t *testing.T) {
+	cases := []struct{ doc, patch, want string }{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"e":null}`, `{"a":1}`, `{"a":1,"e":null}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+	for _, tc := range cases {
+		got, err := Apply([]byte(tc.doc), []byte(tc.patch))
+		if err != nil {
+			t.Fatalf("Apply(%s, %s): %v", tc.doc, tc.patch, err)
+		}
+		var gotV, wantV any
+		json.Unmarshal(got, &gotV)
+		json.Unmarshal([]byte(tc.want), &wantV)
+		if !reflect.DeepEqual(gotV, wantV) {
+			t.Errorf("Apply(%s, %s) = %s, want %s", tc.doc, tc.patch, got, tc.want)
+		}
+	}
+}
+
+func TestApplyTo_Struct(t *testing.T) {
+	type doc struct {
+		Nama   string `json:"nama"`
+		Alamat string `json:"alamat,omitempty"`
+		Tahun  int    `json:"tahun"`
+	}
+	d := doc{Nama: "Budi", Alamat: "Medan", Tahun: 2020}
+
+	if err := ApplyTo(&d, []byte(`{"alamat":null,"tahun":2021}`)); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	want := doc{Nama: "Budi", Tahun: 2021}
+	if d != want {
+		t.Errorf("got %+v, want %+v", d, want)
+	}
+
+	if err := ApplyTo(&d, []byte(`[1]`)); !errors.Is(err, ErrNotObject) {
+		t.Errorf("expected ErrNotObject, got %v", err)
+	}
+}
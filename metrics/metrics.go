@@ -0,0 +1,71 @@
+// Package metrics berisi metric Prometheus aplikasi: HTTP, MongoDB, login,
+// upload file, purge trash, dan runtime Go (lewat default registry).
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "crud_alumni"
+
+var (
+	HTTPRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Jumlah request HTTP per method, route, dan status.",
+	}, []string{"method", "route", "status"})
+
+	HTTPDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency request HTTP per method, route, dan status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	MongoDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "mongo_operation_duration_seconds",
+		Help:      "Durasi command MongoDB per collection, operasi, dan hasil.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+	}, []string{"collection", "operation", "outcome"})
+
+	LoginAttempts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "login_attempts_total",
+		Help:      "Jumlah percobaan login berdasarkan hasil (success/failure).",
+	}, []string{"result"})
+
+	UploadFiles = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "upload_files_total",
+		Help:      "Jumlah file yang berhasil di-upload per kategori.",
+	}, []string{"category"})
+
+	UploadBytes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "upload_bytes_total",
+		Help:      "Total byte file yang berhasil di-upload per kategori.",
+	}, []string{"category"})
+
+	TrashPurged = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "trash_purged_total",
+		Help:      "Jumlah data di trash yang dihapus permanen oleh scheduler per jenis data.",
+	}, []string{"target"})
+)
+
+// ObserveLogin mencatat hasil percobaan login
+func ObserveLogin(success bool) {
+	result := "failure"
+	if success {
+		result = "success"
+	}
+	LoginAttempts.WithLabelValues(result).Inc()
+}
+
+// ObserveUpload mencatat file yang berhasil di-upload
+func ObserveUpload(category string, size int64) {
+	UploadFiles.WithLabelValues(category).Inc()
+	UploadBytes.WithLabelValues(category).Add(float64(size))
+}
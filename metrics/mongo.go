@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// commandInfo disimpan antara event Started dan Succeeded/Failed
+type commandInfo struct {
+	collection string
+	operation  string
+}
+
+// MongoMonitor mengembalikan CommandMonitor yang mengisi MongoDuration.
+// Pasang di koneksi database:
+//
+//	options.Client().ApplyURI(uri).SetMonitor(metrics.MongoMonitor())
+func MongoMonitor() *event.CommandMonitor {
+	var inflight sync.Map
+
+	finish := func(requestID int64, d time.Duration, outcome string) {
+		v, ok := inflight.LoadAndDelete(requestID)
+		if !ok {
+			return
+		}
+		info := v.(commandInfo)
+		MongoDuration.WithLabelValues(info.collection, info.operation, outcome).Observe(d.Seconds())
+	}
+
+	return &event.CommandMonitor{
+		Started: func(_ context.Context, e *event.CommandStartedEvent) {
+			inflight.Store(e.RequestID, commandInfo{
+				collection: commandCollection(e),
+				operation:  e.CommandName,
+			})
+		},
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+			finish(e.RequestID, e.Duration, "success")
+		},
+		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+			finish(e.RequestID, e.Duration, "error")
+		},
+	}
+}
+
+// commandCollection mengambil nama collection dari elemen pertama command,
+// misalnya {"find": "alumni", ...}. Command tanpa collection (ping, hello)
+// dicatat sebagai "-".
+func commandCollection(e *event.CommandStartedEvent) string {
+	if v, err := e.Command.LookupErr(e.CommandName); err == nil {
+		if name, ok := v.StringValueOK(); ok {
+			return name
+		}
+	}
+	return "-"
+}
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"crud_alumni/app/model"
+	"crud_alumni/app/repository"
+	"crud_alumni/config"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// auditDetailKey - key Locals untuk keterangan tambahan dari handler
+const auditDetailKey = "audit_detail"
+
+// Audit mencatat setiap request ke route ini di audit log setelah handler
+// selesai, termasuk yang gagal (status ikut dicatat). :id di route dipakai
+// sebagai resource_id.
+func Audit(action, resource string) fiber.Handler {
+    return func(c *fiber.Ctx) error {
+        err := c.Next()
+
+        status := c.Response().StatusCode()
+        if err != nil {
+            status = statusFromError(err)
+        }
+        RecordAudit(c, model.AuditEntry{
+            Action:     action,
+            Resource:   resource,
+            ResourceID: c.Params("id"),
+            Status:     status,
+        })
+        return err
+    }
+}
+
+// AuditDetail menambahkan keterangan (mis. format export, jumlah baris
+// import) ke entri yang ditulis Audit untuk request ini
+func AuditDetail(c *fiber.Ctx, key, value string) {
+    detail, _ := c.Locals(auditDetailKey).(map[string]string)
+    if detail == nil {
+        detail = map[string]string{}
+        c.Locals(auditDetailKey, detail)
+    }
+    detail[key] = value
+}
+
+// RecordAudit melengkapi e dengan pelaku dari token (jika e belum punya
+// actor), request id, method, path, dan IP, lalu menyimpannya. Kegagalan
+// hanya di-log supaya audit log tidak menggagalkan request.
+func RecordAudit(c *fiber.Ctx, e model.AuditEntry) {
+    if e.ActorID == "" && e.ActorName == "" {
+        e.ActorID, _ = c.Locals("user_id").(string)
+        e.ActorName, _ = c.Locals("username").(string)
+        e.ActorRole, _ = c.Locals("role").(string)
+    }
+    if e.Detail == nil {
+        e.Detail, _ = c.Locals(auditDetailKey).(map[string]string)
+    }
+    requestID, _ := c.Locals("request_id").(string)
+
+    // string dari fiber (params, query, header) memakai buffer yang dipakai
+    // ulang setelah request selesai, jadi disalin
+    e.RequestID = strings.Clone(requestID)
+    e.Method, e.Path, e.IP = strings.Clone(c.Method()), strings.Clone(c.Path()), strings.Clone(c.IP())
+    e.ResourceID, e.ActorName = strings.Clone(e.ResourceID), strings.Clone(e.ActorName)
+    for k, v := range e.Detail {
+        e.Detail[k] = strings.Clone(v)
+    }
+
+    if err := repository.AppendAudit(c.UserContext(), &e); err != nil {
+        config.Logger.Error().Err(err).
+            Str("action", e.Action).Str("resource", e.Resource).Str("resource_id", e.ResourceID).
+            Msg("gagal menulis audit log")
+    }
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"crud_alumni/app/model"
+	"crud_alumni/app/repository"
+	"crud_alumni/apperror"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestAudit_RecordsRequest(t *testing.T) {
+	var entries []model.AuditEntry
+	repository.AppendAuditFunc = func(ctx context.Context, e *model.AuditEntry) error {
+		entries = append(entries, *e)
+		return nil
+	}
+	defer func() { repository.AppendAuditFunc = nil }()
+
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user_id", "u1")
+		c.Locals("username", "admin")
+		c.Locals("role", "admin")
+		return c.Next()
+	})
+	app.Get("/file/:id/download", Audit(model.AuditFileDownload, model.ResourceFiles), func(c *fiber.Ctx) error {
+		if c.Params("id") == "hilang" {
+			return apperror.NotFound(apperror.CodeFileNotFound)
+		}
+		AuditDetail(c, "name", "ijazah.pdf")
+		return c.SendString("ok")
+	})
+
+	for _, path := range []string{"/file/abc/download", "/file/hilang/download"} {
+		if _, err := app.Test(httptest.NewRequest("GET", path, nil)); err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	ok, missing := entries[0], entries[1]
+	if ok.ActorID != "u1" || ok.ActorName != "admin" || ok.Action != model.AuditFileDownload ||
+		ok.ResourceID != "abc" || ok.Status != 200 || ok.Detail["name"] != "ijazah.pdf" || ok.Path != "/file/abc/download" {
+		t.Errorf("unexpected entry: %+v", ok)
+	}
+	// request yang gagal tetap dicatat dengan status dari ErrorHandler
+	if missing.Status != 404 || missing.ResourceID != "hilang" {
+		t.Errorf("unexpected entry for failed request: %+v", missing)
+	}
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"crud_alumni/apperror"
 	"crud_alumni/utils"
 	"strings"
 
@@ -11,17 +12,17 @@ func AuthRequired() fiber.Handler {
     return func(c *fiber.Ctx) error {
         authHeader := c.Get("Authorization")
         if authHeader == "" {
-            return c.Status(401).JSON(fiber.Map{"error": "Token diperlukan"})
+            return apperror.Unauthorized(apperror.CodeTokenRequired)
         }
 
         tokenParts := strings.Split(authHeader, " ")
         if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
-            return c.Status(401).JSON(fiber.Map{"error": "Format token salah"})
+            return apperror.Unauthorized(apperror.CodeTokenMalformed)
         }
 
         claims, err := utils.ValidateToken(tokenParts[1])
         if err != nil {
-            return c.Status(401).JSON(fiber.Map{"error": "Token invalid"})
+            return apperror.Unauthorized(apperror.CodeTokenInvalid)
         }
 
         c.Locals("user_id", claims.UserID)
@@ -33,9 +34,9 @@ func AuthRequired() fiber.Handler {
 
 func AdminOnly() fiber.Handler {
     return func(c *fiber.Ctx) error {
-        role := c.Locals("role").(string)
+        role, _ := c.Locals("role").(string)
         if role != "admin" {
-            return c.Status(403).JSON(fiber.Map{"error": "Hanya admin"})
+            return apperror.Forbidden(apperror.CodeAdminOnly)
         }
         return c.Next()
     }
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"crud_alumni/app/model"
+	"crud_alumni/apperror"
+	"crud_alumni/config"
+	"crud_alumni/i18n"
+	"errors"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ErrorHandler merender semua error sebagai RFC 7807 problem+json dengan
+// pesan dari katalog i18n sesuai Accept-Language. Error yang bukan
+// *apperror.Error dianggap 500 dan detailnya hanya masuk log.
+func ErrorHandler(c *fiber.Ctx, err error) error {
+    appErr := toAppError(err)
+    lang := i18n.Lang(c)
+
+    fields := make([]apperror.FieldError, len(appErr.Fields))
+    for i, f := range appErr.Fields {
+        if f.Message == "" {
+            f.Message = i18n.Message(lang, "field."+f.Code, f.Args...)
+        }
+        fields[i] = f
+    }
+
+    if appErr.Status >= 500 {
+        config.Logger.Error().Err(err).
+            Str("method", c.Method()).
+            Str("path", c.Path()).
+            Str("code", appErr.Code).
+            Msg("request gagal")
+    }
+
+    c.Set(fiber.HeaderContentLanguage, lang)
+    return c.Status(appErr.Status).JSON(model.Problem{
+        Type:     "/problems/" + appErr.Code,
+        Title:    http.StatusText(appErr.Status),
+        Status:   appErr.Status,
+        Detail:   i18n.Message(lang, appErr.Key, appErr.Args...),
+        Instance: c.OriginalURL(),
+        Code:     appErr.Code,
+        Errors:   fields,
+    }, "application/problem+json")
+}
+
+func toAppError(err error) *apperror.Error {
+    if appErr := apperror.As(err); appErr != nil {
+        return appErr
+    }
+
+    var fe *fiber.Error
+    if errors.As(err, &fe) {
+        switch {
+        case fe.Code == fiber.StatusNotFound:
+            return apperror.NotFound(apperror.CodeRouteNotFound)
+        case fe.Code < 500:
+            return apperror.New(fe.Code, apperror.CodeBadRequest)
+        }
+    }
+    return apperror.Internal(err)
+}
+
+// statusFromError - status HTTP yang akan dikirim ErrorHandler untuk err
+func statusFromError(err error) int {
+    return toAppError(err).Status
+}
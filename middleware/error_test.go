@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"crud_alumni/app/model"
+	"crud_alumni/apperror"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func doProblem(t *testing.T, handler fiber.Handler, path string) (int, string, model.Problem) {
+	t.Helper()
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Get("/test", handler)
+
+	resp, err := app.Test(httptest.NewRequest("GET", path, nil))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	var p model.Problem
+	json.NewDecoder(resp.Body).Decode(&p)
+	return resp.StatusCode, resp.Header.Get("Content-Type"), p
+}
+
+func TestErrorHandler_AppError(t *testing.T) {
+	status, ctype, p := doProblem(t, func(c *fiber.Ctx) error {
+		return apperror.Validation(apperror.FieldError{Field: "email", Code: "email", Message: "Email tidak valid"})
+	}, "/test")
+
+	if status != 422 {
+		t.Errorf("expected 422, got %d", status)
+	}
+	if ctype != "application/problem+json" {
+		t.Errorf("expected problem+json, got %s", ctype)
+	}
+	if p.Code != apperror.CodeValidationFailed || len(p.Errors) != 1 || p.Errors[0].Field != "email" {
+		t.Errorf("problem tidak sesuai: %+v", p)
+	}
+}
+
+func TestErrorHandler_UnknownErrorDoesNotLeak(t *testing.T) {
+	status, _, p := doProblem(t, func(c *fiber.Ctx) error {
+		return errors.New("connection refused 10.0.0.5:27017")
+	}, "/test")
+
+	if status != 500 || p.Code != apperror.CodeInternal {
+		t.Errorf("expected 500 internal_error, got %d %s", status, p.Code)
+	}
+	if p.Detail == "connection refused 10.0.0.5:27017" {
+		t.Errorf("detail error internal tidak boleh dikirim ke client")
+	}
+}
+
+func TestErrorHandler_RouteNotFound(t *testing.T) {
+	status, _, p := doProblem(t, func(c *fiber.Ctx) error { return nil }, "/tidak-ada")
+
+	if status != 404 || p.Code != apperror.CodeRouteNotFound {
+		t.Errorf("expected 404 route_not_found, got %d %s", status, p.Code)
+	}
+}
+
+func TestErrorHandler_TranslatesByAcceptLanguage(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Get("/test", func(c *fiber.Ctx) error {
+		return apperror.NotFound(apperror.CodeAlumniNotFound)
+	})
+
+	for lang, want := range map[string]string{"en-US,en;q=0.9": "Alumni not found", "id": "Alumni tidak ditemukan"} {
+		req := httptest.NewRequest("GET", "/test", nil)
+		req.Header.Set("Accept-Language", lang)
+		resp, _ := app.Test(req)
+
+		var p model.Problem
+		json.NewDecoder(resp.Body).Decode(&p)
+		if p.Detail != want {
+			t.Errorf("Accept-Language %q: expected %q, got %q", lang, want, p.Detail)
+		}
+	}
+}
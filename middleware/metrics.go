@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"crud_alumni/apperror"
+	"crud_alumni/metrics"
+	"crypto/subtle"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Metrics mencatat jumlah dan latency request per route (pola route, bukan URL
+// asli, supaya label tidak meledak) dan status.
+func Metrics() fiber.Handler {
+    return func(c *fiber.Ctx) error {
+        start := time.Now()
+        err := c.Next()
+
+        status := c.Response().StatusCode()
+        if err != nil {
+            status = statusFromError(err)
+        }
+
+        route := c.Route().Path
+        if status == fiber.StatusNotFound && route == "/" {
+            route = "unmatched"
+        }
+
+        labels := []string{c.Method(), route, strconv.Itoa(status)}
+        metrics.HTTPRequests.WithLabelValues(labels...).Inc()
+        metrics.HTTPDuration.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+        return err
+    }
+}
+
+// MetricsToken melindungi endpoint /metrics dengan token terpisah dari JWT user
+func MetricsToken(token string) fiber.Handler {
+    return func(c *fiber.Ctx) error {
+        expected := "Bearer " + token
+        if subtle.ConstantTimeCompare([]byte(c.Get("Authorization")), []byte(expected)) != 1 {
+            return apperror.Unauthorized(apperror.CodeTokenInvalid)
+        }
+        return c.Next()
+    }
+}
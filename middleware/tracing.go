@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"crud_alumni/tracing"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracing membuat span server untuk setiap request. Header traceparent /
+// tracestate dari client dilanjutkan, dan context span disimpan di
+// c.UserContext() supaya service dan repository bisa membuat child span.
+func Tracing() fiber.Handler {
+    return func(c *fiber.Ctx) error {
+        header := http.Header{}
+        c.Request().Header.VisitAll(func(k, v []byte) {
+            header.Add(string(k), string(v))
+        })
+        ctx := otel.GetTextMapPropagator().Extract(c.UserContext(), propagation.HeaderCarrier(header))
+
+        ctx, span := tracing.Start(ctx, c.Method()+" "+c.Path(),
+            trace.WithSpanKind(trace.SpanKindServer),
+            trace.WithAttributes(
+                semconv.HTTPRequestMethodKey.String(c.Method()),
+                semconv.URLPath(c.Path()),
+            ),
+        )
+        defer span.End()
+        c.SetUserContext(ctx)
+
+        err := c.Next()
+
+        // nama span memakai pola route supaya tidak berisi ID
+        route := c.Route().Path
+        span.SetName(c.Method() + " " + route)
+        span.SetAttributes(semconv.HTTPRoute(route))
+
+        status := c.Response().StatusCode()
+        if err != nil {
+            span.RecordError(err)
+            status = statusFromError(err)
+        }
+        span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+        if status >= 500 {
+            span.SetStatus(codes.Error, http.StatusText(status))
+        }
+
+        // kembalikan trace id supaya client bisa melaporkan request yang lambat
+        if sc := span.SpanContext(); sc.IsValid() {
+            c.Set("X-Trace-Id", sc.TraceID().String())
+        }
+        return err
+    }
+}
@@ -0,0 +1,33 @@
+package migration
+
+import (
+	"context"
+	"crud_alumni/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Dokumen lama belum punya field version (dipakai untuk ETag / If-Match).
+// Semua diisi versi 1.
+func init() {
+	Register(Migration{
+		ID: "20261019_document_version",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			for _, coll := range []*mongo.Collection{
+				database.AlumniCollection,
+				database.PekerjaanCollection,
+				database.UserCollection,
+			} {
+				_, err := coll.UpdateMany(ctx,
+					bson.M{"version": bson.M{"$exists": false}},
+					bson.M{"$set": bson.M{"version": 1}},
+				)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+}
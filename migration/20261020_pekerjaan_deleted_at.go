@@ -0,0 +1,25 @@
+package migration
+
+import (
+	"context"
+	"crud_alumni/database"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Pekerjaan yang di-soft delete sebelum ada deleted_at tidak punya waktu
+// hapus. Diisi waktu migrasi supaya mendapat masa retensi penuh sebelum purge.
+func init() {
+	Register(Migration{
+		ID: "20261020_pekerjaan_deleted_at",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			_, err := database.PekerjaanCollection.UpdateMany(ctx,
+				bson.M{"isdellete": "yes", "deleted_at": bson.M{"$exists": false}},
+				bson.M{"$set": bson.M{"deleted_at": time.Now()}},
+			)
+			return err
+		},
+	})
+}
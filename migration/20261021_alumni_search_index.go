@@ -0,0 +1,36 @@
+package migration
+
+import (
+	"context"
+	"crud_alumni/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Index untuk pencarian alumni: text index (search_mode=text, skor relevansi)
+// dan index nim untuk pencarian prefix NIM. default_language "none" karena
+// MongoDB tidak punya stemmer bahasa Indonesia; text index versi 3 sudah
+// mengabaikan huruf besar/kecil dan diakritik.
+func init() {
+	Register(Migration{
+		ID: "20261021_alumni_search_index",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			_, err := database.AlumniCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+				{
+					Keys: bson.D{{Key: "nama", Value: "text"}, {Key: "jurusan", Value: "text"}, {Key: "email", Value: "text"}},
+					Options: options.Index().
+						SetName("alumni_text").
+						SetDefaultLanguage("none").
+						SetWeights(bson.D{{Key: "nama", Value: 10}, {Key: "jurusan", Value: 3}, {Key: "email", Value: 1}}),
+				},
+				{
+					Keys:    bson.D{{Key: "nim", Value: 1}},
+					Options: options.Index().SetName("alumni_nim"),
+				},
+			})
+			return err
+		},
+	})
+}
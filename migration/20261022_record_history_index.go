@@ -0,0 +1,24 @@
+package migration
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Index riwayat perubahan: daftar riwayat satu record dan pencarian versi
+// untuk revert
+func init() {
+	Register(Migration{
+		ID: "20261022_record_history_index",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			_, err := db.Collection("record_history").Indexes().CreateOne(ctx, mongo.IndexModel{
+				Keys:    bson.D{{Key: "resource", Value: 1}, {Key: "record_id", Value: 1}, {Key: "version", Value: -1}},
+				Options: options.Index().SetName("history_record_version"),
+			})
+			return err
+		},
+	})
+}
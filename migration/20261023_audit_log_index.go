@@ -0,0 +1,38 @@
+package migration
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Index audit log: seq unik menjaga rantai hash tetap satu walau ditulis
+// beberapa instance; index lain untuk filter actor, action, dan tanggal.
+func init() {
+	Register(Migration{
+		ID: "20261023_audit_log_index",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			_, err := db.Collection("audit_log").Indexes().CreateMany(ctx, []mongo.IndexModel{
+				{
+					Keys:    bson.D{{Key: "seq", Value: 1}},
+					Options: options.Index().SetName("audit_seq").SetUnique(true),
+				},
+				{
+					Keys:    bson.D{{Key: "actor_id", Value: 1}, {Key: "seq", Value: -1}},
+					Options: options.Index().SetName("audit_actor"),
+				},
+				{
+					Keys:    bson.D{{Key: "action", Value: 1}, {Key: "seq", Value: -1}},
+					Options: options.Index().SetName("audit_action"),
+				},
+				{
+					Keys:    bson.D{{Key: "at", Value: 1}},
+					Options: options.Index().SetName("audit_at"),
+				},
+			})
+			return err
+		},
+	})
+}
@@ -0,0 +1,23 @@
+package migration
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Index pasangan alumni yang ditandai bukan duplikat: satu dokumen per pasangan
+func init() {
+	Register(Migration{
+		ID: "20261024_duplicate_dismissal_index",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			_, err := db.Collection("duplicate_dismissals").Indexes().CreateOne(ctx, mongo.IndexModel{
+				Keys:    bson.D{{Key: "a", Value: 1}, {Key: "b", Value: 1}},
+				Options: options.Index().SetName("dismissal_pair").SetUnique(true),
+			})
+			return err
+		},
+	})
+}
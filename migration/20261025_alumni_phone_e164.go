@@ -0,0 +1,72 @@
+package migration
+
+import (
+	"context"
+	"crud_alumni/config"
+	"crud_alumni/phone"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// no_telepon dulu disimpan sebagai angka sehingga angka 0 di depan hilang
+// (0812... menjadi 812...). Diubah ke string E.164, termasuk di snapshot
+// riwayat supaya revert ke versi lama tetap bisa dibaca. Nilai yang tidak
+// bisa dinormalisasi disimpan sebagai string angka apa adanya dan harus
+// diperbaiki saat data diedit (validasi akan menolaknya). Version tidak
+// dinaikkan karena isi datanya tidak berubah.
+func init() {
+	Register(Migration{
+		ID: "20261025_alumni_phone_e164",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			for _, target := range []struct{ coll, field string }{
+				{"alumni", "no_telepon"},
+				{"record_history", "snapshot.no_telepon"},
+			} {
+				if err := phoneToE164(ctx, db.Collection(target.coll), target.field); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+}
+
+func phoneToE164(ctx context.Context, coll *mongo.Collection, field string) error {
+	filter := bson.M{field: bson.M{"$type": "number"}}
+	cursor, err := coll.Find(ctx, filter, options.Find().SetProjection(bson.M{field: 1}))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	invalid := 0
+	for cursor.Next(ctx) {
+		raw, err := cursor.Current.LookupErr(strings.Split(field, ".")...)
+		if err != nil {
+			continue
+		}
+		n, ok := raw.AsInt64OK()
+		if !ok {
+			continue
+		}
+
+		digits := strconv.FormatInt(n, 10)
+		value, err := phone.Normalize(digits)
+		if err != nil {
+			value = digits
+			invalid++
+		}
+		if _, err := coll.UpdateOne(ctx, bson.M{"_id": cursor.Current.Lookup("_id")}, bson.M{"$set": bson.M{field: value}}); err != nil {
+			return err
+		}
+	}
+	if invalid > 0 {
+		config.Logger.Warn().Str("collection", coll.Name()).Int("invalid", invalid).
+			Msg("no_telepon tidak bisa dinormalisasi ke E.164, disimpan sebagai angka")
+	}
+	return cursor.Err()
+}
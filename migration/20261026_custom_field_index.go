@@ -0,0 +1,23 @@
+package migration
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Nama custom field unik karena dipakai sebagai key di alumni.custom
+func init() {
+	Register(Migration{
+		ID: "20261026_custom_field_index",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			_, err := db.Collection("custom_fields").Indexes().CreateOne(ctx, mongo.IndexModel{
+				Keys:    bson.D{{Key: "name", Value: 1}},
+				Options: options.Index().SetName("custom_field_name").SetUnique(true),
+			})
+			return err
+		},
+	})
+}
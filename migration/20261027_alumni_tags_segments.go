@@ -0,0 +1,31 @@
+package migration
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Index tags untuk filter ?tag= dan bulk tag; nama segment unik supaya
+// mudah dipilih dari daftar
+func init() {
+	Register(Migration{
+		ID: "20261027_alumni_tags_segments",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			_, err := db.Collection("alumni").Indexes().CreateOne(ctx, mongo.IndexModel{
+				Keys:    bson.D{{Key: "tags", Value: 1}},
+				Options: options.Index().SetName("alumni_tags"),
+			})
+			if err != nil {
+				return err
+			}
+			_, err = db.Collection("segments").Indexes().CreateOne(ctx, mongo.IndexModel{
+				Keys:    bson.D{{Key: "name", Value: 1}},
+				Options: options.Index().SetName("segment_name").SetUnique(true),
+			})
+			return err
+		},
+	})
+}
@@ -0,0 +1,86 @@
+package migration
+
+import (
+	"context"
+	"crud_alumni/config"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// PUT/PATCH, import, dan revert dulu menyimpan alumni dengan ReplaceOne
+// sehingga id numerik lama (dirujuk pekerjaan.alumni_id) ikut terhapus.
+// id dikembalikan dari riwayat perubahan jika ada snapshot atau perubahan
+// yang masih memuatnya. Alumni yang tidak bisa diperbaiki dicatat di log
+// supaya id-nya bisa dikembalikan dari backup. Version tidak dinaikkan.
+func init() {
+	Register(Migration{
+		ID: "20261028_alumni_legacy_id_repair",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			alumni, history := db.Collection("alumni"), db.Collection("record_history")
+			cursor, err := alumni.Find(ctx, bson.M{"id": bson.M{"$exists": false}},
+				options.Find().SetProjection(bson.M{"_id": 1}))
+			if err != nil {
+				return err
+			}
+			defer cursor.Close(ctx)
+
+			var unrepaired []string
+			for cursor.Next(ctx) {
+				id := cursor.Current.Lookup("_id")
+				legacy, err := legacyIDFromHistory(ctx, history, id)
+				if err != nil {
+					return err
+				}
+				if legacy == nil {
+					unrepaired = append(unrepaired, id.ObjectID().Hex())
+					continue
+				}
+				if _, err := alumni.UpdateOne(ctx, bson.M{"_id": id, "id": bson.M{"$exists": false}}, bson.M{"$set": bson.M{"id": legacy}}); err != nil {
+					return err
+				}
+			}
+			if len(unrepaired) > 0 {
+				config.Logger.Warn().Strs("alumni", unrepaired).
+					Msg("id lama alumni tidak ditemukan di riwayat, kembalikan dari backup agar pekerjaan tetap terhubung")
+			}
+			return cursor.Err()
+		},
+	})
+}
+
+// legacyIDFromHistory - id lama terbaru yang tercatat di riwayat alumni,
+// dari snapshot atau dari nilai sebelum perubahan; nil jika tidak ada
+func legacyIDFromHistory(ctx context.Context, history *mongo.Collection, recordID bson.RawValue) (any, error) {
+	var entry struct {
+		Snapshot bson.M `bson:"snapshot"`
+		Changes  []struct {
+			Field  string `bson:"field"`
+			Before any    `bson:"before"`
+		} `bson:"changes"`
+	}
+	err := history.FindOne(ctx, bson.M{
+		"resource":  "alumni",
+		"record_id": recordID,
+		"$or": bson.A{
+			bson.M{"snapshot.id": bson.M{"$exists": true}},
+			bson.M{"changes": bson.M{"$elemMatch": bson.M{"field": "id", "before": bson.M{"$ne": nil}}}},
+		},
+	}, options.FindOne().SetSort(bson.D{{Key: "version", Value: -1}})).Decode(&entry)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if legacy, ok := entry.Snapshot["id"]; ok && legacy != nil {
+		return legacy, nil
+	}
+	for _, ch := range entry.Changes {
+		if ch.Field == "id" {
+			return ch.Before, nil
+		}
+	}
+	return nil, nil
+}
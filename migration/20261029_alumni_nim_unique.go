@@ -0,0 +1,86 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// nimDuplicateReport - jumlah NIM ganda yang ditampilkan di pesan error
+const nimDuplicateReport = 20
+
+// NIM alumni unik, termasuk yang di trash, supaya import (upsert per NIM) dan
+// create tidak bisa membuat alumni kedua dengan NIM yang sama. Migrasi ini
+// Deferrable: selama masih ada NIM ganda, server tetap berjalan supaya data
+// bisa dirapikan lewat merge, dan migrasi dicoba lagi saat start berikutnya.
+func init() {
+	Register(Migration{ID: "20261029_alumni_nim_unique", Up: ensureNIMUnique, Deferrable: true})
+}
+
+// ensureNIMUnique membuat index unik alumni_nim_scope_unique pada (nim,
+// nim_scope). nim_scope hanya terisi pada duplikat hasil merge (berisi _id
+// duplikat itu sendiri), jadi beberapa duplikat yang di-merge ke survivor
+// yang sama boleh tetap memakai NIM survivor. Index alumni_nim lama (tidak
+// unik) dan alumni_nim_unique versi awal (nim, merged_into) diganti; prefix
+// nim tetap memakai index baru. Jika masih ada NIM ganda, index tidak dibuat.
+func ensureNIMUnique(ctx context.Context, db *mongo.Database) error {
+	alumni := db.Collection("alumni")
+	if _, err := alumni.UpdateMany(ctx,
+		bson.M{"merged_into": bson.M{"$exists": true}, "nim_scope": bson.M{"$exists": false}},
+		mongo.Pipeline{{{Key: "$set", Value: bson.M{"nim_scope": "$_id"}}}},
+	); err != nil {
+		return err
+	}
+
+	cursor, err := alumni.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"nim": "$nim", "nim_scope": bson.M{"$ifNull": bson.A{"$nim_scope", nil}}},
+			"count": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$match", Value: bson.M{"count": bson.M{"$gt": 1}}}},
+		{{Key: "$sort", Value: bson.M{"_id.nim": 1}}},
+	})
+	if err != nil {
+		return err
+	}
+	var groups []struct {
+		ID struct {
+			NIM any `bson:"nim"`
+		} `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return err
+	}
+	if len(groups) > 0 {
+		var nims []string
+		for _, g := range groups[:min(len(groups), nimDuplicateReport)] {
+			nims = append(nims, fmt.Sprint(g.ID.NIM))
+		}
+		return fmt.Errorf("%d NIM alumni masih ganda (mis. %s); merge atau purge dulu alumni yang ganda",
+			len(groups), strings.Join(nims, ", "))
+	}
+
+	if _, err := alumni.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "nim", Value: 1}, {Key: "nim_scope", Value: 1}},
+		Options: options.Index().SetName("alumni_nim_scope_unique").SetUnique(true),
+	}); err != nil {
+		return err
+	}
+	for _, name := range []string{"alumni_nim_unique", "alumni_nim"} {
+		if _, err := alumni.Indexes().DropOne(ctx, name); err != nil && !isIndexNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// isIndexNotFound - index yang mau di-drop memang tidak ada
+func isIndexNotFound(err error) bool {
+	var cmdErr mongo.CommandError
+	return errors.As(err, &cmdErr) && cmdErr.Code == 27 // IndexNotFound
+}
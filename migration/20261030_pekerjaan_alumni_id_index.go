@@ -0,0 +1,26 @@
+package migration
+
+import (
+	"context"
+	"crud_alumni/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Filter has_pekerjaan/currently_employed mencari pekerjaan per alumni lewat
+// $lookup (alumni.id = pekerjaan.alumni_id); tanpa index setiap alumni
+// memindai seluruh koleksi pekerjaan
+func init() {
+	Register(Migration{
+		ID: "20261030_pekerjaan_alumni_id_index",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			_, err := database.PekerjaanCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+				Keys:    bson.D{{Key: "alumni_id", Value: 1}},
+				Options: options.Index().SetName("pekerjaan_alumni_id"),
+			})
+			return err
+		},
+	})
+}
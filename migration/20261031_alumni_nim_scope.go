@@ -0,0 +1,8 @@
+package migration
+
+// Database yang sudah menerapkan versi awal 20261029_alumni_nim_unique
+// (index unik nim + merged_into) dipindah ke index nim + nim_scope; lihat
+// ensureNIMUnique. Tidak mengubah apa pun jika index baru sudah ada.
+func init() {
+	Register(Migration{ID: "20261031_alumni_nim_scope", Up: ensureNIMUnique, Deferrable: true})
+}
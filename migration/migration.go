@@ -0,0 +1,122 @@
+// Package migration menjalankan migrasi data MongoDB secara berurutan dan
+// mencatat yang sudah diterapkan di collection "migrations".
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const collectionName = "migrations"
+
+// Migration - satu langkah migrasi. ID dipakai sebagai urutan (mis. "20250101_nama").
+type Migration struct {
+	ID string
+	Up func(ctx context.Context, db *mongo.Database) error
+	// Deferrable - kegagalan migrasi ini tidak menghentikan Run maupun
+	// menggagalkan readiness; migrasi tetap tercatat belum diterapkan dan
+	// dicoba lagi pada Run berikutnya. Dipakai untuk migrasi yang butuh data
+	// dirapikan dulu lewat aplikasi (mis. index unik saat data masih ganda).
+	Deferrable bool
+}
+
+// ErrDeferred - dibungkus error Run jika yang gagal hanya migrasi Deferrable
+var ErrDeferred = errors.New("migrasi ditunda")
+
+type record struct {
+	ID        string    `bson:"_id"`
+	AppliedAt time.Time `bson:"applied_at"`
+}
+
+var registry []Migration
+
+// Register menambahkan migrasi ke daftar (dipanggil dari init() file migrasi)
+func Register(m Migration) {
+	registry = append(registry, m)
+}
+
+// All mengembalikan semua migrasi terdaftar, urut berdasarkan ID
+func All() []Migration {
+	list := append([]Migration(nil), registry...)
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+// Pending mengembalikan ID migrasi yang belum diterapkan
+func Pending(ctx context.Context, db *mongo.Database) ([]string, error) {
+	applied, err := appliedIDs(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	var pending []string
+	for _, m := range All() {
+		if !applied[m.ID] {
+			pending = append(pending, m.ID)
+		}
+	}
+	return pending, nil
+}
+
+// Deferrable - migrasi id boleh tertunda (lihat Migration.Deferrable)
+func Deferrable(id string) bool {
+	for _, m := range registry {
+		if m.ID == id {
+			return m.Deferrable
+		}
+	}
+	return false
+}
+
+// Run menerapkan semua migrasi yang belum tercatat, berhenti di error pertama.
+// Migrasi Deferrable yang gagal dilewati; jika ada, hasilnya error yang
+// membungkus ErrDeferred setelah migrasi lain selesai.
+func Run(ctx context.Context, db *mongo.Database) error {
+	applied, err := appliedIDs(ctx, db)
+	if err != nil {
+		return err
+	}
+	var deferred []error
+	for _, m := range All() {
+		if applied[m.ID] {
+			continue
+		}
+		if err := m.Up(ctx, db); err != nil {
+			if m.Deferrable {
+				deferred = append(deferred, fmt.Errorf("%s: %w", m.ID, err))
+				continue
+			}
+			return err
+		}
+		if _, err := db.Collection(collectionName).InsertOne(ctx, record{ID: m.ID, AppliedAt: time.Now()}); err != nil {
+			return err
+		}
+	}
+	if len(deferred) > 0 {
+		return fmt.Errorf("%w: %w", ErrDeferred, errors.Join(deferred...))
+	}
+	return nil
+}
+
+func appliedIDs(ctx context.Context, db *mongo.Database) (map[string]bool, error) {
+	cursor, err := db.Collection(collectionName).Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var records []record
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+	applied := make(map[string]bool, len(records))
+	for _, r := range records {
+		applied[r.ID] = true
+	}
+	return applied, nil
+}
@@ -0,0 +1,92 @@
+// Package phone - normalisasi nomor telepon ke format E.164 (+62812...).
+// Nomor tanpa kode negara dianggap nomor Indonesia.
+package phone
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// DefaultCountryCode - kode negara untuk nomor tanpa awalan + atau 00
+const DefaultCountryCode = "62"
+
+// ErrInvalid - nomor tidak bisa dinormalisasi ke E.164
+var ErrInvalid = errors.New("nomor telepon tidak valid")
+
+// separators - pemisah yang biasa diketik dan dibuang sebelum normalisasi
+var separators = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "", "/", "")
+
+var searchSeparators = strings.NewReplacer(" ", "", "-", "", "(", "", ")", "")
+
+var digitsOnly = regexp.MustCompile(`^[0-9]+$`)
+
+// Normalize mengubah raw menjadi E.164. Yang diterima:
+//
+//	+62 812-3456-7890, 0062812...   kode negara eksplisit
+//	0812 3456 7890                  nomor nasional (awalan 0 diganti +62)
+//	62812..., 812...                tanpa awalan (data lama yang disimpan sebagai angka)
+//
+// "+62 0812..." juga diterima; angka 0 setelah kode negara dibuang.
+// Nomor Indonesia harus 7-12 digit setelah 62, nomor negara lain 8-15 digit
+// termasuk kode negara.
+func Normalize(raw string) (string, error) {
+	s := separators.Replace(strings.TrimSpace(raw))
+	international := false
+	switch {
+	case strings.HasPrefix(s, "+"):
+		s, international = s[1:], true
+	case strings.HasPrefix(s, "00"):
+		s, international = s[2:], true
+	case strings.HasPrefix(s, "0"):
+		s = DefaultCountryCode + s[1:]
+	case !strings.HasPrefix(s, DefaultCountryCode):
+		s = DefaultCountryCode + s
+	}
+	if !digitsOnly.MatchString(s) || s[0] == '0' {
+		return "", ErrInvalid
+	}
+	if !international || strings.HasPrefix(s, DefaultCountryCode) {
+		national := strings.TrimPrefix(strings.TrimPrefix(s, DefaultCountryCode), "0")
+		if len(national) < 7 || len(national) > 12 {
+			return "", ErrInvalid
+		}
+		return "+" + DefaultCountryCode + national, nil
+	}
+	if len(s) < 8 || len(s) > 15 {
+		return "", ErrInvalid
+	}
+	return "+" + s, nil
+}
+
+// SearchPattern - regex untuk mencari no_telepon (E.164) dari kata kunci
+// yang diketik user. ok false jika q bukan potongan nomor telepon: minimal 4
+// digit, hanya boleh berisi spasi, strip, atau kurung sebagai pemisah (titik
+// tidak, supaya NIM seperti "2019.01" tidak dianggap nomor telepon).
+// "0812..." dan "+62812..." sama-sama menjadi awalan "+62812"; potongan tanpa
+// awalan dicari di posisi mana saja.
+func SearchPattern(q string) (pattern string, ok bool) {
+	s := searchSeparators.Replace(strings.TrimSpace(q))
+	typed := len(strings.TrimPrefix(s, "+"))
+	anchored := true
+	switch {
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	case strings.HasPrefix(s, "00"):
+		s = s[2:]
+	case strings.HasPrefix(s, "0"):
+		s = DefaultCountryCode + s[1:]
+	default:
+		anchored = false
+	}
+	if !digitsOnly.MatchString(s) || typed < 4 {
+		return "", false
+	}
+	if anchored {
+		if strings.HasPrefix(s, DefaultCountryCode+"0") {
+			s = DefaultCountryCode + s[len(DefaultCountryCode)+1:]
+		}
+		return `^\+` + s, true
+	}
+	return s, true
+}
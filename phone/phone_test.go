@@ -0,0 +1,55 @@
+package phone
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	cases := []struct{ in, want string }{
+		{"081234567890", "+6281234567890"},
+		{"0812-3456-7890", "+6281234567890"},
+		{"+62 812 3456 7890", "+6281234567890"},
+		{"+62 0812 3456 7890", "+6281234567890"},
+		{"0062812.3456.7890", "+6281234567890"},
+		{"6281234567890", "+6281234567890"},
+		{"81234567890", "+6281234567890"}, // data lama: angka 0 di depan hilang
+		{"(021) 5551234", "+62215551234"},
+		{"+65 6123 4567", "+6561234567"},
+		{"+1 (415) 555-2671", "+14155552671"},
+	}
+	for _, tc := range cases {
+		got, err := Normalize(tc.in)
+		if err != nil || got != tc.want {
+			t.Errorf("Normalize(%q) = %q, %v; want %q", tc.in, got, err, tc.want)
+		}
+	}
+
+	for _, in := range []string{"", "0812", "08123456789012345", "0812abc4567", "+0812345678", "+123", "08123x", "+62 812+3456"} {
+		if got, err := Normalize(in); err == nil {
+			t.Errorf("Normalize(%q) = %q, want error", in, got)
+		}
+	}
+}
+
+func TestSearchPattern(t *testing.T) {
+	const stored = "+6281234567890"
+	for _, q := range []string{"0812", "0812-3456", "+62812", "+62 0812", "62812", "812345", "7890"} {
+		pattern, ok := SearchPattern(q)
+		if !ok {
+			t.Errorf("SearchPattern(%q) not ok", q)
+			continue
+		}
+		if !regexp.MustCompile(pattern).MatchString(stored) {
+			t.Errorf("SearchPattern(%q) = %q does not match %s", q, pattern, stored)
+		}
+	}
+	if pattern, _ := SearchPattern("0813"); regexp.MustCompile(pattern).MatchString(stored) {
+		t.Errorf("0813 must not match %s", stored)
+	}
+	for _, q := range []string{"budi", "081", "0812a", "434221001x", "2019.01"} {
+		if _, ok := SearchPattern(q); ok {
+			t.Errorf("SearchPattern(%q) should not be a phone query", q)
+		}
+	}
+}
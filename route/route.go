@@ -54,15 +54,31 @@
 package route
 
 import (
+	"crud_alumni/app/model"
 	"crud_alumni/app/repository"
 	"crud_alumni/app/service"
+	"crud_alumni/config"
 	"crud_alumni/database"
+	"crud_alumni/health"
 	"crud_alumni/middleware"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-func SetupRoutes(app *fiber.App) {
+func SetupRoutes(app *fiber.App, cfg *config.Config, purge *service.PurgeService, importService *service.ImportService) {
+	service.Configure(cfg)
+
+	// === HEALTH (tanpa auth, untuk probe orchestrator) ===
+	minFree := uint64(cfg.Upload.MinFree)
+	healthService := service.NewHealthService(
+		health.MongoCheck(database.DB),
+		health.UploadDirCheck(cfg.Upload.Foto.Dir, minFree),
+		health.UploadDirCheck(cfg.Upload.Sertifikat.Dir, minFree),
+		health.MigrationCheck(database.DB),
+	)
+	app.Get("/healthz", healthService.Liveness)
+	app.Get("/readyz", healthService.Readiness)
+
 	api := app.Group("/api")
 
 	api.Post("/login", service.LoginHandler)
@@ -74,10 +90,32 @@ func SetupRoutes(app *fiber.App) {
 	alumni := protected.Group("/alumni")
 	alumni.Get("/", service.GetAllAlumni)
 	alumni.Get("/pag", service.GetAlumniPagination)
+	alumni.Get("/suggest", service.SuggestAlumni)
+	alumni.Get("/trash", middleware.AdminOnly(), service.GetAlumniTrash)
+	alumni.Get("/export", middleware.AdminOnly(), middleware.Audit(model.AuditExport, model.ResourceAlumni), service.ExportAlumni)
+	alumni.Post("/import", middleware.AdminOnly(), middleware.Audit(model.AuditImport, model.ResourceAlumni), importService.ImportAlumni)
+	alumni.Get("/import/:job_id", middleware.AdminOnly(), importService.GetImportJob)
+	alumni.Get("/tags", service.GetAlumniTags)
+	alumni.Post("/tags", middleware.AdminOnly(), middleware.Audit(model.AuditBulkTag, model.ResourceAlumni), service.BulkTagAlumni)
 	alumni.Get("/:id", service.GetAlumniByID)
+	alumni.Get("/:id/profile", service.GetAlumniProfile)
+	alumni.Get("/:id/history", middleware.AdminOnly(), service.GetAlumniHistory)
+	alumni.Post("/:id/history/:version/revert", middleware.AdminOnly(), service.RevertAlumni)
+	alumni.Post("/:id/merge", middleware.AdminOnly(), middleware.Audit(model.AuditMerge, model.ResourceAlumni), service.MergeAlumni)
 	alumni.Post("/", middleware.AdminOnly(), service.CreateAlumni)
 	alumni.Put("/:id", middleware.AdminOnly(), service.UpdateAlumni)
+	alumni.Patch("/:id", middleware.AdminOnly(), service.PatchAlumni)
 	alumni.Delete("/:id", middleware.AdminOnly(), service.DeleteAlumni)
+	alumni.Put("/:id/restore", middleware.AdminOnly(), service.RestoreAlumni)
+	alumni.Delete("/:id/purge", middleware.AdminOnly(), middleware.Audit(model.AuditHardDelete, model.ResourceAlumni), service.PurgeAlumni)
+
+	// definisi custom field alumni; ubah/hapus lewat /admin/custom-fields
+	protected.Get("/custom-fields", service.GetCustomFields)
+
+	// segment: filter alumni yang disimpan, dipakai lewat ?segment=<id>
+	protected.Get("/segments", service.GetSegments)
+	protected.Get("/segments/:id", service.GetSegmentByID)
+	protected.Get("/segments/:id/count", service.CountSegment)
 
 	// === PEKERJAAN ===
 	pekerjaan := protected.Group("/pekerjaan")
@@ -91,12 +129,13 @@ func SetupRoutes(app *fiber.App) {
     pekerjaan.Get("/alumni/:alumni_id", middleware.AdminOnly(), service.GetPekerjaanByAlumniID)
     pekerjaan.Post("/", middleware.AdminOnly(), service.CreatePekerjaan)
     pekerjaan.Put("/:id", middleware.AdminOnly(), service.UpdatePekerjaan)
-    pekerjaan.Delete("/:id", middleware.AdminOnly(), service.DeletePekerjaan)
-    pekerjaan.Delete("/hard/:id", service.DeletePekerjaan)
+    pekerjaan.Patch("/:id", middleware.AdminOnly(), service.PatchPekerjaan)
+    pekerjaan.Delete("/:id", middleware.AdminOnly(), middleware.Audit(model.AuditHardDelete, model.ResourcePekerjaan), service.DeletePekerjaan)
+    pekerjaan.Delete("/hard/:id", middleware.AdminOnly(), middleware.Audit(model.AuditHardDelete, model.ResourcePekerjaan), service.DeletePekerjaan)
 
 	file := protected.Group("/file")
 	fileRepo := repository.NewFileRepository(database.DB)
-	fileService := service.NewFileService(fileRepo)
+	fileService := service.NewFileService(fileRepo, cfg.Upload)
 	file.Post("/foto", func(c *fiber.Ctx) error {
 		return fileService.UploadFile(c, "foto")
 	})
@@ -105,6 +144,33 @@ func SetupRoutes(app *fiber.App) {
     })
 	file.Get("/", fileService.GetAllFiles)
 	file.Get("/:id", fileService.GetFileByID)
-	file.Delete("/:id", fileService.DeleteFile)
-
+	file.Get("/:id/download", middleware.Audit(model.AuditFileDownload, model.ResourceFiles), fileService.DownloadFile)
+	file.Put("/:id/verify", middleware.AdminOnly(), fileService.VerifyFile)
+	file.Delete("/:id", middleware.Audit(model.AuditHardDelete, model.ResourceFiles), fileService.DeleteFile)
+
+	// === ADMIN ===
+	admin := protected.Group("/admin", middleware.AdminOnly())
+	admin.Get("/log-level", service.GetLogLevel)
+	admin.Put("/log-level", service.SetLogLevel)
+
+	configService := service.NewConfigService(cfg)
+	admin.Get("/config", configService.GetConfig)
+	admin.Get("/users", service.GetUsers)
+	admin.Put("/users/:id/role", service.UpdateUserRole)
+	admin.Get("/alumni/duplicates", service.GetAlumniDuplicates)
+	admin.Post("/alumni/duplicates/dismiss", service.DismissAlumniDuplicate)
+	admin.Post("/custom-fields", service.CreateCustomField)
+	admin.Put("/custom-fields/:id", service.UpdateCustomField)
+	admin.Delete("/custom-fields/:id", middleware.Audit(model.AuditHardDelete, model.ResourceCustomFields), service.DeleteCustomField)
+	admin.Post("/segments", service.CreateSegment)
+	admin.Put("/segments/:id", service.UpdateSegment)
+	admin.Delete("/segments/:id", middleware.Audit(model.AuditHardDelete, model.ResourceSegments), service.DeleteSegment)
+
+	// audit log: ditulis middleware.Audit di route di atas dan oleh service
+	admin.Get("/audit", service.GetAuditLog)
+	admin.Get("/audit/export", middleware.Audit(model.AuditExport, model.ResourceAuditLog), service.ExportAuditLog)
+	admin.Get("/audit/verify", service.VerifyAuditLog)
+
+	// laporan purge trash; scheduler-nya dijalankan dari main
+	admin.Get("/trash/purge-report", purge.GetPurgeReport)
 }
@@ -0,0 +1,98 @@
+// Package tabular membaca (CSV, XLSX) dan menulis (CSV, XLSX, PDF) data
+// tabel untuk import dan export alumni.
+package tabular
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ErrUnsupportedFormat - ekstensi file bukan .csv atau .xlsx
+var ErrUnsupportedFormat = errors.New("format file harus csv atau xlsx")
+
+// Format menentukan format dari nama file ("csv" / "xlsx")
+func Format(filename string) (string, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return "csv", nil
+	case ".xlsx":
+		return "xlsx", nil
+	}
+	return "", ErrUnsupportedFormat
+}
+
+// Read membaca seluruh baris file CSV atau XLSX (sheet pertama). Baris
+// pertama adalah header. Baris kosong di akhir dibuang, awalan ' dari
+// EscapeFormula di CSV dibuang.
+func Read(data []byte, format string) ([][]string, error) {
+	var rows [][]string
+	var err error
+	switch format {
+	case "csv":
+		rows, err = readCSV(data)
+	case "xlsx":
+		rows, err = readXLSX(data)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	for len(rows) > 0 && isBlank(rows[len(rows)-1]) {
+		rows = rows[:len(rows)-1]
+	}
+	return rows, nil
+}
+
+func readCSV(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")) // BOM dari Excel
+
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comma = detectDelimiter(data)
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	rows, err := r.ReadAll()
+	for _, row := range rows {
+		for i, cell := range row {
+			row[i] = unescapeFormula(cell)
+		}
+	}
+	return rows, err
+}
+
+// detectDelimiter - Excel dengan locale Indonesia menyimpan CSV memakai ";"
+func detectDelimiter(data []byte) rune {
+	header, _, _ := bytes.Cut(data, []byte("\n"))
+	if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		return ';'
+	}
+	return ','
+}
+
+func readXLSX(data []byte) ([][]string, error) {
+	f, err := excelize.OpenReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, nil
+	}
+	return f.GetRows(sheets[0])
+}
+
+func isBlank(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,54 @@
+package tabular
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestRead_CSVDelimiters(t *testing.T) {
+	want := [][]string{{"nim", "nama"}, {"123", "Budi, S.Kom"}}
+	inputs := map[string]string{
+		"koma":       "nim,nama\n123,\"Budi, S.Kom\"\n\n",
+		"titik koma": "\xef\xbb\xbfnim;nama\r\n123;Budi, S.Kom\r\n",
+	}
+	for name, in := range inputs {
+		got, err := Read([]byte(in), "csv")
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestRead_XLSX(t *testing.T) {
+	f := excelize.NewFile()
+	f.SetSheetRow("Sheet1", "A1", &[]any{"nim", "angkatan"})
+	f.SetSheetRow("Sheet1", "A2", &[]any{"123", 2020})
+	var buf bytes.Buffer
+	if err := f.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Read(buf.Bytes(), "xlsx")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	want := [][]string{{"nim", "angkatan"}, {"123", "2020"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	if f, err := Format("Lulusan 2025.XLSX"); err != nil || f != "xlsx" {
+		t.Errorf("expected xlsx, got %q, %v", f, err)
+	}
+	if _, err := Format("data.xls"); err != ErrUnsupportedFormat {
+		t.Errorf("expected ErrUnsupportedFormat, got %v", err)
+	}
+}
@@ -0,0 +1,250 @@
+package tabular
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/xuri/excelize/v2"
+)
+
+// Writer menulis tabel baris demi baris. Baris pertama yang ditulis adalah
+// header. Close wajib dipanggil untuk menyelesaikan file; Close tidak
+// menutup io.Writer tujuan.
+type Writer interface {
+	Write(row []string) error
+	Close() error
+}
+
+// PDFHeader - judul dan keterangan (mis. filter yang dipakai) di atas tabel PDF
+type PDFHeader struct {
+	Title string
+	Lines []string
+}
+
+// ContentType - MIME type untuk format export
+func ContentType(format string) string {
+	switch format {
+	case "csv":
+		return "text/csv; charset=utf-8"
+	case "xlsx":
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case "pdf":
+		return "application/pdf"
+	}
+	return "application/octet-stream"
+}
+
+// NewWriter membuat Writer untuk format "csv", "xlsx", atau "pdf".
+//
+// CSV langsung ditulis ke w. XLSX memakai stream writer excelize (baris yang
+// banyak ditampung di file sementara, bukan memori) dan baru ditulis ke w saat
+// Close. PDF disusun di memori lalu ditulis saat Close, jadi pemanggil perlu
+// membatasi jumlah barisnya.
+func NewWriter(w io.Writer, format string, header PDFHeader) (Writer, error) {
+	switch format {
+	case "csv":
+		return newCSVWriter(w)
+	case "xlsx":
+		return newXLSXWriter(w)
+	case "pdf":
+		return newPDFWriter(w, header), nil
+	}
+	return nil, ErrUnsupportedFormat
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) (*csvWriter, error) {
+	// BOM supaya Excel membaca file sebagai UTF-8
+	if _, err := io.WriteString(w, "\xef\xbb\xbf"); err != nil {
+		return nil, err
+	}
+	return &csvWriter{w: csv.NewWriter(w)}, nil
+}
+
+func (c *csvWriter) Write(row []string) error {
+	escaped := make([]string, len(row))
+	for i, v := range row {
+		escaped[i] = EscapeFormula(v)
+	}
+	return c.w.Write(escaped)
+}
+
+// formulaPrefix - karakter awal sel yang dibaca spreadsheet sebagai formula
+const formulaPrefix = "=+-@\t\r"
+
+// EscapeFormula mencegah CSV injection: sel yang diawali = + - @ (atau tab /
+// CR) diberi awalan ' supaya Excel/LibreOffice menampilkannya sebagai teks,
+// mis. no_telepon "+6281..." atau nama "=HYPERLINK(...)". Read membuang
+// awalan ini lagi, jadi hasil export tetap bisa di-import ulang.
+func EscapeFormula(v string) string {
+	if v != "" && strings.ContainsRune(formulaPrefix, rune(v[0])) {
+		return "'" + v
+	}
+	return v
+}
+
+// unescapeFormula - kebalikan EscapeFormula
+func unescapeFormula(v string) string {
+	if len(v) > 1 && v[0] == '\'' && strings.ContainsRune(formulaPrefix, rune(v[1])) {
+		return v[1:]
+	}
+	return v
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+type xlsxWriter struct {
+	out    io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+func newXLSXWriter(w io.Writer) (*xlsxWriter, error) {
+	f := excelize.NewFile()
+	stream, err := f.NewStreamWriter(f.GetSheetName(0))
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &xlsxWriter{out: w, file: f, stream: stream}, nil
+}
+
+// Write menulis setiap nilai sebagai teks (inline string), bukan formula,
+// jadi sel seperti "+6281..." atau "=1+1" tidak perlu di-escape
+func (x *xlsxWriter) Write(row []string) error {
+	x.row++
+	cell, err := excelize.CoordinatesToCellName(1, x.row)
+	if err != nil {
+		return err
+	}
+	values := make([]any, len(row))
+	for i, v := range row {
+		values[i] = v
+	}
+	return x.stream.SetRow(cell, values)
+}
+
+func (x *xlsxWriter) Close() error {
+	defer x.file.Close()
+	if err := x.stream.Flush(); err != nil {
+		return err
+	}
+	return x.file.Write(x.out)
+}
+
+const (
+	pdfFontSize   = 8
+	pdfLineHeight = 5
+)
+
+type pdfWriter struct {
+	out    io.Writer
+	pdf    *fpdf.Fpdf
+	tr     func(string) string
+	header []string
+	widths []float64
+}
+
+func newPDFWriter(w io.Writer, header PDFHeader) *pdfWriter {
+	pdf := fpdf.New("L", "mm", "A4", "")
+	pdf.SetMargins(10, 10, 10)
+	pdf.SetAutoPageBreak(true, 12)
+	p := &pdfWriter{out: w, pdf: pdf, tr: pdf.UnicodeTranslatorFromDescriptor("")}
+
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-10)
+		pdf.SetFont("Helvetica", "", pdfFontSize)
+		pdf.CellFormat(0, pdfLineHeight, strconv.Itoa(pdf.PageNo()), "", 0, "R", false, 0, "")
+	})
+	// header tabel diulang di setiap halaman baru
+	pdf.SetHeaderFunc(func() {
+		if pdf.PageNo() > 1 && p.header != nil {
+			p.row(p.header, true)
+		}
+	})
+
+	pdf.AddPage()
+	pdf.SetFont("Helvetica", "B", 14)
+	pdf.CellFormat(0, 8, p.tr(header.Title), "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", pdfFontSize+1)
+	for _, line := range header.Lines {
+		pdf.CellFormat(0, pdfLineHeight, p.tr(line), "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(2)
+	return p
+}
+
+func (p *pdfWriter) Write(row []string) error {
+	if p.header == nil {
+		p.header = row
+		p.widths = p.columnWidths(row)
+		p.row(row, true)
+		return p.pdf.Error()
+	}
+	p.row(row, false)
+	return p.pdf.Error()
+}
+
+// row menulis satu baris tabel; teks yang terlalu panjang dipotong
+func (p *pdfWriter) row(cells []string, bold bool) {
+	style := ""
+	if bold {
+		style = "B"
+	}
+	p.pdf.SetFont("Helvetica", style, pdfFontSize)
+	p.pdf.SetFillColor(230, 230, 230)
+	for i, cell := range cells {
+		if i >= len(p.widths) {
+			break
+		}
+		text := p.fit(p.tr(cell), p.widths[i]-2)
+		p.pdf.CellFormat(p.widths[i], pdfLineHeight+1, text, "1", 0, "L", bold, 0, "")
+	}
+	p.pdf.Ln(-1)
+}
+
+// columnWidths membagi lebar halaman; kolom teks panjang mendapat porsi lebih
+func (p *pdfWriter) columnWidths(header []string) []float64 {
+	weight := map[string]float64{"nama": 2, "email": 2, "alamat": 3, "jurusan": 1.5}
+	var total float64
+	weights := make([]float64, len(header))
+	for i, h := range header {
+		weights[i] = 1
+		if w, ok := weight[h]; ok {
+			weights[i] = w
+		}
+		total += weights[i]
+	}
+	pageW, _ := p.pdf.GetPageSize()
+	left, _, right, _ := p.pdf.GetMargins()
+	usable := pageW - left - right
+	widths := make([]float64, len(header))
+	for i := range weights {
+		widths[i] = usable * weights[i] / total
+	}
+	return widths
+}
+
+func (p *pdfWriter) fit(text string, width float64) string {
+	if p.pdf.GetStringWidth(text) <= width {
+		return text
+	}
+	for len(text) > 0 && p.pdf.GetStringWidth(text+"...") > width {
+		text = text[:len(text)-1]
+	}
+	return text + "..."
+}
+
+func (p *pdfWriter) Close() error {
+	return p.pdf.Output(p.out)
+}
@@ -0,0 +1,87 @@
+package tabular
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func writeAll(t *testing.T, format string, rows [][]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, format, PDFHeader{Title: "Data Alumni", Lines: []string{"Jurusan: Teknik Informatika"}})
+	if err != nil {
+		t.Fatalf("NewWriter: %v", err)
+	}
+	for _, row := range rows {
+		if err := w.Write(row); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestWriter_RoundTrip(t *testing.T) {
+	rows := [][]string{{"nim", "nama"}, {"123", "Budi, S.Kom"}, {"124", "Siti Aisyah"}}
+	for _, format := range []string{"csv", "xlsx"} {
+		got, err := Read(writeAll(t, format, rows), format)
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		if !reflect.DeepEqual(got, rows) {
+			t.Errorf("%s: got %q, want %q", format, got, rows)
+		}
+	}
+}
+
+func TestWriter_FormulaCells(t *testing.T) {
+	rows := [][]string{{"nim", "no_telepon", "nama"}, {"123", "+6281234567890", "=HYPERLINK(\"http://x\")"}, {"124", "-5", "@SUM(A1)"}}
+
+	out := writeAll(t, "csv", rows)
+	if !bytes.Contains(out, []byte("'+6281234567890")) || !bytes.Contains(out, []byte(`"'=HYPERLINK(""http://x"")"`)) || !bytes.Contains(out, []byte("'@SUM(A1)")) {
+		t.Errorf("csv cells must be escaped, got %s", out)
+	}
+
+	xlsx := writeAll(t, "xlsx", rows)
+	f, err := excelize.OpenReader(bytes.NewReader(xlsx))
+	if err != nil {
+		t.Fatalf("open xlsx: %v", err)
+	}
+	defer f.Close()
+	if formula, _ := f.GetCellFormula(f.GetSheetName(0), "C2"); formula != "" {
+		t.Errorf("xlsx cell must be text, got formula %q", formula)
+	}
+
+	// export bisa di-import ulang tanpa awalan '
+	for _, format := range []string{"csv", "xlsx"} {
+		got, err := Read(writeAll(t, format, rows), format)
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		if !reflect.DeepEqual(got, rows) {
+			t.Errorf("%s: got %q, want %q", format, got, rows)
+		}
+	}
+}
+
+func TestWriter_PDF(t *testing.T) {
+	rows := [][]string{{"nim", "nama", "alamat"}}
+	for i := 0; i < 120; i++ { // cukup untuk beberapa halaman
+		rows = append(rows, []string{"123", "Ñoño Pérez", "Jl. yang sangat panjang sekali sampai harus dipotong supaya muat di kolom alamat tabel"})
+	}
+	out := writeAll(t, "pdf", rows)
+	if !bytes.HasPrefix(out, []byte("%PDF-")) {
+		t.Fatalf("expected PDF output, got %q", out[:min(len(out), 16)])
+	}
+}
+
+func TestNewWriter_UnsupportedFormat(t *testing.T) {
+	if _, err := NewWriter(&bytes.Buffer{}, "xls", PDFHeader{}); err != ErrUnsupportedFormat {
+		t.Errorf("expected ErrUnsupportedFormat, got %v", err)
+	}
+}
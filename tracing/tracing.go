@@ -0,0 +1,100 @@
+// Package tracing mengatur OpenTelemetry: tracer provider, exporter, dan
+// propagasi W3C trace-context untuk request Fiber, service, dan MongoDB.
+package tracing
+
+import (
+	"context"
+	"crud_alumni/config"
+	"errors"
+	"io"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "crud_alumni"
+
+// Init memasang tracer provider global sesuai cfg.Exporter (OTEL_TRACES_EXPORTER):
+//
+//	none   (default) tracing nonaktif
+//	otlp   kirim ke collector via OTLP/HTTP; endpoint, header, dan TLS diatur
+//	       lewat env standar OTEL_EXPORTER_OTLP_* (mis. OTEL_EXPORTER_OTLP_ENDPOINT)
+//	stdout tulis span ke stdout (pretty JSON)
+//	file   tulis span ke cfg.File (OTEL_TRACES_FILE, default logs/traces.json)
+//
+// Sampler mengikuti env standar OTEL_TRACES_SAMPLER / OTEL_TRACES_SAMPLER_ARG.
+// Fungsi yang dikembalikan harus dipanggil saat shutdown untuk flush span.
+func Init(ctx context.Context, cfg config.TracingConfig) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	exporterName := strings.ToLower(cfg.Exporter)
+	var (
+		exporter sdktrace.SpanExporter
+		closer   io.Closer
+		err      error
+	)
+
+	switch exporterName {
+	case "none", "":
+		return func(context.Context) error { return nil }, nil
+	case "otlp":
+		exporter, err = otlptracehttp.New(ctx)
+	case "stdout":
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case "file":
+		var f *os.File
+		f, err = os.OpenFile(cfg.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+		if err == nil {
+			closer = f
+			exporter, err = stdouttrace.New(stdouttrace.WithWriter(f))
+		}
+	default:
+		return nil, errors.New("OTEL_TRACES_EXPORTER tidak dikenal: " + exporterName)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(cfg.ServiceName),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+
+	return func(ctx context.Context) error {
+		err := tp.Shutdown(ctx)
+		if closer != nil {
+			closer.Close()
+		}
+		return err
+	}, nil
+}
+
+// Tracer mengembalikan tracer aplikasi dari provider global
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// Start membuka span baru sebagai child dari span di ctx
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, opts...)
+}
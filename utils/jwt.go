@@ -2,27 +2,37 @@ package utils
 
 import (
 	"crud_alumni/app/model"
+	"crud_alumni/config"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
-var jwtSecret = []byte("secret-key-panjang-minimal-32-char")
+// jwtConfig - diisi ConfigureJWT saat startup (JWT_SECRET, JWT_TTL)
+var jwtConfig = config.Defaults().JWT
 
+func ConfigureJWT(cfg config.JWTConfig) {
+    jwtConfig = cfg
+}
+
+func jwtSecret() []byte {
+    return []byte(jwtConfig.Secret)
+}
 
 func GenerateToken(user model.User) (string, error) {
+    ttl := jwtConfig.TTL
     claims := model.JWTClaims{
         UserID:   user.ID.Hex(), // ubah ObjectID ke string Hex
         Username: user.Username,
         Role:     user.Role,
         RegisteredClaims: jwt.RegisteredClaims{
-            ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+            ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
             IssuedAt:  jwt.NewNumericDate(time.Now()),
         },
     }
 
     token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-    return token.SignedString(jwtSecret)
+    return token.SignedString(jwtSecret())
 }
 
 // func GenerateToken(user model.User) (string, error) {
@@ -42,7 +52,7 @@ func GenerateToken(user model.User) (string, error) {
 
 func ValidateToken(tokenString string) (*model.JWTClaims, error) {
     token, err := jwt.ParseWithClaims(tokenString, &model.JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-        return jwtSecret, nil
+        return jwtSecret(), nil
     })
     if err != nil {
         return nil, err
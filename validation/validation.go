@@ -0,0 +1,225 @@
+// Package validation - validasi deklaratif lewat tag `validate` pada struct
+// model. Semua pelanggaran dikumpulkan sekaligus sebagai apperror.FieldError
+// (nama field mengikuti tag json) supaya bisa dikembalikan dalam satu 422.
+//
+// Aturan yang tersedia:
+//
+//	required      wajib diisi (string tidak boleh kosong/spasi, angka tidak boleh 0)
+//	min=N, max=N  panjang string (karakter), nilai angka, atau jumlah item slice
+//	email         format alamat email
+//	nim           format NIM sesuai config alumni.nim_pattern
+//	jurusan       salah satu jurusan di config alumni.jurusan
+//	year          tahun antara config alumni.min_year dan tahun sekarang
+//	date          tanggal format YYYY-MM-DD
+//	gtefield=F    nilai >= field F pada struct yang sama (angka atau tanggal)
+//	oneof=A B     salah satu nilai yang dipisah spasi
+//	phone         nomor telepon yang bisa dinormalisasi ke E.164 (lihat package phone)
+//	slug          huruf kecil, angka, dan _, diawali huruf (mis. nama custom field)
+//	tags          setiap item slice string tidak kosong dan paling banyak TagMaxLen karakter
+//
+// Field yang kosong dan tidak required tidak dicek aturan lainnya. Setiap
+// field paling banyak menghasilkan satu error.
+package validation
+
+import (
+	"crud_alumni/apperror"
+	"crud_alumni/config"
+	"crud_alumni/phone"
+	"fmt"
+	"net/mail"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Struct memvalidasi s (struct atau pointer ke struct) dan mengembalikan
+// apperror.Validation jika ada pelanggaran, atau nil
+func Struct(s any) error {
+	if fields := Fields(s); len(fields) > 0 {
+		return apperror.Validation(fields...)
+	}
+	return nil
+}
+
+// Fields mengembalikan semua pelanggaran aturan pada s
+func Fields(s any) []apperror.FieldError {
+	v := reflect.Indirect(reflect.ValueOf(s))
+	t := v.Type()
+
+	var out []apperror.FieldError
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("validate")
+		if tag == "" || tag == "-" {
+			continue
+		}
+		fv := deref(v.Field(i))
+		name := jsonName(sf)
+
+		rules := strings.Split(tag, ",")
+		if isZero(fv) {
+			for _, r := range rules {
+				if r == "required" {
+					out = append(out, apperror.FieldError{Field: name, Code: "required"})
+				}
+			}
+			continue
+		}
+
+		for _, r := range rules {
+			rule, param, _ := strings.Cut(r, "=")
+			if rule == "required" {
+				continue
+			}
+			// cukup satu pesan per field: aturan pertama yang gagal
+			if fe := check(v, fv, rule, param); fe != nil {
+				fe.Field = name
+				out = append(out, *fe)
+				break
+			}
+		}
+	}
+	return out
+}
+
+func check(parent, fv reflect.Value, rule, param string) *apperror.FieldError {
+	switch rule {
+	case "min", "max":
+		n, _ := strconv.Atoi(param)
+		if fv.Kind() == reflect.String {
+			length := utf8.RuneCountInString(strings.TrimSpace(fv.String()))
+			if (rule == "min" && length < n) || (rule == "max" && length > n) {
+				return &apperror.FieldError{Code: rule + "_len", Args: []any{n}}
+			}
+			return nil
+		}
+		if fv.Kind() == reflect.Slice {
+			if count := fv.Len(); (rule == "min" && count < n) || (rule == "max" && count > n) {
+				return &apperror.FieldError{Code: rule + "_items", Args: []any{n}}
+			}
+			return nil
+		}
+		if val := toInt(fv); (rule == "min" && val < int64(n)) || (rule == "max" && val > int64(n)) {
+			return &apperror.FieldError{Code: rule, Args: []any{n}}
+		}
+	case "email":
+		s := strings.TrimSpace(fv.String())
+		addr, err := mail.ParseAddress(s)
+		if err != nil || addr.Address != s || !strings.Contains(s[strings.LastIndex(s, "@"):], ".") {
+			return &apperror.FieldError{Code: "email"}
+		}
+	case "nim":
+		if !nimPattern.MatchString(strings.TrimSpace(fv.String())) {
+			return &apperror.FieldError{Code: "nim"}
+		}
+	case "jurusan":
+		allowed := alumniRules.Jurusan
+		for _, j := range allowed {
+			if strings.EqualFold(strings.TrimSpace(fv.String()), j) {
+				return nil
+			}
+		}
+		return &apperror.FieldError{Code: "jurusan", Args: []any{strings.Join(allowed, ", ")}}
+	case "year":
+		minYear, maxYear := int64(alumniRules.MinYear), int64(time.Now().Year())
+		if val := toInt(fv); val < minYear || val > maxYear {
+			return &apperror.FieldError{Code: "year", Args: []any{minYear, maxYear}}
+		}
+	case "date":
+		if _, err := time.Parse("2006-01-02", strings.TrimSpace(fv.String())); err != nil {
+			return &apperror.FieldError{Code: "date"}
+		}
+	case "gtefield":
+		other, ok := parent.Type().FieldByName(param)
+		if !ok {
+			panic(fmt.Sprintf("validation: field %s tidak ada", param))
+		}
+		otherVal := deref(parent.FieldByIndex(other.Index))
+		if isZero(otherVal) {
+			return nil
+		}
+		less := toInt(fv) < toInt(otherVal)
+		if fv.Kind() == reflect.String {
+			less = strings.TrimSpace(fv.String()) < strings.TrimSpace(otherVal.String())
+		}
+		if less {
+			return &apperror.FieldError{Code: "gtefield", Args: []any{jsonName(other)}}
+		}
+	case "oneof":
+		allowed := strings.Fields(param)
+		for _, a := range allowed {
+			if strings.TrimSpace(fv.String()) == a {
+				return nil
+			}
+		}
+		return &apperror.FieldError{Code: "oneof", Args: []any{strings.Join(allowed, ", ")}}
+	case "slug":
+		if !slugPattern.MatchString(fv.String()) {
+			return &apperror.FieldError{Code: "slug"}
+		}
+	case "tags":
+		for i := 0; i < fv.Len(); i++ {
+			if n := utf8.RuneCountInString(strings.TrimSpace(fv.Index(i).String())); n == 0 || n > TagMaxLen {
+				return &apperror.FieldError{Code: "tag", Args: []any{TagMaxLen}}
+			}
+		}
+	case "phone":
+		if _, err := phone.Normalize(fv.String()); err != nil {
+			return &apperror.FieldError{Code: "phone"}
+		}
+	default:
+		panic("validation: aturan tidak dikenal: " + rule)
+	}
+	return nil
+}
+
+var slugPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// TagMaxLen - panjang maksimal satu tag (aturan tags)
+const TagMaxLen = 50
+
+var (
+	alumniRules = config.Defaults().Alumni
+	nimPattern  = regexp.MustCompile(alumniRules.NIMPattern)
+)
+
+// Configure menerapkan aturan alumni (ALUMNI_*) dari konfigurasi yang sudah
+// divalidasi; dipanggil sekali saat startup
+func Configure(cfg config.AlumniConfig) {
+	alumniRules = cfg
+	nimPattern = regexp.MustCompile(cfg.NIMPattern)
+}
+
+// deref mengikuti pointer; pointer nil dikembalikan apa adanya (dianggap kosong)
+func deref(v reflect.Value) reflect.Value {
+	if v.Kind() == reflect.Pointer && !v.IsNil() {
+		return v.Elem()
+	}
+	return v
+}
+
+func isZero(v reflect.Value) bool {
+	if v.Kind() == reflect.String {
+		return strings.TrimSpace(v.String()) == ""
+	}
+	return v.IsZero()
+}
+
+func toInt(v reflect.Value) int64 {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	}
+	return 0
+}
+
+func jsonName(sf reflect.StructField) string {
+	name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return sf.Name
+	}
+	return name
+}
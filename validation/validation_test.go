@@ -0,0 +1,176 @@
+package validation
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"crud_alumni/app/model"
+	"crud_alumni/apperror"
+)
+
+func validAlumni() model.Alumni {
+	return model.Alumni{
+		NIM:        "434221001",
+		Nama:       "Budi Santoso",
+		Jurusan:    "Teknik Informatika",
+		Angkatan:   2018,
+		TahunLulus: 2022,
+		Email:      "budi@example.com",
+	}
+}
+
+func codes(fields []apperror.FieldError) map[string]string {
+	out := map[string]string{}
+	for _, f := range fields {
+		out[f.Field] = f.Code
+	}
+	return out
+}
+
+func TestStruct_Valid(t *testing.T) {
+	a := validAlumni()
+	if err := Struct(&a); err != nil {
+		t.Fatalf("expected valid, got %v", err)
+	}
+	a.Jurusan = "teknik informatika"
+	if err := Struct(a); err != nil {
+		t.Errorf("jurusan harus case-insensitive, got %v", err)
+	}
+}
+
+func TestStruct_ReportsAllFields(t *testing.T) {
+	a := model.Alumni{
+		NIM:        "!!",
+		Nama:       "   ",
+		Jurusan:    "Kedokteran",
+		Angkatan:   2020,
+		TahunLulus: 2019,
+		Email:      "bukan-email",
+	}
+
+	err := Struct(a)
+	var appErr *apperror.Error
+	if !errors.As(err, &appErr) {
+		t.Fatalf("expected *apperror.Error, got %v", err)
+	}
+	if appErr.Status != http.StatusUnprocessableEntity {
+		t.Errorf("expected status 422, got %d", appErr.Status)
+	}
+
+	want := map[string]string{
+		"nim":         "nim",
+		"nama":        "required",
+		"jurusan":     "jurusan",
+		"tahun_lulus": "gtefield",
+		"email":       "email",
+	}
+	got := codes(appErr.Fields)
+	for field, code := range want {
+		if got[field] != code {
+			t.Errorf("field %s: expected code %s, got %q", field, code, got[field])
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("expected %d field error, got %v", len(want), got)
+	}
+}
+
+func TestFields_Rules(t *testing.T) {
+	cases := []struct {
+		name   string
+		mutate func(a *model.Alumni)
+		field  string
+		code   string
+	}{
+		{"email tanpa domain bertitik", func(a *model.Alumni) { a.Email = "budi@localhost" }, "email", "email"},
+		{"email dengan nama tampilan", func(a *model.Alumni) { a.Email = "Budi <budi@example.com>" }, "email", "email"},
+		{"angkatan terlalu lama", func(a *model.Alumni) { a.Angkatan = 1900 }, "angkatan", "year"},
+		{"tahun lulus di masa depan", func(a *model.Alumni) { a.TahunLulus = time.Now().Year() + 1 }, "tahun_lulus", "year"},
+		{"nama terlalu panjang", func(a *model.Alumni) { a.Nama = strings.Repeat("a", 151) }, "nama", "max_len"},
+		{"angkatan kosong", func(a *model.Alumni) { a.Angkatan = 0 }, "angkatan", "required"},
+		{"nomor telepon berisi huruf", func(a *model.Alumni) { a.NoTelepon = "0812-abcd-123" }, "no_telepon", "phone"},
+		{"nomor telepon terlalu pendek", func(a *model.Alumni) { a.NoTelepon = "0812" }, "no_telepon", "phone"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := validAlumni()
+			tc.mutate(&a)
+			got := codes(Fields(a))
+			if got[tc.field] != tc.code {
+				t.Errorf("expected %s=%s, got %v", tc.field, tc.code, got)
+			}
+		})
+	}
+}
+
+func TestFields_PekerjaanDates(t *testing.T) {
+	selesai := "2020-01-01"
+	p := model.Pekerjaan{
+		AlumniID:            1,
+		NamaPerusahaan:      "PT Maju",
+		PosisiJabatan:       "Backend Engineer",
+		BidangIndustri:      "Teknologi",
+		LokasiKerja:         "Medan",
+		TanggalMulaiKerja:   "2021-03-01",
+		TanggalSelesaiKerja: &selesai,
+		StatusPekerjaan:     "aktif",
+	}
+	if got := codes(Fields(p)); got["tanggal_selesai_kerja"] != "gtefield" {
+		t.Errorf("expected tanggal_selesai_kerja=gtefield, got %v", got)
+	}
+
+	selesai = "01-02-2022"
+	if got := codes(Fields(p)); got["tanggal_selesai_kerja"] != "date" {
+		t.Errorf("expected tanggal_selesai_kerja=date, got %v", got)
+	}
+
+	p.TanggalSelesaiKerja = nil
+	if got := Fields(p); len(got) != 0 {
+		t.Errorf("expected valid, got %v", got)
+	}
+}
+
+func TestFields_OneOf(t *testing.T) {
+	req := model.RoleUpdateRequest{Role: "superadmin"}
+	if got := codes(Fields(req)); got["role"] != "oneof" {
+		t.Errorf("expected role=oneof, got %v", got)
+	}
+	req.Role = "admin"
+	if got := Fields(req); len(got) != 0 {
+		t.Errorf("expected no errors, got %v", got)
+	}
+}
+
+func TestFields_CustomFieldDefinition(t *testing.T) {
+	f := model.CustomField{Name: "Judul Skripsi", Type: "teks", Visibility: "public"}
+	got := codes(Fields(f))
+	if got["name"] != "slug" || got["type"] != "oneof" {
+		t.Errorf("expected name=slug and type=oneof, got %v", got)
+	}
+	f.Name, f.Type = "judul_skripsi", model.CustomFieldString
+	if got := Fields(f); len(got) != 0 {
+		t.Errorf("expected valid, got %v", got)
+	}
+}
+
+func TestFields_Tags(t *testing.T) {
+	a := validAlumni()
+	a.Tags = []string{"penerima beasiswa", " ", strings.Repeat("x", TagMaxLen+1)}
+	if got := codes(Fields(a)); got["tags"] != "tag" {
+		t.Errorf("expected tags=tag, got %v", got)
+	}
+	a.Tags = make([]string, 21)
+	for i := range a.Tags {
+		a.Tags[i] = "tag"
+	}
+	if got := codes(Fields(a)); got["tags"] != "max_items" {
+		t.Errorf("expected tags=max_items, got %v", got)
+	}
+	a.Tags = []string{"narasumber"}
+	if got := Fields(a); len(got) != 0 {
+		t.Errorf("expected valid, got %v", got)
+	}
+}